@@ -0,0 +1,27 @@
+// Package api holds the definition of the task API served by
+// examples/04-rest-api: openapi.json, an OpenAPI 3.1 document.
+//
+// The server checks its traffic against the document with
+// openapi.Document.Middleware, and the client in api/client is
+// generated from it by cmd/apigen, so the three cannot drift apart
+// without a check failing.
+package api
+
+import (
+	_ "embed"
+	"sync"
+
+	"golang-learning-project/api/openapi"
+)
+
+//go:embed openapi.json
+var spec []byte
+
+// Spec returns the document's bytes, for serving as they are.
+func Spec() []byte { return spec }
+
+// Document returns the loaded document. It is loaded once; an error
+// means openapi.json is broken.
+var Document = sync.OnceValues(func() (*openapi.Document, error) {
+	return openapi.Load(spec)
+})
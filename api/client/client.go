@@ -0,0 +1,176 @@
+// Package client is a typed Go client for the task API defined in
+// api/openapi.json.
+//
+// The types and the operation methods are generated into client_gen.go
+// by cmd/apigen; this file holds the parts written by hand. Rerun the
+// generator after changing the document:
+//
+//	go generate ./api/client
+//
+// Every method returns its successful responses as a Result and every
+// other response as a *Problem error, which matches the pkg/errs
+// sentinels for its status:
+//
+//	_, err := c.GetTask(ctx, 42, client.GetTaskParams{})
+//	if errors.Is(err, errs.ErrNotFound) { ... }
+package client
+
+//go:generate go run ../../cmd/apigen -o client_gen.go
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang-learning-project/pkg/errs"
+)
+
+// Client sends requests to one server. A session cookie from Login is
+// kept only if HTTPClient has a cookie jar, and then unsafe requests
+// need the session's CSRF token in Header.
+type Client struct {
+	BaseURL    string       // such as http://localhost:8080
+	HTTPClient *http.Client // nil means http.DefaultClient
+	Token      string       // an access token to send as Authorization: Bearer
+	Header     http.Header  // sent with every request
+}
+
+// New returns a Client for the server at baseURL.
+func New(baseURL string, hc *http.Client) *Client {
+	return &Client{BaseURL: strings.TrimSuffix(baseURL, "/"), HTTPClient: hc}
+}
+
+// do sends a request. query, header and body may be nil; a body is sent
+// as JSON with the given Content-Type.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, header http.Header, contentType string, body any) (*http.Response, error) {
+	var r io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("%s %s: encoding body: %w", method, path, err)
+		}
+		r = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+path, r)
+	if err != nil {
+		return nil, err
+	}
+	if len(query) > 0 {
+		req.URL.RawQuery = query.Encode()
+	}
+	maps.Copy(req.Header, c.Header)
+	maps.Copy(req.Header, header)
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	hc := c.HTTPClient
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	return hc.Do(req)
+}
+
+// decodeBody decodes a successful response into v. JSON members the
+// client does not know are an error: the server has drifted from the
+// document the client was generated from.
+func decodeBody(resp *http.Response, v any) error {
+	req := resp.Request
+	if s, ok := v.(*string); ok {
+		data, err := io.ReadAll(resp.Body)
+		*s = string(data)
+		return err
+	}
+	dec := json.NewDecoder(resp.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("%s %s: decoding %d response: %w", req.Method, req.URL.Path, resp.StatusCode, err)
+	}
+	return nil
+}
+
+// errorFrom turns an unsuccessful response into a *Problem. A response
+// that is not a problem document still gets one, from its status.
+func errorFrom(resp *http.Response) error {
+	p := &Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(resp.StatusCode),
+		Status: resp.StatusCode,
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if mt, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); err == nil && mt == "application/problem+json" {
+		json.Unmarshal(data, p)
+	}
+	return p
+}
+
+func (p *Problem) Error() string {
+	msg := fmt.Sprintf("%d %s", p.Status, p.Title)
+	if p.Detail != "" {
+		msg += ": " + p.Detail
+	}
+	for _, ip := range p.InvalidParams {
+		msg += fmt.Sprintf("; %s %s", ip.Name, ip.Reason)
+	}
+	return msg
+}
+
+// Unwrap matches a problem with the pkg/errs sentinel for its status.
+func (p *Problem) Unwrap() error {
+	switch p.Status {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return errs.ErrInvalidArgument
+	case http.StatusUnauthorized:
+		return errs.ErrUnauthenticated
+	case http.StatusForbidden:
+		return errs.ErrPermissionDenied
+	case http.StatusNotFound:
+		return errs.ErrNotFound
+	case http.StatusConflict, http.StatusPreconditionFailed, http.StatusPreconditionRequired:
+		return errs.ErrConflict
+	}
+	return nil
+}
+
+// Nullable is a member that JSON merge patch can leave out, set, or set
+// to null, which resets it. The zero value leaves it out.
+type Nullable[T any] struct {
+	Value T
+	Set   bool // the member is sent
+	Null  bool // it is sent as null
+}
+
+// Some returns a Nullable that sets the member to v.
+func Some[T any](v T) Nullable[T] { return Nullable[T]{Value: v, Set: true} }
+
+// Null returns a Nullable that resets the member.
+func Null[T any]() Nullable[T] { return Nullable[T]{Set: true, Null: true} }
+
+// IsZero makes the omitzero option leave out an unset member.
+func (n Nullable[T]) IsZero() bool { return !n.Set }
+
+func (n Nullable[T]) MarshalJSON() ([]byte, error) {
+	if n.Null {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.Value)
+}
+
+func (n *Nullable[T]) UnmarshalJSON(b []byte) error {
+	*n = Nullable[T]{Set: true}
+	if string(b) == "null" {
+		n.Null = true
+		return nil
+	}
+	return json.Unmarshal(b, &n.Value)
+}
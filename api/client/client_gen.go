@@ -0,0 +1,594 @@
+// Code generated by apigen. DO NOT EDIT.
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// SpecDigest identifies the OpenAPI document this file was generated
+// from; a client is stale when it differs from the document's Digest.
+const SpecDigest = "sha256:100b2fcb4ec1b41618c1a7471d766a65b04ba9296f6a7a2d8160cb5190630016"
+
+// Credentials is a user name and password.
+type Credentials struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// InvalidParam is one invalid member of a body or query parameter.
+type InvalidParam struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// Me is the user sending a request.
+type Me struct {
+	User        User     `json:"user"`
+	Permissions []string `json:"permissions"`
+	// Present when a session cookie authenticated the request.
+	CsrfToken string `json:"csrf_token,omitempty"`
+}
+
+// Problem is an error, as defined by RFC 7807.
+type Problem struct {
+	// A URI naming the kind of problem; about:blank when the status says
+	// it all.
+	Type          string         `json:"type"`
+	Title         string         `json:"title"`
+	Status        int            `json:"status"`
+	Detail        string         `json:"detail,omitempty"`
+	Instance      string         `json:"instance,omitempty"`
+	InvalidParams []InvalidParam `json:"invalid-params,omitempty"`
+}
+
+// RefreshRequest is a refresh token to spend or revoke.
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// Role is what a user may do: viewers read tasks, editors also create
+// and change them, admins also delete them.
+type Role string
+
+const (
+	RoleViewer Role = "viewer"
+	RoleEditor Role = "editor"
+	RoleAdmin  Role = "admin"
+)
+
+// Task is a task as the server stores it.
+type Task struct {
+	ID       int64      `json:"id"`
+	Title    string     `json:"title"`
+	Notes    string     `json:"notes"`
+	Status   TaskStatus `json:"status"`
+	Priority int        `json:"priority"`
+	// Absent when the task has no due date.
+	Due string `json:"due,omitempty"`
+	// 1 when created, one more after every change.
+	Version   int64     `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TaskInput is the body that creates or replaces a task. Absent members
+// take their defaults. The read-only members of a Task are accepted and
+// ignored, so a fetched task can be sent back.
+type TaskInput struct {
+	Title    string     `json:"title"`
+	Notes    string     `json:"notes,omitempty"`
+	Status   TaskStatus `json:"status,omitempty"`
+	Priority int        `json:"priority,omitempty"`
+	Due      string     `json:"due,omitempty"`
+}
+
+// TaskPage is one page of a task list.
+type TaskPage struct {
+	Items []Task `json:"items"`
+	// Matching tasks on all pages.
+	Total  int `json:"total"`
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+}
+
+// TaskPatch is a JSON merge patch of a task: absent members are left
+// alone and null resets a member to its default. The title has no
+// default, so it cannot be null.
+type TaskPatch struct {
+	Title    string               `json:"title,omitempty"`
+	Notes    Nullable[string]     `json:"notes,omitzero"`
+	Status   Nullable[TaskStatus] `json:"status,omitzero"`
+	Priority Nullable[int]        `json:"priority,omitzero"`
+	Due      Nullable[string]     `json:"due,omitzero"`
+}
+
+// TaskStatus is how far along a task is.
+type TaskStatus string
+
+const (
+	TaskStatusTodo  TaskStatus = "todo"
+	TaskStatusDoing TaskStatus = "doing"
+	TaskStatusDone  TaskStatus = "done"
+)
+
+// TokenPair is an OAuth 2.0 style token response.
+type TokenPair struct {
+	// A JWT to send as Authorization: Bearer.
+	AccessToken string `json:"access_token"`
+	// A JWT for /auth/refresh; it works once.
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	// Seconds until the access token expires.
+	ExpiresIn int `json:"expires_in"`
+}
+
+// User is someone who has logged in.
+type User struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Role Role   `json:"role"`
+}
+
+// LoginResult is a successful response to Login.
+type LoginResult struct {
+	StatusCode int
+	Body       Me
+	SetCookie  string // the Set-Cookie header
+}
+
+// Login sends POST /auth/login: log in for a session cookie.
+func (c *Client) Login(ctx context.Context, body Credentials) (*LoginResult, error) {
+	resp, err := c.do(ctx, "POST", "/auth/login", nil, nil, "application/json", body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	result := &LoginResult{StatusCode: resp.StatusCode}
+	switch resp.StatusCode {
+	case 200:
+		result.SetCookie = resp.Header.Get("Set-Cookie")
+		err = decodeBody(resp, &result.Body)
+	default:
+		return nil, errorFrom(resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// LogoutResult is a successful response to Logout.
+type LogoutResult struct {
+	StatusCode int
+}
+
+// Logout sends POST /auth/logout: end the session, and the login of a
+// refresh token if one is sent.
+func (c *Client) Logout(ctx context.Context, body *RefreshRequest) (*LogoutResult, error) {
+	var reqBody any
+	if body != nil {
+		reqBody = body
+	}
+	resp, err := c.do(ctx, "POST", "/auth/logout", nil, nil, "application/json", reqBody)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	result := &LogoutResult{StatusCode: resp.StatusCode}
+	switch resp.StatusCode {
+	case 204:
+	default:
+		return nil, errorFrom(resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetMeResult is a successful response to GetMe.
+type GetMeResult struct {
+	StatusCode int
+	Body       Me
+}
+
+// GetMe sends GET /auth/me: describe the user sending the request.
+func (c *Client) GetMe(ctx context.Context) (*GetMeResult, error) {
+	resp, err := c.do(ctx, "GET", "/auth/me", nil, nil, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	result := &GetMeResult{StatusCode: resp.StatusCode}
+	switch resp.StatusCode {
+	case 200:
+		err = decodeBody(resp, &result.Body)
+	default:
+		return nil, errorFrom(resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// RefreshTokenResult is a successful response to RefreshToken.
+type RefreshTokenResult struct {
+	StatusCode   int
+	Body         TokenPair
+	CacheControl string // the Cache-Control header
+}
+
+// RefreshToken sends POST /auth/refresh: spend a refresh token on a new
+// pair of tokens.
+//
+// A refresh token works once. Sending one a second time revokes every
+// token of its login.
+func (c *Client) RefreshToken(ctx context.Context, body RefreshRequest) (*RefreshTokenResult, error) {
+	resp, err := c.do(ctx, "POST", "/auth/refresh", nil, nil, "application/json", body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	result := &RefreshTokenResult{StatusCode: resp.StatusCode}
+	switch resp.StatusCode {
+	case 200:
+		result.CacheControl = resp.Header.Get("Cache-Control")
+		err = decodeBody(resp, &result.Body)
+	default:
+		return nil, errorFrom(resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CreateTokenResult is a successful response to CreateToken.
+type CreateTokenResult struct {
+	StatusCode   int
+	Body         TokenPair
+	CacheControl string // the Cache-Control header
+}
+
+// CreateToken sends POST /auth/token: log in for a pair of tokens.
+//
+// Send the access token as Authorization: Bearer; spend the refresh
+// token at /auth/refresh when it expires.
+func (c *Client) CreateToken(ctx context.Context, body Credentials) (*CreateTokenResult, error) {
+	resp, err := c.do(ctx, "POST", "/auth/token", nil, nil, "application/json", body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	result := &CreateTokenResult{StatusCode: resp.StatusCode}
+	switch resp.StatusCode {
+	case 200:
+		result.CacheControl = resp.Header.Get("Cache-Control")
+		err = decodeBody(resp, &result.Body)
+	default:
+		return nil, errorFrom(resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetProblemTypeResult is a successful response to GetProblemType.
+type GetProblemTypeResult struct {
+	StatusCode int
+	Body       string
+}
+
+// GetProblemType sends GET /problems/{name}: describe a problem type.
+func (c *Client) GetProblemType(ctx context.Context, name string) (*GetProblemTypeResult, error) {
+	resp, err := c.do(ctx, "GET", "/problems/"+url.PathEscape(name), nil, nil, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	result := &GetProblemTypeResult{StatusCode: resp.StatusCode}
+	switch resp.StatusCode {
+	case 200:
+		err = decodeBody(resp, &result.Body)
+	default:
+		return nil, errorFrom(resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ListTasksParams holds the optional parameters of ListTasks. Zero
+// values are not sent.
+type ListTasksParams struct {
+	// Comma-separated statuses; a task matches any of them.
+	Status string
+	// Only tasks with exactly this priority.
+	Priority int
+	// Only tasks whose title or notes contain this, ignoring ASCII case.
+	Q string
+	// Only tasks due on or before this date.
+	DueBefore string
+	// Comma-separated fields to sort by, each with an optional - for
+	// descending order. Ties are broken by ascending id.
+	Sort string
+	// Page size.
+	Limit int
+	// Number of matching tasks to skip.
+	Offset int
+}
+
+// ListTasksResult is a successful response to ListTasks.
+type ListTasksResult struct {
+	StatusCode int
+	Body       TaskPage
+	Link       string // the Link header
+}
+
+// ListTasks sends GET /tasks: list one page of tasks, filtered and
+// sorted.
+//
+// Needs tasks:read.
+func (c *Client) ListTasks(ctx context.Context, params ListTasksParams) (*ListTasksResult, error) {
+	query := url.Values{}
+	if params.Status != "" {
+		query.Set("status", params.Status)
+	}
+	if params.Priority != 0 {
+		query.Set("priority", strconv.Itoa(params.Priority))
+	}
+	if params.Q != "" {
+		query.Set("q", params.Q)
+	}
+	if params.DueBefore != "" {
+		query.Set("due_before", params.DueBefore)
+	}
+	if params.Sort != "" {
+		query.Set("sort", params.Sort)
+	}
+	if params.Limit != 0 {
+		query.Set("limit", strconv.Itoa(params.Limit))
+	}
+	if params.Offset != 0 {
+		query.Set("offset", strconv.Itoa(params.Offset))
+	}
+	resp, err := c.do(ctx, "GET", "/tasks", query, nil, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	result := &ListTasksResult{StatusCode: resp.StatusCode}
+	switch resp.StatusCode {
+	case 200:
+		result.Link = resp.Header.Get("Link")
+		err = decodeBody(resp, &result.Body)
+	default:
+		return nil, errorFrom(resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CreateTaskResult is a successful response to CreateTask.
+type CreateTaskResult struct {
+	StatusCode int
+	Body       Task
+	ETag       string // the ETag header
+	Location   string // the Location header
+}
+
+// CreateTask sends POST /tasks: create a task.
+//
+// Needs tasks:write.
+func (c *Client) CreateTask(ctx context.Context, body TaskInput) (*CreateTaskResult, error) {
+	resp, err := c.do(ctx, "POST", "/tasks", nil, nil, "application/json", body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	result := &CreateTaskResult{StatusCode: resp.StatusCode}
+	switch resp.StatusCode {
+	case 201:
+		result.ETag = resp.Header.Get("ETag")
+		result.Location = resp.Header.Get("Location")
+		err = decodeBody(resp, &result.Body)
+	default:
+		return nil, errorFrom(resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetTaskParams holds the optional parameters of GetTask. Zero values
+// are not sent.
+type GetTaskParams struct {
+	// ETags the client already has; if one is current the answer is 304
+	// without a body.
+	IfNoneMatch string
+}
+
+// GetTaskResult is a successful response to GetTask.
+type GetTaskResult struct {
+	StatusCode int
+	// Body is nil for a response without one.
+	Body *Task
+	ETag string // the ETag header
+}
+
+// GetTask sends GET /tasks/{id}: fetch a task.
+//
+// Needs tasks:read.
+func (c *Client) GetTask(ctx context.Context, id int64, params GetTaskParams) (*GetTaskResult, error) {
+	header := http.Header{}
+	if params.IfNoneMatch != "" {
+		header.Set("If-None-Match", params.IfNoneMatch)
+	}
+	resp, err := c.do(ctx, "GET", "/tasks/"+strconv.FormatInt(id, 10), nil, header, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	result := &GetTaskResult{StatusCode: resp.StatusCode}
+	switch resp.StatusCode {
+	case 200:
+		result.ETag = resp.Header.Get("ETag")
+		result.Body = new(Task)
+		err = decodeBody(resp, result.Body)
+	case 304:
+		result.ETag = resp.Header.Get("ETag")
+	default:
+		return nil, errorFrom(resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ReplaceTaskParams holds the optional parameters of ReplaceTask. Zero
+// values are not sent.
+type ReplaceTaskParams struct {
+	// The ETag of the version being changed, or * for any version. Not
+	// marked required so that a missing one gets 428, not 400.
+	IfMatch string
+}
+
+// ReplaceTaskResult is a successful response to ReplaceTask.
+type ReplaceTaskResult struct {
+	StatusCode int
+	Body       Task
+	ETag       string // the ETag header
+}
+
+// ReplaceTask sends PUT /tasks/{id}: replace every writable field of a
+// task.
+//
+// Needs tasks:write.
+func (c *Client) ReplaceTask(ctx context.Context, id int64, params ReplaceTaskParams, body TaskInput) (*ReplaceTaskResult, error) {
+	header := http.Header{}
+	if params.IfMatch != "" {
+		header.Set("If-Match", params.IfMatch)
+	}
+	resp, err := c.do(ctx, "PUT", "/tasks/"+strconv.FormatInt(id, 10), nil, header, "application/json", body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	result := &ReplaceTaskResult{StatusCode: resp.StatusCode}
+	switch resp.StatusCode {
+	case 200:
+		result.ETag = resp.Header.Get("ETag")
+		err = decodeBody(resp, &result.Body)
+	default:
+		return nil, errorFrom(resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// UpdateTaskParams holds the optional parameters of UpdateTask. Zero
+// values are not sent.
+type UpdateTaskParams struct {
+	// The ETag of the version being changed, or * for any version. Not
+	// marked required so that a missing one gets 428, not 400.
+	IfMatch string
+}
+
+// UpdateTaskResult is a successful response to UpdateTask.
+type UpdateTaskResult struct {
+	StatusCode int
+	Body       Task
+	ETag       string // the ETag header
+}
+
+// UpdateTask sends PATCH /tasks/{id}: change some fields of a task with
+// a JSON merge patch.
+//
+// Needs tasks:write.
+func (c *Client) UpdateTask(ctx context.Context, id int64, params UpdateTaskParams, body TaskPatch) (*UpdateTaskResult, error) {
+	header := http.Header{}
+	if params.IfMatch != "" {
+		header.Set("If-Match", params.IfMatch)
+	}
+	resp, err := c.do(ctx, "PATCH", "/tasks/"+strconv.FormatInt(id, 10), nil, header, "application/merge-patch+json", body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	result := &UpdateTaskResult{StatusCode: resp.StatusCode}
+	switch resp.StatusCode {
+	case 200:
+		result.ETag = resp.Header.Get("ETag")
+		err = decodeBody(resp, &result.Body)
+	default:
+		return nil, errorFrom(resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// DeleteTaskParams holds the optional parameters of DeleteTask. Zero
+// values are not sent.
+type DeleteTaskParams struct {
+	// The ETag of the version being changed, or * for any version. Not
+	// marked required so that a missing one gets 428, not 400.
+	IfMatch string
+}
+
+// DeleteTaskResult is a successful response to DeleteTask.
+type DeleteTaskResult struct {
+	StatusCode int
+}
+
+// DeleteTask sends DELETE /tasks/{id}: delete a task.
+//
+// Needs tasks:delete.
+func (c *Client) DeleteTask(ctx context.Context, id int64, params DeleteTaskParams) (*DeleteTaskResult, error) {
+	header := http.Header{}
+	if params.IfMatch != "" {
+		header.Set("If-Match", params.IfMatch)
+	}
+	resp, err := c.do(ctx, "DELETE", "/tasks/"+strconv.FormatInt(id, 10), nil, header, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	result := &DeleteTaskResult{StatusCode: resp.StatusCode}
+	switch resp.StatusCode {
+	case 204:
+	default:
+		return nil, errorFrom(resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,364 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"golang-learning-project/api"
+	"golang-learning-project/api/client"
+	"golang-learning-project/api/openapi"
+	"golang-learning-project/examples/04-rest-api/tasks"
+	"golang-learning-project/internal/auth"
+	"golang-learning-project/pkg/errs"
+)
+
+// TestGenerated regenerates the client from api/openapi.json and fails
+// when client_gen.go is not what the generator writes: the document
+// changed without go generate ./api/client.
+func TestGenerated(t *testing.T) {
+	doc := document(t)
+	src, err := doc.GenerateClient("client")
+	if err != nil {
+		t.Fatal(err)
+	}
+	committed, err := os.ReadFile("client_gen.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(src, committed) {
+		t.Error("client_gen.go is stale; run go generate ./api/client")
+	}
+	if client.SpecDigest != doc.Digest() {
+		t.Errorf("SpecDigest is %s, the document is %s", client.SpecDigest, doc.Digest())
+	}
+}
+
+func document(t *testing.T) *openapi.Document {
+	t.Helper()
+	doc, err := api.Document()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+// syncBuffer collects the server log, which handlers write to from
+// their own goroutines.
+type syncBuffer struct {
+	mu sync.Mutex
+	b  strings.Builder
+}
+
+func (s *syncBuffer) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.b.Write(p)
+}
+
+func (s *syncBuffer) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.b.String()
+}
+
+// newServer starts the task API of examples/04-rest-api: the task
+// handler behind authentication, with every response checked against
+// the document. A response that breaks the document is logged, and the
+// test fails when it ends with anything in the log.
+func newServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	logs := new(syncBuffer)
+	logger := log.New(logs, "", 0)
+
+	users := auth.NewMemoryUsers()
+	for _, u := range []struct {
+		name string
+		role auth.Role
+	}{{"alice", auth.RoleAdmin}, {"carol", auth.RoleViewer}} {
+		if _, err := users.Add(u.name, u.name+"-secret", u.role); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tokens, err := auth.NewTokens("client-test", "k1", []byte(strings.Repeat("1", auth.MinKeyLen)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := auth.New(users, tokens, auth.NewSessions(false), auth.DefaultPolicy, logger)
+
+	h := tasks.NewHandler(tasks.NewMemoryRepository(), logger)
+	mux := http.NewServeMux()
+	mux.Handle("GET /tasks", a.Require(auth.PermReadTasks)(h))
+	mux.Handle("GET /tasks/{id}", a.Require(auth.PermReadTasks)(h))
+	mux.Handle("POST /tasks", a.Require(auth.PermWriteTasks)(h))
+	mux.Handle("PUT /tasks/{id}", a.Require(auth.PermWriteTasks)(h))
+	mux.Handle("PATCH /tasks/{id}", a.Require(auth.PermWriteTasks)(h))
+	mux.Handle("DELETE /tasks/{id}", a.Require(auth.PermDeleteTasks)(h))
+	mux.Handle("/auth/", a.Handler())
+	mux.Handle("/", h)
+
+	check := document(t).Middleware(openapi.Options{
+		SkipRequests: true,
+		OnResponseError: func(r *http.Request, err error) {
+			logger.Printf("openapi: %v", err)
+		},
+	})
+	srv := httptest.NewServer(check(a.Authenticate(mux)))
+	t.Cleanup(func() {
+		srv.Close()
+		if logs.String() != "" {
+			t.Errorf("server log:\n%s", logs)
+		}
+	})
+	return srv
+}
+
+// login returns a client for srv holding an access token for name.
+func login(t *testing.T, srv *httptest.Server, name string) *client.Client {
+	t.Helper()
+	c := client.New(srv.URL, srv.Client())
+	res, err := c.CreateToken(context.Background(), client.Credentials{Username: name, Password: name + "-secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Body.TokenType != "Bearer" || res.CacheControl != "no-store" {
+		t.Errorf("CreateToken = %+v", res)
+	}
+	c.Token = res.Body.AccessToken
+	return c
+}
+
+func TestTasks(t *testing.T) {
+	c := login(t, newServer(t), "alice")
+	ctx := context.Background()
+
+	created, err := c.CreateTask(ctx, client.TaskInput{Title: "Buy milk", Priority: 2, Due: "2026-01-10"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.StatusCode != 201 || created.Location != "/tasks/1" || created.Body.Status != client.TaskStatusTodo {
+		t.Errorf("CreateTask = %+v", created)
+	}
+	etag := created.ETag
+	if _, err := c.CreateTask(ctx, client.TaskInput{Title: "Write report", Status: client.TaskStatusDoing}); err != nil {
+		t.Fatal(err)
+	}
+
+	list, err := c.ListTasks(ctx, client.ListTasksParams{Sort: "-id", Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list.Body.Total != 2 || list.Body.Items[0].Title != "Write report" || !strings.Contains(list.Link, `rel="next"`) {
+		t.Errorf("ListTasks = %+v, Link %q", list.Body, list.Link)
+	}
+
+	got, err := c.GetTask(ctx, 1, client.GetTaskParams{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ETag != etag || got.Body.Due != "2026-01-10" || got.Body.CreatedAt.IsZero() {
+		t.Errorf("GetTask = %+v", got)
+	}
+	notModified, err := c.GetTask(ctx, 1, client.GetTaskParams{IfNoneMatch: etag})
+	if err != nil || notModified.StatusCode != 304 || notModified.Body != nil {
+		t.Errorf("GetTask with If-None-Match = %+v, %v; want 304 and no body", notModified, err)
+	}
+
+	patch := client.TaskPatch{Status: client.Some(client.TaskStatusDone), Due: client.Null[string]()}
+	updated, err := c.UpdateTask(ctx, 1, client.UpdateTaskParams{IfMatch: etag}, patch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b := updated.Body; b.Status != client.TaskStatusDone || b.Due != "" || b.Priority != 2 {
+		t.Errorf("UpdateTask = %+v", b)
+	}
+
+	_, err = c.ReplaceTask(ctx, 1, client.ReplaceTaskParams{IfMatch: etag}, client.TaskInput{Title: "Buy oat milk"})
+	if !errors.Is(err, errs.ErrConflict) {
+		t.Errorf("ReplaceTask with a stale ETag: %v, want a conflict", err)
+	}
+	replaced, err := c.ReplaceTask(ctx, 1, client.ReplaceTaskParams{IfMatch: updated.ETag}, client.TaskInput{Title: "Buy oat milk"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b := replaced.Body; b.Version != 3 || b.Priority != 3 || b.Status != client.TaskStatusTodo {
+		t.Errorf("ReplaceTask = %+v", b)
+	}
+
+	if _, err := c.DeleteTask(ctx, 1, client.DeleteTaskParams{IfMatch: "*"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetTask(ctx, 1, client.GetTaskParams{}); !errors.Is(err, errs.ErrNotFound) {
+		t.Errorf("GetTask after DeleteTask: %v, want not found", err)
+	}
+}
+
+func TestProblems(t *testing.T) {
+	srv := newServer(t)
+	ctx := context.Background()
+	alice, carol := login(t, srv, "alice"), login(t, srv, "carol")
+
+	_, err := alice.CreateTask(ctx, client.TaskInput{Title: " ", Priority: 9})
+	var p *client.Problem
+	if !errors.As(err, &p) || !errors.Is(err, errs.ErrInvalidArgument) {
+		t.Fatalf("CreateTask with a blank title: %v, want an invalid argument problem", err)
+	}
+	if p.Status != 422 || len(p.InvalidParams) != 2 {
+		t.Errorf("problem %+v, want 422 with title and priority", p)
+	}
+
+	_, err = carol.CreateTask(ctx, client.TaskInput{Title: "Water plants"})
+	if !errors.Is(err, errs.ErrPermissionDenied) {
+		t.Errorf("CreateTask as a viewer: %v, want permission denied", err)
+	}
+	anon := client.New(srv.URL, srv.Client())
+	if _, err := anon.ListTasks(ctx, client.ListTasksParams{}); !errors.Is(err, errs.ErrUnauthenticated) {
+		t.Errorf("ListTasks without a token: %v, want unauthenticated", err)
+	}
+
+	doc, err := anon.GetProblemType(ctx, "edit-conflict")
+	if err != nil || !strings.Contains(doc.Body, "GET it again") {
+		t.Errorf("GetProblemType = %+v, %v", doc, err)
+	}
+}
+
+// TestSession logs in for a cookie, which the client keeps in its jar,
+// and sends the CSRF token with the unsafe requests.
+func TestSession(t *testing.T) {
+	srv := newServer(t)
+	ctx := context.Background()
+	jar, _ := cookiejar.New(nil)
+	c := client.New(srv.URL, &http.Client{Jar: jar})
+
+	res, err := c.Login(ctx, client.Credentials{Username: "alice", Password: "alice-secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	csrf := res.Body.CsrfToken
+	if csrf == "" || !strings.Contains(res.SetCookie, "HttpOnly") {
+		t.Fatalf("Login = %+v", res)
+	}
+	me, err := c.GetMe(ctx)
+	if err != nil || me.Body.User.Name != "alice" || me.Body.CsrfToken != csrf {
+		t.Errorf("GetMe = %+v, %v", me, err)
+	}
+	if _, err := c.CreateTask(ctx, client.TaskInput{Title: "Water plants"}); !errors.Is(err, errs.ErrPermissionDenied) {
+		t.Errorf("CreateTask without CSRF: %v, want permission denied", err)
+	}
+	c.Header = http.Header{auth.CSRFHeader: {csrf}}
+	if _, err := c.CreateTask(ctx, client.TaskInput{Title: "Water plants"}); err != nil {
+		t.Errorf("CreateTask with CSRF: %v", err)
+	}
+	if _, err := c.Logout(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetMe(ctx); !errors.Is(err, errs.ErrUnauthenticated) {
+		t.Errorf("GetMe after Logout: %v, want unauthenticated", err)
+	}
+}
+
+func TestRefreshToken(t *testing.T) {
+	srv := newServer(t)
+	ctx := context.Background()
+	c := client.New(srv.URL, srv.Client())
+	first, err := c.CreateToken(ctx, client.Credentials{Username: "alice", Password: "alice-secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	next, err := c.RefreshToken(ctx, client.RefreshRequest{RefreshToken: first.Body.RefreshToken})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next.Body.RefreshToken == first.Body.RefreshToken {
+		t.Error("the refresh token did not change")
+	}
+	if _, err := c.RefreshToken(ctx, client.RefreshRequest{RefreshToken: first.Body.RefreshToken}); !errors.Is(err, errs.ErrUnauthenticated) {
+		t.Errorf("RefreshToken used twice: %v, want unauthenticated", err)
+	}
+	c.Token = next.Body.AccessToken
+	if _, err := c.Logout(ctx, &client.RefreshRequest{RefreshToken: next.Body.RefreshToken}); err != nil {
+		t.Errorf("Logout: %v", err)
+	}
+	if _, err := c.RefreshToken(ctx, client.RefreshRequest{RefreshToken: next.Body.RefreshToken}); !errors.Is(err, errs.ErrUnauthenticated) {
+		t.Errorf("RefreshToken after Logout: %v, want unauthenticated", err)
+	}
+}
+
+// TestDrift serves responses that break the document. The client
+// decodes strictly, so a member it does not know is an error rather
+// than silently dropped.
+func TestDrift(t *testing.T) {
+	task := map[string]any{
+		"id": 1, "title": "Buy milk", "notes": "", "status": "todo", "priority": 3, "version": 1,
+		"created_at": "2026-01-01T00:00:00Z", "updated_at": "2026-01-01T00:00:00Z",
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"1-1"`)
+		json.NewEncoder(w).Encode(task)
+	}))
+	defer srv.Close()
+	c := client.New(srv.URL, srv.Client())
+	ctx := context.Background()
+
+	if _, err := c.GetTask(ctx, 1, client.GetTaskParams{}); err != nil {
+		t.Fatalf("GetTask of a task as documented: %v", err)
+	}
+	task["assignee"] = "bob"
+	if _, err := c.GetTask(ctx, 1, client.GetTaskParams{}); err == nil || !strings.Contains(err.Error(), "assignee") {
+		t.Errorf("GetTask with an undocumented member: %v, want an error naming it", err)
+	}
+}
+
+func TestProblemUnwrap(t *testing.T) {
+	tests := []struct {
+		status int
+		want   error
+	}{
+		{400, errs.ErrInvalidArgument},
+		{422, errs.ErrInvalidArgument},
+		{401, errs.ErrUnauthenticated},
+		{403, errs.ErrPermissionDenied},
+		{404, errs.ErrNotFound},
+		{409, errs.ErrConflict},
+		{412, errs.ErrConflict},
+		{428, errs.ErrConflict},
+		{500, nil},
+	}
+	for _, tt := range tests {
+		p := &client.Problem{Status: tt.status, Title: http.StatusText(tt.status)}
+		if got := p.Unwrap(); got != tt.want {
+			t.Errorf("Unwrap of a %d problem = %v, want %v", tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestNullable(t *testing.T) {
+	tests := []struct {
+		patch client.TaskPatch
+		want  string
+	}{
+		{client.TaskPatch{}, `{}`},
+		{client.TaskPatch{Priority: client.Some(0)}, `{"priority":0}`},
+		{client.TaskPatch{Due: client.Null[string]()}, `{"due":null}`},
+	}
+	for _, tt := range tests {
+		b, err := json.Marshal(tt.patch)
+		if err != nil || string(b) != tt.want {
+			t.Errorf("Marshal(%+v) = %s, %v; want %s", tt.patch, b, err, tt.want)
+		}
+	}
+	var n client.Nullable[int]
+	if err := json.Unmarshal([]byte("null"), &n); err != nil || !n.Set || !n.Null {
+		t.Errorf("Unmarshal(null) = %+v, %v", n, err)
+	}
+}
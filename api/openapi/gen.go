@@ -0,0 +1,481 @@
+package openapi
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// GenerateClient returns the Go source of a typed client for d, in
+// package pkg: a type for every component schema and, for every
+// operation, a method on Client with types for its parameters and its
+// result. Error responses become errors.
+//
+// The source relies on things the package writes by hand: the Client
+// type with its do method, the Nullable type and the decodeBody and
+// errorFrom helpers.
+func (d *Document) GenerateClient(pkg string) ([]byte, error) {
+	g := &generator{doc: d, imports: make(map[string]bool)}
+
+	// Request bodies leave out readOnly properties: the server ignores
+	// them, so a client has no reason to send them
+	g.requestTypes = make(map[string]bool)
+	for _, r := range d.routes {
+		if b := r.Operation.RequestBody; b != nil {
+			for _, mt := range b.Content.Values() {
+				g.requestTypes[mt.Schema.RefName()] = true
+			}
+		}
+	}
+
+	g.printf("// SpecDigest identifies the OpenAPI document this file was generated\n")
+	g.printf("// from; a client is stale when it differs from the document's Digest.\n")
+	g.printf("const SpecDigest = %q\n\n", d.digest)
+
+	for _, name := range slices.Sorted(maps.Keys(d.Components.Schemas)) {
+		if err := g.schemaType(name, d.Components.Schemas[name]); err != nil {
+			return nil, fmt.Errorf("schema %s: %w", name, err)
+		}
+	}
+	for _, r := range d.routes {
+		if err := g.operation(r); err != nil {
+			return nil, fmt.Errorf("%s %s: %w", r.Method, r.Path, err)
+		}
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by apigen. DO NOT EDIT.\n\npackage %s\n\nimport (\n", pkg)
+	for _, imp := range slices.Sorted(maps.Keys(g.imports)) {
+		fmt.Fprintf(&out, "\t%q\n", imp)
+	}
+	out.WriteString(")\n\n")
+	out.Write(g.buf.Bytes())
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w", err)
+	}
+	return src, nil
+}
+
+type generator struct {
+	doc          *Document
+	buf          bytes.Buffer
+	imports      map[string]bool
+	requestTypes map[string]bool
+}
+
+func (g *generator) printf(format string, args ...any) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// comment writes text as a // comment, wrapped at about 70 columns.
+func (g *generator) comment(indent, text string) {
+	line := indent + "//"
+	for _, word := range strings.Fields(text) {
+		if len(line)+1+len(word) > 72 && line != indent+"//" {
+			g.printf("%s\n", line)
+			line = indent + "//"
+		}
+		line += " " + word
+	}
+	g.printf("%s\n", line)
+}
+
+// ========================================
+// TYPES
+// ========================================
+
+func (g *generator) schemaType(name string, s *Schema) error {
+	goName := exported(name)
+	if s.Description != "" {
+		g.comment("", goName+" is "+lowerFirst(s.Description))
+	}
+
+	switch {
+	case s.Type.Has("string") && len(s.Enum) > 0:
+		g.printf("type %s string\n\nconst (\n", goName)
+		for _, e := range s.Enum {
+			v, ok := e.(string)
+			if !ok {
+				return fmt.Errorf("enum value %v is not a string", e)
+			}
+			g.printf("\t%s%s %s = %q\n", goName, exported(v), goName, v)
+		}
+		g.printf(")\n\n")
+
+	case s.Type.Has("object"):
+		g.printf("type %s struct {\n", goName)
+		for _, prop := range s.Properties.Keys() {
+			p, _ := s.Properties.Get(prop)
+			if p.ReadOnly && g.requestTypes[name] {
+				continue
+			}
+			typ, nullable, err := g.goType(p)
+			if err != nil {
+				return fmt.Errorf("%s: %w", prop, err)
+			}
+			tag := prop
+			switch required := slices.Contains(s.Required, prop); {
+			case nullable && required:
+				typ = "*" + typ
+			case nullable:
+				typ, tag = "Nullable["+typ+"]", tag+",omitzero"
+			case !required:
+				tag += ",omitempty"
+			}
+			if p.Description != "" {
+				g.comment("\t", p.Description)
+			}
+			g.printf("\t%s %s `json:%q`\n", exported(prop), typ, tag)
+		}
+		g.printf("}\n\n")
+
+	default:
+		typ, _, err := g.goType(s)
+		if err != nil {
+			return err
+		}
+		g.printf("type %s %s\n\n", goName, typ)
+	}
+	return nil
+}
+
+// goType returns the Go type for values of s, and whether JSON null is
+// allowed too.
+func (g *generator) goType(s *Schema) (typ string, nullable bool, err error) {
+	if s.Ref != "" {
+		return exported(s.RefName()), false, nil
+	}
+	if len(s.AnyOf) > 0 {
+		// Only "this or null" is supported
+		var other *Schema
+		for _, sub := range s.AnyOf {
+			if len(sub.Type) == 1 && sub.Type[0] == "null" {
+				nullable = true
+			} else if other == nil {
+				other = sub
+			} else {
+				return "", false, fmt.Errorf("anyOf with more than one non-null schema")
+			}
+		}
+		typ, _, err := g.goType(other)
+		return typ, nullable, err
+	}
+
+	var base []string
+	for _, t := range s.Type {
+		if t == "null" {
+			nullable = true
+		} else {
+			base = append(base, t)
+		}
+	}
+	switch {
+	case len(base) == 0:
+		g.imports["encoding/json"] = true
+		return "json.RawMessage", nullable, nil
+	case len(base) > 1:
+		return "", false, fmt.Errorf("type %v has more than one non-null type", s.Type)
+	}
+
+	switch base[0] {
+	case "string":
+		if s.Format == "date-time" {
+			g.imports["time"] = true
+			return "time.Time", nullable, nil
+		}
+		return "string", nullable, nil
+	case "integer":
+		if s.Format == "int64" {
+			return "int64", nullable, nil
+		}
+		return "int", nullable, nil
+	case "number":
+		return "float64", nullable, nil
+	case "boolean":
+		return "bool", nullable, nil
+	case "array":
+		if s.Items == nil {
+			return "", false, fmt.Errorf("array without items")
+		}
+		elem, _, err := g.goType(s.Items)
+		return "[]" + elem, nullable, err
+	}
+	return "", false, fmt.Errorf("inline %s schemas are not supported; use a component", base[0])
+}
+
+// ========================================
+// OPERATIONS
+// ========================================
+
+// param is one parameter of an operation as the generated code sees it.
+type param struct {
+	*Parameter
+	goName string // the argument or field name
+	goType string
+}
+
+func (g *generator) operation(r *Route) error {
+	op := r.Operation
+	name := exported(op.OperationID)
+
+	var pathParams, optional []param
+	for _, p := range r.Parameters {
+		typ, _, err := g.goType(p.Schema)
+		if err != nil {
+			return fmt.Errorf("parameter %s: %w", p.Name, err)
+		}
+		if p.In == "path" {
+			pathParams = append(pathParams, param{p, unexported(p.Name), typ})
+		} else {
+			optional = append(optional, param{p, exported(p.Name), typ})
+		}
+	}
+
+	// The path as a Go expression
+	var path []string
+	literal := ""
+	for i, seg := range r.Segments {
+		if i > 0 {
+			literal += "/"
+		}
+		wc, ok := wildcard(seg)
+		if !ok {
+			literal += seg
+			continue
+		}
+		path = append(path, strconv.Quote(literal))
+		literal = ""
+		for _, p := range pathParams {
+			if p.Name != wc {
+				continue
+			}
+			if expr := g.toString(p.goName, p.goType); expr == p.goName {
+				g.imports["net/url"] = true
+				path = append(path, "url.PathEscape("+expr+")")
+			} else {
+				path = append(path, expr)
+			}
+		}
+	}
+	if literal != "" || len(path) == 0 {
+		path = append(path, strconv.Quote(literal))
+	}
+
+	// The request body
+	bodyType, contentType := "", ""
+	if rb := op.RequestBody; rb != nil {
+		contentType = rb.Content.Keys()[0]
+		mt, _ := rb.Content.Get(contentType)
+		typ, _, err := g.goType(mt.Schema)
+		if err != nil {
+			return fmt.Errorf("request body: %w", err)
+		}
+		bodyType = typ
+		if !rb.Required {
+			// nil sends no body
+			bodyType = "*" + typ
+		}
+	}
+
+	// The successful responses: their body type and headers
+	var codes []int
+	for code := range op.Responses {
+		n, err := strconv.Atoi(code)
+		if err != nil {
+			return fmt.Errorf("response %q: only numeric status codes are supported", code)
+		}
+		if n < 400 {
+			codes = append(codes, n)
+		}
+	}
+	slices.Sort(codes)
+	resultBody, bodyOptional := "", false
+	headers := make(map[string]*Header)
+	for _, code := range codes {
+		resp := op.Responses[strconv.Itoa(code)]
+		maps.Copy(headers, resp.Headers)
+		if resp.Content.Len() == 0 {
+			bodyOptional = true
+			continue
+		}
+		typ, _, err := g.goType(resp.Content.Values()[0].Schema)
+		if err != nil {
+			return fmt.Errorf("response %d: %w", code, err)
+		}
+		if resultBody != "" && resultBody != typ {
+			return fmt.Errorf("responses %v have different body types", codes)
+		}
+		resultBody = typ
+	}
+	if resultBody != "" && bodyOptional {
+		resultBody = "*" + resultBody
+	}
+
+	// Parameter and result types
+	if len(optional) > 0 {
+		g.comment("", name+"Params holds the optional parameters of "+name+". Zero values are not sent.")
+		g.printf("type %sParams struct {\n", name)
+		for _, p := range optional {
+			if p.Description != "" {
+				g.comment("\t", p.Description)
+			}
+			g.printf("\t%s %s\n", p.goName, p.goType)
+		}
+		g.printf("}\n\n")
+	}
+	g.comment("", name+"Result is a successful response to "+name+".")
+	g.printf("type %sResult struct {\n\tStatusCode int\n", name)
+	if resultBody != "" {
+		if bodyOptional {
+			g.printf("\t// Body is nil for a response without one.\n")
+		}
+		g.printf("\tBody %s\n", resultBody)
+	}
+	for _, h := range slices.Sorted(maps.Keys(headers)) {
+		g.printf("\t%s string // the %s header\n", exported(h), h)
+	}
+	g.printf("}\n\n")
+
+	// The method
+	doc := fmt.Sprintf("%s sends %s %s: %s.", name, r.Method, r.Path, lowerFirst(op.Summary))
+	g.comment("", doc)
+	if op.Description != "" {
+		g.printf("//\n")
+		g.comment("", op.Description)
+	}
+	g.imports["context"] = true
+	args := []string{"ctx context.Context"}
+	for _, p := range pathParams {
+		args = append(args, p.goName+" "+p.goType)
+	}
+	if len(optional) > 0 {
+		args = append(args, "params "+name+"Params")
+	}
+	if bodyType != "" {
+		args = append(args, "body "+bodyType)
+	}
+	g.printf("func (c *Client) %s(%s) (*%sResult, error) {\n", name, strings.Join(args, ", "), name)
+
+	query, header := "nil", "nil"
+	for _, p := range optional {
+		zero := "0"
+		if p.goType == "string" || g.isStringType(p.Schema) {
+			zero = `""`
+		}
+		switch p.In {
+		case "query":
+			if query == "nil" {
+				g.imports["net/url"] = true
+				g.printf("\tquery := url.Values{}\n")
+				query = "query"
+			}
+			g.printf("\tif params.%s != %s {\n\t\tquery.Set(%q, %s)\n\t}\n", p.goName, zero, p.Name, g.toString("params."+p.goName, p.goType))
+		case "header":
+			if header == "nil" {
+				g.imports["net/http"] = true
+				g.printf("\theader := http.Header{}\n")
+				header = "header"
+			}
+			g.printf("\tif params.%s != %s {\n\t\theader.Set(%q, %s)\n\t}\n", p.goName, zero, p.Name, g.toString("params."+p.goName, p.goType))
+		}
+	}
+	bodyArg := "nil"
+	switch {
+	case strings.HasPrefix(bodyType, "*"):
+		// A nil pointer in an interface is not nil, and would be sent as
+		// JSON null
+		g.printf("\tvar reqBody any\n\tif body != nil {\n\t\treqBody = body\n\t}\n")
+		bodyArg = "reqBody"
+	case bodyType != "":
+		bodyArg = "body"
+	}
+	g.printf("\tresp, err := c.do(ctx, %q, %s, %s, %s, %q, %s)\n", r.Method, strings.Join(path, " + "), query, header, contentType, bodyArg)
+	g.printf("\tif err != nil {\n\t\treturn nil, err\n\t}\n\tdefer resp.Body.Close()\n\n")
+	g.printf("\tresult := &%sResult{StatusCode: resp.StatusCode}\n\tswitch resp.StatusCode {\n", name)
+	for _, code := range codes {
+		resp := op.Responses[strconv.Itoa(code)]
+		g.printf("\tcase %d:\n", code)
+		for _, h := range slices.Sorted(maps.Keys(resp.Headers)) {
+			g.printf("\t\tresult.%s = resp.Header.Get(%q)\n", exported(h), h)
+		}
+		if resp.Content.Len() > 0 {
+			if bodyOptional {
+				g.printf("\t\tresult.Body = new(%s)\n\t\terr = decodeBody(resp, result.Body)\n", strings.TrimPrefix(resultBody, "*"))
+			} else {
+				g.printf("\t\terr = decodeBody(resp, &result.Body)\n")
+			}
+		}
+	}
+	g.printf("\tdefault:\n\t\treturn nil, errorFrom(resp)\n\t}\n")
+	g.printf("\tif err != nil {\n\t\treturn nil, err\n\t}\n\treturn result, nil\n}\n\n")
+	return nil
+}
+
+// isStringType reports whether s is a component whose Go type is a
+// named string type, such as an enum.
+func (g *generator) isStringType(s *Schema) bool {
+	return g.doc.Resolve(s).Type.Has("string")
+}
+
+// toString returns a Go expression that formats expr, of type typ, for a
+// URL or header.
+func (g *generator) toString(expr, typ string) string {
+	switch typ {
+	case "string":
+		return expr
+	case "int":
+		g.imports["strconv"] = true
+		return "strconv.Itoa(" + expr + ")"
+	case "int64":
+		g.imports["strconv"] = true
+		return "strconv.FormatInt(" + expr + ", 10)"
+	}
+	return "string(" + expr + ")"
+}
+
+// exported turns a JSON or OpenAPI name into an exported Go name:
+// "created_at" is CreatedAt, "If-Match" is IfMatch, "id" is ID.
+func exported(name string) string {
+	var b strings.Builder
+	for _, word := range strings.FieldsFunc(name, func(r rune) bool {
+		return r == '_' || r == '-' || r == ' '
+	}) {
+		switch strings.ToLower(word) {
+		case "id", "url", "uri", "json", "http":
+			b.WriteString(strings.ToUpper(word))
+		default:
+			b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+	return b.String()
+}
+
+// unexported is exported with a lower case first word: "task_id" is
+// taskID.
+func unexported(name string) string {
+	s := exported(name)
+	if strings.ToLower(name) == "id" {
+		return "id"
+	}
+	return lowerFirst(s)
+}
+
+// lowerFirst lowers the first letter of s unless it starts an acronym,
+// so "A task" becomes "a task" but "JSON patch" stays.
+func lowerFirst(s string) string {
+	r := []rune(s)
+	if len(r) > 1 && unicode.IsUpper(r[1]) {
+		return s
+	}
+	if len(r) > 0 {
+		r[0] = unicode.ToLower(r[0])
+	}
+	return string(r)
+}
@@ -0,0 +1,277 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang-learning-project/pkg/errs"
+)
+
+// DefaultMaxBodyBytes is the largest request body Middleware reads
+// unless Options says otherwise.
+const DefaultMaxBodyBytes = 1 << 20
+
+// Options configures Middleware.
+type Options struct {
+	// SkipRequests passes every request to the handler unchecked, for
+	// when only the responses are of interest.
+	SkipRequests bool
+
+	// OnResponseError is called with every response that breaks the
+	// document; the response has been sent unchanged by then. When it is
+	// nil responses are not checked, and not buffered either.
+	OnResponseError func(r *http.Request, err error)
+
+	// MaxBodyBytes limits the request bodies read; 0 means
+	// DefaultMaxBodyBytes.
+	MaxBodyBytes int64
+}
+
+// Middleware returns middleware that checks requests and responses
+// against d. Requests for a path and method that d does not describe
+// pass through unchecked.
+//
+// A request that breaks the document never reaches the handler. It gets
+// an RFC 7807 problem: 404 if a path parameter is invalid, since such a
+// URL names nothing; 415 for a Content-Type the operation does not take;
+// 413 for a body over the limit; 400 for anything else, with every
+// problem listed in invalid-params.
+func (d *Document) Middleware(opts Options) func(http.Handler) http.Handler {
+	if opts.MaxBodyBytes == 0 {
+		opts.MaxBodyBytes = DefaultMaxBodyBytes
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route, pathValues := d.Find(r.Method, r.URL.Path)
+			if route == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if !opts.SkipRequests && !d.checkRequest(w, r, route, pathValues, opts.MaxBodyBytes) {
+				return
+			}
+			if opts.OnResponseError == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			rec := &responseBuffer{header: w.Header()}
+			next.ServeHTTP(rec, r)
+			if rec.status == 0 {
+				rec.status = http.StatusOK
+			}
+			w.WriteHeader(rec.status)
+			w.Write(rec.body.Bytes())
+			if err := d.CheckResponse(route, rec.status, w.Header(), rec.body.Bytes()); err != nil {
+				opts.OnResponseError(r, err)
+			}
+		})
+	}
+}
+
+// checkRequest reports whether r matches route. If it does not, it has
+// written the problem response. A body it reads is put back for the
+// handler.
+func (d *Document) checkRequest(w http.ResponseWriter, r *http.Request, route *Route, pathValues map[string]string, maxBody int64) bool {
+	var c errs.Collector
+	for _, p := range route.Parameters {
+		if p.In == "path" {
+			d.validate(p.Schema, d.parseParam(p.Schema, pathValues[p.Name]), p.Name, inRequest, &c)
+		}
+	}
+	if c.Len() > 0 {
+		writeProblem(w, r, http.StatusNotFound, "no resource at "+r.URL.Path, nil)
+		return false
+	}
+
+	for _, p := range route.Parameters {
+		var text string
+		var present bool
+		switch p.In {
+		case "query":
+			if values, ok := r.URL.Query()[p.Name]; ok {
+				text, present = values[0], true
+			}
+		case "header":
+			if values := r.Header.Values(p.Name); len(values) > 0 {
+				text, present = strings.Join(values, ", "), true
+			}
+		default:
+			continue
+		}
+		switch {
+		case !present && p.Required:
+			c.Add(&ValidationError{p.Name, "is required"})
+		case present:
+			d.validate(p.Schema, d.parseParam(p.Schema, text), p.Name, inRequest, &c)
+		}
+	}
+
+	if body := route.Operation.RequestBody; body != nil {
+		if !d.checkBody(w, r, body, maxBody, &c) {
+			return false
+		}
+	}
+
+	if err := c.Err(); err != nil {
+		writeProblem(w, r, http.StatusBadRequest, "the request does not match the API definition", ValidationErrors(err))
+		return false
+	}
+	return true
+}
+
+// checkBody reads and checks a request body, adding schema problems to
+// c. It returns false if it has already written a response.
+func (d *Document) checkBody(w http.ResponseWriter, r *http.Request, body *RequestBody, maxBody int64, c *errs.Collector) bool {
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBody))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeProblem(w, r, http.StatusRequestEntityTooLarge, fmt.Sprintf("body is larger than %d bytes", maxBody), nil)
+		} else {
+			writeProblem(w, r, http.StatusBadRequest, "reading the body: "+err.Error(), nil)
+		}
+		return false
+	}
+	r.Body = io.NopCloser(bytes.NewReader(data))
+
+	if len(data) == 0 {
+		if body.Required {
+			c.Add(&ValidationError{"", "a body is required"})
+		}
+		return true
+	}
+	mt, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	media, ok := body.Content.Get(mt)
+	if !ok {
+		if r.Method == http.MethodPatch {
+			w.Header().Set("Accept-Patch", strings.Join(body.Content.Keys(), ", "))
+		}
+		writeProblem(w, r, http.StatusUnsupportedMediaType,
+			"Content-Type must be "+strings.Join(body.Content.Keys(), " or "), nil)
+		return false
+	}
+	v, err := decodeJSON(data)
+	if err != nil {
+		c.Add(&ValidationError{"", "body is not valid JSON: " + err.Error()})
+		return true
+	}
+	d.validate(media.Schema, v, "", inRequest, c)
+	return true
+}
+
+// CheckResponse checks a response to a request for route: the status
+// must be documented, with its required headers, and the body must
+// match its content type's schema. The error lists every problem.
+func (d *Document) CheckResponse(route *Route, status int, header http.Header, body []byte) error {
+	resp := route.Operation.Responses[strconv.Itoa(status)]
+	if resp == nil {
+		resp = route.Operation.Responses["default"]
+	}
+	if resp == nil {
+		return fmt.Errorf("%s %s: status %d is not documented", route.Method, route.Path, status)
+	}
+
+	var c errs.Collector
+	for _, name := range slices.Sorted(maps.Keys(resp.Headers)) {
+		h := resp.Headers[name]
+		values := header.Values(name)
+		switch {
+		case len(values) == 0 && h.Required:
+			c.Add(&ValidationError{name, "header is required"})
+		case len(values) > 0:
+			d.validate(h.Schema, d.parseParam(h.Schema, strings.Join(values, ", ")), name, inResponse, &c)
+		}
+	}
+
+	if resp.Content.Len() == 0 {
+		if len(body) > 0 {
+			c.Add(&ValidationError{"", "body must be empty"})
+		}
+	} else {
+		mt, _, _ := mime.ParseMediaType(header.Get("Content-Type"))
+		media, ok := resp.Content.Get(mt)
+		switch {
+		case !ok:
+			c.Add(&ValidationError{"Content-Type", fmt.Sprintf("%q is not one of %s", mt, strings.Join(resp.Content.Keys(), ", "))})
+		case isJSON(mt):
+			v, err := decodeJSON(body)
+			if err != nil {
+				c.Add(&ValidationError{"", "body is not valid JSON: " + err.Error()})
+				break
+			}
+			d.validate(media.Schema, v, "", inResponse, &c)
+		default:
+			d.validate(media.Schema, string(body), "", inResponse, &c)
+		}
+	}
+
+	if err := c.Err(); err != nil {
+		return fmt.Errorf("%s %s: %d response: %w", route.Method, route.Path, status, err)
+	}
+	return nil
+}
+
+// isJSON reports whether a media type is JSON: application/json or a
+// type with the +json suffix, such as application/problem+json.
+func isJSON(mt string) bool {
+	return mt == "application/json" || strings.HasSuffix(mt, "+json")
+}
+
+// responseBuffer holds a response so that it can be both sent and
+// checked. It shares the real ResponseWriter's header map.
+type responseBuffer struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (b *responseBuffer) Header() http.Header { return b.header }
+
+func (b *responseBuffer) WriteHeader(code int) {
+	if b.status == 0 {
+		b.status = code
+	}
+}
+
+func (b *responseBuffer) Write(p []byte) (int, error) {
+	if b.status == 0 {
+		b.status = http.StatusOK
+	}
+	return b.body.Write(p)
+}
+
+// problem is an RFC 7807 problem with the invalid-params extension of
+// the RFC's example.
+type problem struct {
+	Type          string             `json:"type"`
+	Title         string             `json:"title"`
+	Status        int                `json:"status"`
+	Detail        string             `json:"detail,omitempty"`
+	Instance      string             `json:"instance,omitempty"`
+	InvalidParams []*ValidationError `json:"invalid-params,omitempty"`
+}
+
+func writeProblem(w http.ResponseWriter, r *http.Request, status int, detail string, invalid []*ValidationError) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(problem{
+		Type:          "about:blank",
+		Title:         http.StatusText(status),
+		Status:        status,
+		Detail:        detail,
+		Instance:      r.URL.Path,
+		InvalidParams: invalid,
+	})
+}
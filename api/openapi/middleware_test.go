@@ -0,0 +1,129 @@
+package openapi_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"golang-learning-project/api"
+	"golang-learning-project/api/openapi"
+)
+
+func document(t *testing.T) *openapi.Document {
+	t.Helper()
+	doc, err := api.Document()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+// echo stands in for the task handler: it answers 200 with the body it
+// was given, so a test can see what got past the middleware.
+var echo = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("X-Reached", "yes")
+	io.Copy(w, r.Body)
+})
+
+func TestMiddlewareRequests(t *testing.T) {
+	const merge = "application/merge-patch+json"
+	tests := []struct {
+		name         string
+		method, path string
+		contentType  string
+		body         string
+		status       int
+		contains     string
+	}{
+		{"valid", "POST", "/tasks", "", `{"title":"Buy milk"}`, 200, `{"title":"Buy milk"}`},
+		{"unknown member", "POST", "/tasks", "", `{"title":"x","colour":"red"}`, 400, `"name": "colour"`},
+		{"wrong type", "POST", "/tasks", "", `{"title":"x","notes":5}`, 400, `"reason": "must be a string"`},
+		{"missing member", "POST", "/tasks", "", `{"notes":"x"}`, 400, `"reason": "is required"`},
+		{"every problem", "POST", "/tasks", "", `{"notes":5,"colour":"red"}`, 400, `"name": "title"`},
+		{"not JSON", "POST", "/tasks", "", `{"title":`, 400, ""},
+		{"content type", "POST", "/tasks", "text/plain", `{"title":"x"}`, 415, "must be application/json"},
+		{"too large", "POST", "/tasks", "", `{"title":"` + strings.Repeat("x", openapi.DefaultMaxBodyBytes) + `"}`, 413, ""},
+		{"query", "GET", "/tasks?limit=500&status=later", "", "", 400, `"name": "status"`},
+		{"query is fine", "GET", "/tasks?limit=5&status=done", "", "", 200, ""},
+		{"path parameter", "GET", "/tasks/abc", "", "", 404, "no resource at /tasks/abc"},
+		{"not nullable", "PATCH", "/tasks/1", merge, `{"title":null}`, 400, "must be a string"},
+		{"nullable, read-only ignored", "PATCH", "/tasks/1", merge, `{"priority":null,"id":7}`, 200, `"id":7`},
+		{"undocumented path", "GET", "/no/such/path?limit=x", "", "", 200, ""},
+	}
+	srv := httptest.NewServer(document(t).Middleware(openapi.Options{})(echo))
+	defer srv.Close()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, srv.URL+tt.path, strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("If-Match", "*")
+			req.Header.Set("Content-Type", "application/json")
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			res, err := srv.Client().Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer res.Body.Close()
+			body, _ := io.ReadAll(res.Body)
+			if res.StatusCode != tt.status || !strings.Contains(string(body), tt.contains) {
+				t.Errorf("%s %s: %d %s\nwant %d with %q", tt.method, tt.path, res.StatusCode, body, tt.status, tt.contains)
+			}
+			reached := res.Header.Get("X-Reached") == "yes"
+			if reached != (tt.status == 200) {
+				t.Errorf("%s %s: reached the handler %v", tt.method, tt.path, reached)
+			}
+			if tt.status != 200 && res.Header.Get("Content-Type") != "application/problem+json" {
+				t.Errorf("%s %s: Content-Type %q, want a problem", tt.method, tt.path, res.Header.Get("Content-Type"))
+			}
+		})
+	}
+}
+
+func TestMiddlewareResponses(t *testing.T) {
+	const task = `{"id":1,"title":"Buy milk","notes":"","status":"todo","priority":3,"version":1,` +
+		`"created_at":"2026-01-01T00:00:00Z","updated_at":"2026-01-01T00:00:00Z"}`
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		broken string // in the error, or "" if the response is as documented
+	}{
+		{"as documented", 200, task, ""},
+		{"unknown member", 200, strings.Replace(task, `"notes":""`, `"assignee":"bob"`, 1), "assignee"},
+		{"wrong type", 200, strings.Replace(task, `"id":1`, `"id":"1"`, 1), "id"},
+		{"undocumented status", 418, `{}`, "418"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got error
+			check := document(t).Middleware(openapi.Options{
+				SkipRequests:    true,
+				OnResponseError: func(r *http.Request, err error) { got = err },
+			})
+			h := check(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("ETag", `"1-1"`)
+				w.WriteHeader(tt.status)
+				io.WriteString(w, tt.body)
+			}))
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest("GET", "/tasks/1", nil))
+
+			if rec.Code != tt.status || rec.Body.String() != tt.body {
+				t.Errorf("response changed to %d %s", rec.Code, rec.Body)
+			}
+			switch {
+			case tt.broken == "" && got != nil:
+				t.Errorf("OnResponseError(%v) for a documented response", got)
+			case tt.broken != "" && (got == nil || !strings.Contains(got.Error(), tt.broken)):
+				t.Errorf("OnResponseError(%v), want an error naming %s", got, tt.broken)
+			}
+		})
+	}
+}
@@ -0,0 +1,438 @@
+// Package openapi reads the subset of OpenAPI 3.1 that api/openapi.json
+// uses, validates HTTP requests and responses against it, and generates
+// a typed Go client from it.
+//
+// It is not a general OpenAPI library. Load rejects any keyword it does
+// not know, so a definition cannot rely on a rule that is silently left
+// unchecked; support for a keyword is added when the definition needs
+// it. Security requirements are the exception: Load checks that they
+// name known schemes, but enforcing them is up to the server.
+package openapi
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Document is a loaded OpenAPI document.
+type Document struct {
+	OpenAPI    string                `json:"openapi"`
+	Info       Info                  `json:"info"`
+	Servers    []Server              `json:"servers"`
+	Paths      map[string]*PathItem  `json:"paths"`
+	Components Components            `json:"components"`
+	Security   []SecurityRequirement `json:"security"` // for operations that do not say
+
+	digest   string
+	routes   []*Route
+	patterns map[string]*regexp.Regexp
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Summary     string `json:"summary"`
+	Description string `json:"description"`
+}
+
+type Server struct {
+	URL         string `json:"url"`
+	Description string `json:"description"`
+}
+
+type Components struct {
+	Schemas    map[string]*Schema    `json:"schemas"`
+	Parameters map[string]*Parameter `json:"parameters"`
+	Headers    map[string]*Header    `json:"headers"`
+	Responses  map[string]*Response  `json:"responses"`
+
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes"`
+}
+
+// SecurityScheme is a way for a client to say who it is.
+type SecurityScheme struct {
+	Type         string `json:"type"` // http or apiKey
+	Description  string `json:"description"`
+	Scheme       string `json:"scheme"` // http: such as bearer
+	BearerFormat string `json:"bearerFormat"`
+	Name         string `json:"name"` // apiKey: the header, query parameter or cookie
+	In           string `json:"in"`
+}
+
+// SecurityRequirement names the schemes a request must satisfy
+// together, each with its scopes. An operation accepts a request that
+// satisfies any one of its requirements.
+type SecurityRequirement map[string][]string
+
+// PathItem holds the operations on one path template.
+type PathItem struct {
+	Summary     string       `json:"summary"`
+	Description string       `json:"description"`
+	Parameters  []*Parameter `json:"parameters"` // shared by every operation
+	Get         *Operation   `json:"get"`
+	Post        *Operation   `json:"post"`
+	Put         *Operation   `json:"put"`
+	Patch       *Operation   `json:"patch"`
+	Delete      *Operation   `json:"delete"`
+}
+
+type Operation struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary"`
+	Description string               `json:"description"`
+	Parameters  []*Parameter         `json:"parameters"`
+	RequestBody *RequestBody         `json:"requestBody"`
+	Responses   map[string]*Response `json:"responses"` // by status code
+
+	// Security overrides the document's requirements when it is present;
+	// an empty list makes the operation public.
+	Security []SecurityRequirement `json:"security"`
+}
+
+type Parameter struct {
+	Ref         string  `json:"$ref"`
+	Name        string  `json:"name"`
+	In          string  `json:"in"` // path, query or header
+	Description string  `json:"description"`
+	Required    bool    `json:"required"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Description string                 `json:"description"`
+	Required    bool                   `json:"required"`
+	Content     OrderedMap[*MediaType] `json:"content"` // the first is the preferred one
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Response struct {
+	Ref         string                 `json:"$ref"`
+	Description string                 `json:"description"`
+	Headers     map[string]*Header     `json:"headers"`
+	Content     OrderedMap[*MediaType] `json:"content"`
+}
+
+type Header struct {
+	Ref         string  `json:"$ref"`
+	Description string  `json:"description"`
+	Required    bool    `json:"required"`
+	Schema      *Schema `json:"schema"`
+}
+
+// Route is one operation with everything needed to match and check a
+// request for it.
+type Route struct {
+	Method    string
+	Path      string   // the template, such as /tasks/{id}
+	Segments  []string // the template split at "/"
+	Operation *Operation
+	// Parameters are the operation's and the path item's together,
+	// with references resolved.
+	Parameters []*Parameter
+	// Security is what the operation requires, its own or the
+	// document's; empty if it is public.
+	Security []SecurityRequirement
+}
+
+// Load parses and checks an OpenAPI document. Every reference must
+// resolve, every path wildcard must have a parameter and every
+// operation needs a unique operationId.
+func Load(data []byte) (*Document, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var d Document
+	if err := dec.Decode(&d); err != nil {
+		return nil, fmt.Errorf("openapi: %w", err)
+	}
+	if !strings.HasPrefix(d.OpenAPI, "3.1.") {
+		return nil, fmt.Errorf("openapi: version %q is not 3.1", d.OpenAPI)
+	}
+	sum := sha256.Sum256(data)
+	d.digest = "sha256:" + hex.EncodeToString(sum[:])
+	d.patterns = make(map[string]*regexp.Regexp)
+	if err := d.resolve(); err != nil {
+		return nil, fmt.Errorf("openapi: %w", err)
+	}
+	return &d, nil
+}
+
+// Digest identifies the exact bytes the document was loaded from.
+func (d *Document) Digest() string { return d.digest }
+
+// Routes returns every operation, ordered by path and then method.
+func (d *Document) Routes() []*Route { return d.routes }
+
+// Schema returns the component schema with the given name.
+func (d *Document) Schema(name string) (*Schema, bool) {
+	s, ok := d.Components.Schemas[name]
+	return s, ok
+}
+
+// methods lists the supported methods in the order routes use.
+var methods = []string{"GET", "POST", "PUT", "PATCH", "DELETE"}
+
+func (p *PathItem) operation(method string) *Operation {
+	switch method {
+	case "GET":
+		return p.Get
+	case "POST":
+		return p.Post
+	case "PUT":
+		return p.Put
+	case "PATCH":
+		return p.Patch
+	case "DELETE":
+		return p.Delete
+	}
+	return nil
+}
+
+// resolve replaces parameter, header and response references with what
+// they point at, checks the schemas and builds the routes.
+func (d *Document) resolve() error {
+	for name, s := range d.Components.Schemas {
+		if err := d.checkSchema(s); err != nil {
+			return fmt.Errorf("schema %s: %w", name, err)
+		}
+	}
+
+	for name, ss := range d.Components.SecuritySchemes {
+		switch {
+		case ss.Type == "http" && ss.Scheme != "":
+		case ss.Type == "apiKey" && ss.Name != "" && (ss.In == "header" || ss.In == "query" || ss.In == "cookie"):
+		default:
+			return fmt.Errorf("security scheme %s: unsupported type %q or missing fields", name, ss.Type)
+		}
+	}
+	if err := d.checkSecurity(d.Security); err != nil {
+		return err
+	}
+
+	ids := make(map[string]bool)
+	for _, path := range slices.Sorted(maps.Keys(d.Paths)) {
+		item := d.Paths[path]
+		for _, method := range methods {
+			op := item.operation(method)
+			if op == nil {
+				continue
+			}
+			where := method + " " + path
+			if op.OperationID == "" || ids[op.OperationID] {
+				return fmt.Errorf("%s: operationId %q is missing or not unique", where, op.OperationID)
+			}
+			ids[op.OperationID] = true
+
+			r := &Route{Method: method, Path: path, Segments: strings.Split(path, "/"), Operation: op, Security: d.Security}
+			if op.Security != nil {
+				if err := d.checkSecurity(op.Security); err != nil {
+					return fmt.Errorf("%s: %w", where, err)
+				}
+				r.Security = op.Security
+			}
+			for _, p := range append(slices.Clone(item.Parameters), op.Parameters...) {
+				p, err := d.parameter(p)
+				if err != nil {
+					return fmt.Errorf("%s: %w", where, err)
+				}
+				r.Parameters = append(r.Parameters, p)
+			}
+			for _, seg := range r.Segments {
+				name, ok := wildcard(seg)
+				if ok && r.Parameter("path", name) == nil {
+					return fmt.Errorf("%s: no path parameter %s", where, name)
+				}
+			}
+
+			if op.RequestBody != nil {
+				for _, mt := range op.RequestBody.Content.Values() {
+					if err := d.checkSchema(mt.Schema); err != nil {
+						return fmt.Errorf("%s: request body: %w", where, err)
+					}
+				}
+			}
+			for code, resp := range op.Responses {
+				resp, err := d.response(resp)
+				if err != nil {
+					return fmt.Errorf("%s: response %s: %w", where, code, err)
+				}
+				op.Responses[code] = resp
+			}
+			d.routes = append(d.routes, r)
+		}
+	}
+	return nil
+}
+
+// checkSecurity checks that requirements name only known schemes.
+func (d *Document) checkSecurity(reqs []SecurityRequirement) error {
+	for _, req := range reqs {
+		for name := range req {
+			if d.Components.SecuritySchemes[name] == nil {
+				return fmt.Errorf("security: no scheme %s", name)
+			}
+		}
+	}
+	return nil
+}
+
+func (d *Document) parameter(p *Parameter) (*Parameter, error) {
+	if p.Ref != "" {
+		name, ok := strings.CutPrefix(p.Ref, "#/components/parameters/")
+		ref := p.Ref
+		if p = d.Components.Parameters[name]; !ok || p == nil {
+			return nil, fmt.Errorf("unresolved reference %s", ref)
+		}
+	}
+	switch p.In {
+	case "path", "query", "header":
+	default:
+		return nil, fmt.Errorf("parameter %s: unsupported location %q", p.Name, p.In)
+	}
+	if err := d.checkSchema(p.Schema); err != nil {
+		return nil, fmt.Errorf("parameter %s: %w", p.Name, err)
+	}
+	return p, nil
+}
+
+func (d *Document) response(r *Response) (*Response, error) {
+	if r.Ref != "" {
+		name, ok := strings.CutPrefix(r.Ref, "#/components/responses/")
+		ref := r.Ref
+		if r = d.Components.Responses[name]; !ok || r == nil {
+			return nil, fmt.Errorf("unresolved reference %s", ref)
+		}
+	}
+	for name, h := range r.Headers {
+		if h.Ref != "" {
+			ref, _ := strings.CutPrefix(h.Ref, "#/components/headers/")
+			target := d.Components.Headers[ref]
+			if target == nil {
+				return nil, fmt.Errorf("header %s: unresolved reference %s", name, h.Ref)
+			}
+			r.Headers[name] = target
+			h = target
+		}
+		if err := d.checkSchema(h.Schema); err != nil {
+			return nil, fmt.Errorf("header %s: %w", name, err)
+		}
+	}
+	for _, mt := range r.Content.Values() {
+		if err := d.checkSchema(mt.Schema); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// Parameter returns the route's parameter with the location and name,
+// or nil.
+func (r *Route) Parameter(in, name string) *Parameter {
+	for _, p := range r.Parameters {
+		if p.In == in && p.Name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// Find returns the route for a request's method and path, with the
+// values of the path wildcards, or nil if the document has none.
+func (d *Document) Find(method, path string) (*Route, map[string]string) {
+	segs := strings.Split(path, "/")
+	for _, r := range d.routes {
+		if r.Method != method || len(r.Segments) != len(segs) {
+			continue
+		}
+		values := make(map[string]string)
+		for i, seg := range r.Segments {
+			if name, ok := wildcard(seg); ok && segs[i] != "" {
+				values[name] = segs[i]
+			} else if seg != segs[i] {
+				values = nil
+				break
+			}
+		}
+		if values != nil {
+			return r, values
+		}
+	}
+	return nil, nil
+}
+
+// wildcard reports whether a template segment is "{name}".
+func wildcard(seg string) (string, bool) {
+	if len(seg) > 2 && seg[0] == '{' && seg[len(seg)-1] == '}' {
+		return seg[1 : len(seg)-1], true
+	}
+	return "", false
+}
+
+// ========================================
+// ORDERED MAPS
+// ========================================
+
+// OrderedMap is a JSON object that remembers the order of its members,
+// which a Go map forgets. The order of a schema's properties becomes the
+// order of the generated struct's fields.
+type OrderedMap[V any] struct {
+	keys   []string
+	values map[string]V
+}
+
+func (m *OrderedMap[V]) UnmarshalJSON(b []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return fmt.Errorf("expected a JSON object")
+	}
+	m.keys, m.values = nil, make(map[string]V)
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key := tok.(string)
+		var v V
+		if err := dec.Decode(&v); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		if _, dup := m.values[key]; dup {
+			return fmt.Errorf("duplicate member %s", key)
+		}
+		m.keys = append(m.keys, key)
+		m.values[key] = v
+	}
+	_, err := dec.Token() // the closing }
+	return err
+}
+
+// Keys returns the member names in document order.
+func (m OrderedMap[V]) Keys() []string { return m.keys }
+
+// Get returns the member with the given name.
+func (m OrderedMap[V]) Get(key string) (V, bool) {
+	v, ok := m.values[key]
+	return v, ok
+}
+
+// Values returns the members in document order.
+func (m OrderedMap[V]) Values() []V {
+	vs := make([]V, len(m.keys))
+	for i, k := range m.keys {
+		vs[i] = m.values[k]
+	}
+	return vs
+}
+
+// Len returns the number of members.
+func (m OrderedMap[V]) Len() int { return len(m.keys) }
@@ -0,0 +1,360 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang-learning-project/pkg/errs"
+)
+
+// Schema is a JSON Schema, limited to the keywords below.
+type Schema struct {
+	Ref                  string              `json:"$ref"`
+	Description          string              `json:"description"`
+	Type                 Types               `json:"type"`
+	Enum                 []any               `json:"enum"`
+	Format               string              `json:"format"` // date, date-time or int64
+	Pattern              string              `json:"pattern"`
+	MinLength            *int                `json:"minLength"`
+	MaxLength            *int                `json:"maxLength"`
+	Minimum              *float64            `json:"minimum"`
+	Maximum              *float64            `json:"maximum"`
+	Properties           OrderedMap[*Schema] `json:"properties"`
+	Required             []string            `json:"required"`
+	AdditionalProperties *bool               `json:"additionalProperties"` // only false is meaningful
+	Items                *Schema             `json:"items"`
+	AnyOf                []*Schema           `json:"anyOf"`
+	ReadOnly             bool                `json:"readOnly"`
+	Default              any                 `json:"default"`
+}
+
+// Types is the "type" keyword: one JSON type name, or a list of them
+// such as ["string", "null"].
+type Types []string
+
+func (t *Types) UnmarshalJSON(b []byte) error {
+	var one string
+	if err := json.Unmarshal(b, &one); err == nil {
+		*t = Types{one}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(b, &list); err != nil {
+		return fmt.Errorf("type must be a string or a list of strings")
+	}
+	*t = list
+	return nil
+}
+
+// Has reports whether name is one of the types.
+func (t Types) Has(name string) bool { return slices.Contains(t, name) }
+
+// RefName returns the component name a "#/components/schemas/Name"
+// reference points at.
+func (s *Schema) RefName() string {
+	name, _ := strings.CutPrefix(s.Ref, "#/components/schemas/")
+	return name
+}
+
+// Resolve follows s's reference, if it has one.
+func (d *Document) Resolve(s *Schema) *Schema {
+	if s.Ref != "" {
+		return d.Components.Schemas[s.RefName()]
+	}
+	return s
+}
+
+// checkSchema checks that references resolve and patterns compile, in s
+// and every schema inside it.
+func (d *Document) checkSchema(s *Schema) error {
+	if s == nil {
+		return fmt.Errorf("missing schema")
+	}
+	if s.Ref != "" {
+		if !strings.HasPrefix(s.Ref, "#/components/schemas/") || d.Components.Schemas[s.RefName()] == nil {
+			return fmt.Errorf("unresolved reference %s", s.Ref)
+		}
+	}
+	for _, t := range s.Type {
+		switch t {
+		case "string", "integer", "number", "boolean", "null", "object", "array":
+		default:
+			return fmt.Errorf("unknown type %q", t)
+		}
+	}
+	switch s.Format {
+	case "", "date", "date-time", "int64":
+	default:
+		return fmt.Errorf("unsupported format %q", s.Format)
+	}
+	if s.Pattern != "" && d.patterns[s.Pattern] == nil {
+		re, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return fmt.Errorf("pattern: %w", err)
+		}
+		d.patterns[s.Pattern] = re
+	}
+	for _, name := range s.Properties.Keys() {
+		p, _ := s.Properties.Get(name)
+		if err := d.checkSchema(p); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	if s.Items != nil {
+		if err := d.checkSchema(s.Items); err != nil {
+			return fmt.Errorf("items: %w", err)
+		}
+	}
+	for _, sub := range s.AnyOf {
+		if err := d.checkSchema(sub); err != nil {
+			return fmt.Errorf("anyOf: %w", err)
+		}
+	}
+	return nil
+}
+
+// ========================================
+// VALIDATION
+// ========================================
+
+// ValidationError is one way a value breaks its schema. Name says where:
+// a body member ("title", "items[2].due"), a parameter ("limit",
+// "If-Match"), or "" for a whole body.
+type ValidationError struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+func (e *ValidationError) Error() string {
+	if e.Name == "" {
+		return e.Reason
+	}
+	return e.Name + ": " + e.Reason
+}
+
+// Unwrap makes every ValidationError match errs.ErrInvalidArgument.
+func (e *ValidationError) Unwrap() error { return errs.ErrInvalidArgument }
+
+// ValidationErrors returns every *ValidationError in err.
+func ValidationErrors(err error) []*ValidationError {
+	var list []*ValidationError
+	switch e := err.(type) {
+	case *ValidationError:
+		list = append(list, e)
+	case *errs.MultiError:
+		for _, inner := range e.Errors() {
+			list = append(list, ValidationErrors(inner)...)
+		}
+	}
+	return list
+}
+
+// direction tells validate which side of the exchange a value is on. A
+// readOnly property belongs to responses: a request may carry it, and
+// it is ignored rather than checked, but it need not.
+type direction int
+
+const (
+	inRequest direction = iota
+	inResponse
+)
+
+// decodeJSON decodes JSON the way validate expects it: numbers become
+// json.Number, so that 3 and 3.5 can be told apart.
+func decodeJSON(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("more than one JSON value")
+	}
+	return v, nil
+}
+
+// validate checks v, as returned by decodeJSON, against s, adding a
+// *ValidationError to c for every problem found.
+func (d *Document) validate(s *Schema, v any, name string, dir direction, c *errs.Collector) {
+	fail := func(format string, args ...any) {
+		c.Add(&ValidationError{name, fmt.Sprintf(format, args...)})
+	}
+
+	if s.Ref != "" {
+		d.validate(d.Resolve(s), v, name, dir, c)
+	}
+	if len(s.AnyOf) > 0 && !slices.ContainsFunc(s.AnyOf, func(sub *Schema) bool {
+		var sc errs.Collector
+		d.validate(sub, v, name, dir, &sc)
+		return sc.Len() == 0
+	}) {
+		fail("must be %s", d.describe(s))
+		return
+	}
+	if len(s.Type) > 0 && !slices.ContainsFunc(s.Type, func(t string) bool { return hasType(v, t) }) {
+		fail("must be %s", d.describe(s))
+		return
+	}
+	if len(s.Enum) > 0 && !slices.ContainsFunc(s.Enum, func(e any) bool { return sameJSON(e, v) }) {
+		fail("must be %s", d.describe(s))
+		return
+	}
+
+	switch v := v.(type) {
+	case string:
+		n := utf8.RuneCountInString(v)
+		if s.MinLength != nil && n < *s.MinLength {
+			fail("must be at least %d characters", *s.MinLength)
+		}
+		if s.MaxLength != nil && n > *s.MaxLength {
+			fail("must be at most %d characters", *s.MaxLength)
+		}
+		if s.Pattern != "" && !d.patterns[s.Pattern].MatchString(v) {
+			fail("must match %s", s.Pattern)
+		}
+		switch s.Format {
+		case "date":
+			if _, err := time.Parse(time.DateOnly, v); err != nil {
+				fail("must be a date such as 2026-01-31")
+			}
+		case "date-time":
+			if _, err := time.Parse(time.RFC3339Nano, v); err != nil {
+				fail("must be an RFC 3339 date and time")
+			}
+		}
+
+	case json.Number:
+		f, _ := v.Float64()
+		if s.Minimum != nil && f < *s.Minimum {
+			fail("must be at least %v", *s.Minimum)
+		}
+		if s.Maximum != nil && f > *s.Maximum {
+			fail("must be at most %v", *s.Maximum)
+		}
+
+	case map[string]any:
+		for _, req := range s.Required {
+			if _, ok := v[req]; ok {
+				continue
+			}
+			if p, ok := s.Properties.Get(req); ok && dir == inRequest && p.ReadOnly {
+				continue
+			}
+			c.Add(&ValidationError{join(name, req), "is required"})
+		}
+		for _, key := range slices.Sorted(maps.Keys(v)) {
+			p, ok := s.Properties.Get(key)
+			switch {
+			case !ok && s.AdditionalProperties != nil && !*s.AdditionalProperties:
+				c.Add(&ValidationError{join(name, key), "is not allowed"})
+			case ok && p.ReadOnly && dir == inRequest:
+				// sent back by the client; the server ignores it
+			case ok:
+				d.validate(p, v[key], join(name, key), dir, c)
+			}
+		}
+
+	case []any:
+		if s.Items != nil {
+			for i, item := range v {
+				d.validate(s.Items, item, fmt.Sprintf("%s[%d]", name, i), dir, c)
+			}
+		}
+	}
+}
+
+func join(name, member string) string {
+	if name == "" {
+		return member
+	}
+	return name + "." + member
+}
+
+// hasType reports whether a decoded JSON value is of the named type.
+func hasType(v any, t string) bool {
+	switch v := v.(type) {
+	case nil:
+		return t == "null"
+	case bool:
+		return t == "boolean"
+	case string:
+		return t == "string"
+	case json.Number:
+		if t == "number" {
+			return true
+		}
+		_, err := strconv.ParseInt(string(v), 10, 64)
+		return t == "integer" && err == nil
+	case map[string]any:
+		return t == "object"
+	case []any:
+		return t == "array"
+	}
+	return false
+}
+
+// sameJSON compares an enum value from the document with a decoded one.
+func sameJSON(a, b any) bool {
+	x, _ := json.Marshal(a)
+	y, _ := json.Marshal(b)
+	return bytes.Equal(x, y)
+}
+
+// describe says in words what s accepts, for error messages: "a
+// string", "one of todo, doing, done or null".
+func (d *Document) describe(s *Schema) string {
+	if s.Ref != "" {
+		return d.describe(d.Resolve(s))
+	}
+	var parts []string
+	for _, sub := range s.AnyOf {
+		parts = append(parts, d.describe(sub))
+	}
+	if len(s.Enum) > 0 {
+		vals := make([]string, len(s.Enum))
+		for i, e := range s.Enum {
+			b, _ := json.Marshal(e)
+			vals[i] = strings.Trim(string(b), `"`)
+		}
+		parts = append(parts, "one of "+strings.Join(vals, ", "))
+	} else {
+		for _, t := range s.Type {
+			switch t {
+			case "null":
+				parts = append(parts, "null")
+			case "integer":
+				parts = append(parts, "an integer")
+			case "array", "object":
+				parts = append(parts, "an "+t)
+			default:
+				parts = append(parts, "a "+t)
+			}
+		}
+	}
+	if len(parts) == 0 {
+		return "valid"
+	}
+	return strings.Join(parts, " or ")
+}
+
+// parseParam turns the text of a path, query or header parameter into
+// the value validate expects: a json.Number for a number that parses,
+// the text otherwise, so that a bad number fails the type check.
+func (d *Document) parseParam(s *Schema, text string) any {
+	s = d.Resolve(s)
+	if s.Type.Has("integer") || s.Type.Has("number") {
+		if _, err := strconv.ParseFloat(text, 64); err == nil {
+			return json.Number(text)
+		}
+	}
+	return text
+}
@@ -0,0 +1,269 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"golang-learning-project/internal/lessons"
+)
+
+// command is one `demo <name>` subcommand.
+type command struct {
+	Name    string
+	Args    string // usage of the arguments after the name
+	Summary string
+	Run     func(root string, args []string) error
+}
+
+// errReported is returned by commands that already told the user what
+// went wrong; main exits non-zero without printing it again.
+var errReported = errors.New("reported")
+
+// errUsage is returned for bad arguments; main prints the command usage.
+var errUsage = errors.New("usage")
+
+// commands returns the subcommands in the order `demo help` lists them.
+func commands() []command {
+	return []command{
+		{"menu", "", "interactive numbered menu (the default)", runMenu},
+		{"list", "[--json]", "list the discovered lessons with level and topic", runList},
+		{"info", "[--json] <lesson>", "show a lesson's file and Key Concepts", runInfo},
+		{"run", "[--json] <lesson>", "build and run a lesson's example", runRun},
+		{"exercise", "[--json] [--reset] <lesson>", "check your solutions to a lesson's exercise", runExercise},
+		{"hint", "[--solution] <function>", "reveal the next hint for an exercise function", runHint},
+		{"quiz", "<lesson>", "answer questions on a lesson's Key Concepts", runQuiz},
+		{"progress", "[--json]", "show runs, exercise results and hint usage", runProgress},
+		{"verify", "[-update] [lesson...]", "compare example output with the golden files", runVerify},
+		{"slices", "[scenario...]", "draw how append, reslice and copy move slice backing arrays", runSlices},
+		{"inspect", "[--json] [-x] <string | ->", "show the bytes, runes, UTF-8 encoding and normalization of a string", runInspect},
+		{"serve", "[-addr host:port]", "browse the lessons and run code in a web page", runServe},
+	}
+}
+
+// dispatch runs the subcommand name with args.
+func dispatch(root, name string, args []string) error {
+	if name == "help" || name == "-h" || name == "--help" {
+		printUsage(os.Stdout)
+		return nil
+	}
+	for _, c := range commands() {
+		if c.Name != name {
+			continue
+		}
+		err := c.Run(root, args)
+		if errors.Is(err, errUsage) {
+			fmt.Fprintf(os.Stderr, "usage: demo %s %s\n", c.Name, c.Args)
+			return errReported
+		}
+		return err
+	}
+	printUsage(os.Stderr)
+	return fmt.Errorf("unknown command %q", name)
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "usage: demo [command] [arguments]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, c := range commands() {
+		fmt.Fprintf(tw, "  %s %s\t%s\n", c.Name, c.Args, c.Summary)
+	}
+	tw.Flush()
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "A <lesson> is a catalog number (3), a name (functions) or an id (01-basics/functions).")
+	fmt.Fprintln(w, "A <function> is an exercise function, optionally qualified: functions.divideWithRemainder.")
+}
+
+// parseFlags parses fs from args, allowing flags before and after the
+// positional arguments (`demo info functions --json`), and returns the
+// positional arguments.
+func parseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	fs.SetOutput(io.Discard)
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, errUsage
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// lessonArg discovers the catalog and resolves the single <lesson>
+// argument of info and run.
+func lessonArg(root string, args []string) (lessons.Lesson, error) {
+	if len(args) != 1 {
+		return lessons.Lesson{}, errUsage
+	}
+	catalog, err := lessons.Discover(root)
+	if err != nil {
+		return lessons.Lesson{}, err
+	}
+	return lessons.Find(catalog, args[0])
+}
+
+// indent prefixes every line of s with prefix.
+func indent(s, prefix string) string {
+	return prefix + strings.ReplaceAll(s, "\n", "\n"+prefix)
+}
+
+func writeJSON(v any) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(v)
+}
+
+func runMenu(root string, args []string) error {
+	if len(args) != 0 {
+		return errUsage
+	}
+	printBanner()
+	store := openProgress()
+	if store != nil {
+		defer store.Close()
+	}
+	return showMenu(root, bufio.NewScanner(os.Stdin), store)
+}
+
+func runList(root string, args []string) error {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "print the catalog as JSON")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 0 {
+		return errUsage
+	}
+
+	catalog, err := lessons.Discover(root)
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		if catalog == nil {
+			catalog = []lessons.Lesson{}
+		}
+		return writeJSON(catalog)
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "#\tLEVEL\tLESSON\tTOPIC")
+	for _, l := range catalog {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\n", l.Number, l.Level, l.Name, l.Topic)
+	}
+	return tw.Flush()
+}
+
+func runInfo(root string, args []string) error {
+	fs := flag.NewFlagSet("info", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "print the lesson as JSON")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	l, err := lessonArg(root, args)
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		return writeJSON(l)
+	}
+
+	fmt.Printf("%s\n\n", l.Topic)
+	fmt.Printf("  Lesson: %s (#%d)\n", l.ID, l.Number)
+	fmt.Printf("  File:   %s\n", l.File)
+	fmt.Printf("  Run:    go run ./%s\n", l.Dir)
+	if l.Server != "" {
+		fmt.Printf("  Serves: %s until Ctrl+C\n", l.Server)
+	}
+	if l.Summary != "" {
+		fmt.Printf("\n%s\n", l.Summary)
+	}
+	if len(l.Concepts) > 0 {
+		fmt.Println("\nKey Concepts:")
+		for _, c := range l.Concepts {
+			fmt.Printf("  - %s\n", c)
+		}
+	}
+	return nil
+}
+
+// runReport is the --json output of `demo run`.
+type runReport struct {
+	Lesson     string `json:"lesson"`
+	File       string `json:"file"`
+	ExitCode   int    `json:"exit_code"`
+	DurationMS int64  `json:"duration_ms"`
+	Stdout     string `json:"stdout"`
+	Stderr     string `json:"stderr"`
+	Error      string `json:"error,omitempty"`
+}
+
+func runRun(root string, args []string) error {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "capture the output and print a JSON report")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	l, err := lessonArg(root, args)
+	if err != nil {
+		return err
+	}
+
+	store := openProgress()
+	if store != nil {
+		defer store.Close()
+	}
+
+	if !*asJSON {
+		res, err := l.Run(root, os.Stdout, os.Stderr)
+		if err != nil {
+			return err
+		}
+		recordRun(store, l, res.ExitCode)
+		if res.ExitCode != 0 {
+			return fmt.Errorf("%s exited with status %d", l.ID, res.ExitCode)
+		}
+		return nil
+	}
+
+	var stdout, stderr bytes.Buffer
+	res, runErr := l.Run(root, &stdout, &stderr)
+	report := runReport{
+		Lesson:     l.ID,
+		File:       l.File,
+		ExitCode:   res.ExitCode,
+		DurationMS: res.Duration.Milliseconds(),
+		Stdout:     stdout.String(),
+		Stderr:     stderr.String(),
+	}
+	if runErr != nil {
+		report.ExitCode = -1
+		report.Error = runErr.Error()
+	} else {
+		recordRun(store, l, res.ExitCode)
+	}
+	if err := writeJSON(report); err != nil {
+		return err
+	}
+	if report.ExitCode != 0 {
+		return errReported
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"golang-learning-project/internal/exercise"
+)
+
+func runExercise(root string, args []string) error {
+	fs := flag.NewFlagSet("exercise", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "print the report as JSON")
+	reset := fs.Bool("reset", false, "overwrite the workspace copy with fresh stubs")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	l, err := lessonArg(root, args)
+	if err != nil {
+		return err
+	}
+	ex, err := exercise.Load(root, l)
+	if err != nil {
+		return err
+	}
+
+	created, err := ex.Prepare(root, *reset)
+	if err != nil {
+		return err
+	}
+	if created && !*asJSON {
+		fmt.Printf("📝 Stubs copied to %s\n", ex.Workspace)
+		fmt.Printf("   Implement the functions there, then run: go run ./cmd/demo exercise %s\n\n", l.Name)
+	}
+
+	report, err := ex.Check(root)
+	if err != nil {
+		return err
+	}
+	if store := openProgress(); store != nil {
+		recordAttempts(store, report)
+		store.Close()
+	}
+	if *asJSON {
+		if err := writeJSON(report); err != nil {
+			return err
+		}
+	} else {
+		printExerciseReport(ex, report)
+	}
+	if report.Passed() < len(report.Results) {
+		return errReported
+	}
+	return nil
+}
+
+// maxFailuresShown limits the failing inputs printed per function; the
+// --json report has all of them.
+const maxFailuresShown = 3
+
+func printExerciseReport(ex exercise.Exercise, report exercise.Report) {
+	fmt.Printf("Exercise: %s (%s)\n\n", ex.Lesson.Topic, ex.Workspace)
+	if report.BuildErrors != "" {
+		fmt.Println("❌ Your code does not compile:")
+		fmt.Println(indent(report.BuildErrors, "   "))
+		fmt.Println()
+	}
+	for _, res := range report.Results {
+		if res.Passed {
+			fmt.Printf("  ✅ %s\n", res.Function)
+			continue
+		}
+		if !res.Started {
+			fmt.Printf("  ⬜ %s (not started)\n", res.Function)
+			continue
+		}
+		fmt.Printf("  ❌ %s\n", res.Function)
+		for i, f := range res.Failures {
+			if i == maxFailuresShown {
+				fmt.Printf("       … and %d more\n", len(res.Failures)-i)
+				break
+			}
+			fmt.Printf("       %s\n", f)
+		}
+	}
+	fmt.Printf("\n%d/%d functions pass\n", report.Passed(), len(report.Results))
+}
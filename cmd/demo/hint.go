@@ -0,0 +1,139 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+
+	"golang-learning-project/internal/exercise"
+	"golang-learning-project/internal/lessons"
+	"golang-learning-project/internal/progress"
+)
+
+func runHint(root string, args []string) error {
+	fs := flag.NewFlagSet("hint", flag.ContinueOnError)
+	solution := fs.Bool("solution", false, "reveal the reference solution (after enough failed attempts)")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		return errUsage
+	}
+	ex, fn, err := exerciseFunctionArg(root, args[0])
+	if err != nil {
+		return err
+	}
+	hints, err := ex.Hints(root)
+	if err != nil {
+		return err
+	}
+
+	store := openProgress()
+	if store == nil {
+		return errors.New("hints are tracked in the progress database, which could not be opened")
+	}
+	defer store.Close()
+	recorded, err := store.Lessons()
+	if err != nil {
+		return err
+	}
+	var f progress.Function
+	if p := recorded[ex.Lesson.ID]; p != nil {
+		f = p.Functions[fn]
+	}
+
+	if *solution {
+		if f.Failures < hints.SolutionAfter && !f.SolutionRevealed {
+			fmt.Printf("🔒 The solution to %s unlocks after %d failed attempts; you have %d.\n",
+				fn, hints.SolutionAfter, f.Failures)
+			fmt.Printf("   Try `go run ./cmd/demo hint %s` for a nudge first.\n", fn)
+			return errReported
+		}
+		src, err := ex.Solution(root, fn)
+		if err != nil {
+			return err
+		}
+		if err := store.RecordSolution(ex.Lesson.ID, fn); err != nil {
+			return err
+		}
+		fmt.Printf("🔓 Reference solution for %s:\n\n%s\n", fn, src)
+		return nil
+	}
+
+	list := hints.Functions[fn]
+	if len(list) == 0 {
+		fmt.Printf("There are no hints for %s.\n", fn)
+		return nil
+	}
+	for i := 0; i < f.HintsRevealed && i < len(list); i++ {
+		fmt.Printf("   Hint %d/%d: %s\n", i+1, len(list), list[i])
+	}
+	next := f.HintsRevealed + 1
+	if next > len(list) {
+		fmt.Printf("\nThat was every hint for %s.\n", fn)
+		if f.Failures >= hints.SolutionAfter || f.SolutionRevealed {
+			fmt.Printf("The solution is unlocked: go run ./cmd/demo hint --solution %s\n", fn)
+		} else {
+			fmt.Printf("The solution unlocks after %d failed attempts; you have %d.\n", hints.SolutionAfter, f.Failures)
+		}
+		return nil
+	}
+	if err := store.RecordHint(ex.Lesson.ID, fn, next); err != nil {
+		return err
+	}
+	fmt.Printf("💡 Hint %d/%d: %s\n", next, len(list), list[next-1])
+	return nil
+}
+
+// exerciseFunctionArg resolves an exercise function named either on its
+// own ("divideWithRemainder") or qualified by any form of lesson that
+// lessons.Find accepts ("functions.divideWithRemainder").
+func exerciseFunctionArg(root, arg string) (exercise.Exercise, string, error) {
+	catalog, err := lessons.Discover(root)
+	if err != nil {
+		return exercise.Exercise{}, "", err
+	}
+
+	if i := strings.LastIndex(arg, "."); i >= 0 {
+		l, err := lessons.Find(catalog, arg[:i])
+		if err != nil {
+			return exercise.Exercise{}, "", err
+		}
+		ex, err := exercise.Load(root, l)
+		if err != nil {
+			return exercise.Exercise{}, "", err
+		}
+		fn := arg[i+1:]
+		if !ex.HasFunction(fn) {
+			return exercise.Exercise{}, "", fmt.Errorf("exercise %s has no function %q", l.ID, fn)
+		}
+		return ex, fn, nil
+	}
+
+	var matches []exercise.Exercise
+	for _, l := range catalog {
+		ex, err := exercise.Load(root, l)
+		if errors.Is(err, exercise.ErrNoExercise) {
+			continue
+		}
+		if err != nil {
+			return exercise.Exercise{}, "", err
+		}
+		if ex.HasFunction(arg) {
+			matches = append(matches, ex)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return exercise.Exercise{}, "", fmt.Errorf("no exercise has a function %q", arg)
+	case 1:
+		return matches[0], arg, nil
+	}
+	names := make([]string, len(matches))
+	for i, m := range matches {
+		names[i] = m.Lesson.Name + "." + arg
+	}
+	return exercise.Exercise{}, "", fmt.Errorf("%q is ambiguous: %s", arg, strings.Join(names, ", "))
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang-learning-project/pkg/textinspect"
+)
+
+func runInspect(root string, args []string) error {
+	fs := flag.NewFlagSet("inspect", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "print the report as JSON")
+	escapes := fs.Bool("x", false, `interpret Go escapes such as \xff, \u0301 and \n in the argument`)
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		return errUsage
+	}
+
+	s := args[0]
+	switch {
+	case s == "-":
+		// Read stdin as is, so bytes a shell would mangle can be piped in.
+		b, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+		s = string(b)
+	case *escapes:
+		s, err = strconv.Unquote(`"` + strings.ReplaceAll(s, `"`, `\"`) + `"`)
+		if err != nil {
+			return fmt.Errorf("-x: %q is not a valid Go string body", args[0])
+		}
+	}
+
+	report := textinspect.Inspect(s)
+	if *asJSON {
+		return writeJSON(report)
+	}
+	return report.WriteText(os.Stdout)
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"golang-learning-project/internal/lessons"
+	"golang-learning-project/internal/progress"
+)
+
+// showMenu prints the numbered menu, reads a choice from in and runs the
+// chosen lesson, until the user quits or in reaches EOF. The catalog is
+// rediscovered before every prompt so new examples show up immediately.
+// Runs are recorded in store, which may be nil.
+func showMenu(root string, in *bufio.Scanner, store *progress.Store) error {
+	for {
+		catalog, err := lessons.Discover(root)
+		if err != nil {
+			return err
+		}
+		printMenu(root, catalog, store)
+
+		fmt.Printf("\nChoose a lesson (1-%d, q to quit): ", len(catalog))
+		if !in.Scan() {
+			fmt.Println()
+			break
+		}
+		choice := strings.TrimSpace(in.Text())
+		if choice == "" {
+			continue
+		}
+		if choice == "q" || choice == "quit" {
+			break
+		}
+
+		n, err := strconv.Atoi(choice)
+		if err != nil || n < 1 || n > len(catalog) {
+			fmt.Printf("❌ %q is not a menu entry\n\n", choice)
+			continue
+		}
+		l := catalog[n-1]
+
+		fmt.Printf("\n▶ Running %s (%s)\n", l.Topic, l.Dir)
+		if l.Server != "" {
+			fmt.Printf("  It serves %s until you press Ctrl+C, which brings you back here.\n", l.Server)
+		}
+		printRule()
+		res, err := l.Run(root, os.Stdout, os.Stderr)
+		if err == nil {
+			recordRun(store, l, res.ExitCode)
+		}
+		if err == nil && res.ExitCode != 0 {
+			err = fmt.Errorf("exit status %d", res.ExitCode)
+		}
+		if err != nil {
+			printRule()
+			fmt.Printf("❌ %s: %v\n\n", l.Topic, err)
+			continue
+		}
+		printRule()
+		fmt.Printf("✅ %s finished\n\n", l.Topic)
+	}
+
+	fmt.Println("\n✨ Happy Learning! ✨")
+	return nil
+}
+
+// printMenu lists every discovered lesson under its level, with a check
+// mark and completion percentage from store (if any) and the command
+// that runs it directly.
+func printMenu(root string, catalog []lessons.Lesson, store *progress.Store) {
+	var recorded map[string]*progress.Lesson
+	if store != nil {
+		var err error
+		if recorded, err = store.Lessons(); err != nil {
+			fmt.Fprintf(os.Stderr, "demo: reading progress: %v\n", err)
+		}
+	}
+
+	var sumDone, sumTotal int
+	lines := make([]string, len(catalog))
+	for i, l := range catalog {
+		done, total := completion(root, l, recorded[l.ID])
+		sumDone += done
+		sumTotal += total
+		mark := " "
+		if done == total {
+			mark = "✓"
+		}
+		lines[i] = fmt.Sprintf("   %s %2d. %-32s %3d%%   go run ./%s", mark, l.Number, l.Topic, percent(done, total), l.Dir)
+	}
+
+	fmt.Printf("📚 Learning Modules Available (%d%% complete):\n", percent(sumDone, sumTotal))
+	level := ""
+	for i, l := range catalog {
+		if l.Level != level {
+			level = l.Level
+			fmt.Printf("\n🔹 %s:\n", level)
+		}
+		fmt.Println(lines[i])
+	}
+}
+
+func printRule() {
+	fmt.Println(strings.Repeat("─", 56))
+}
+
+// findRepoRoot walks up from the working directory to the directory
+// holding go.mod, so the demo works from anywhere inside the project.
+func findRepoRoot() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("go.mod not found; run the demo from inside the project")
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"golang-learning-project/internal/exercise"
+	"golang-learning-project/internal/lessons"
+	"golang-learning-project/internal/progress"
+)
+
+// openProgress opens the learner's progress database. Progress is a
+// convenience, so if it cannot be opened the demo warns and carries on
+// without it; every caller accepts a nil store.
+func openProgress() *progress.Store {
+	path, err := progress.DefaultPath()
+	if err == nil {
+		var store *progress.Store
+		if store, err = progress.Open(path); err == nil {
+			return store
+		}
+	}
+	fmt.Fprintf(os.Stderr, "demo: progress will not be saved: %v\n", err)
+	return nil
+}
+
+// recordRun saves a run of l, if there is a store.
+func recordRun(store *progress.Store, l lessons.Lesson, exitCode int) {
+	if store == nil {
+		return
+	}
+	if err := store.RecordRun(l.ID, exitCode); err != nil {
+		fmt.Fprintf(os.Stderr, "demo: saving progress: %v\n", err)
+	}
+}
+
+// recordAttempts saves the result of every function in an exercise
+// report, if there is a store. A function still as its stub was not
+// attempted; counting it would unlock its solution for running the
+// checks a few times.
+func recordAttempts(store *progress.Store, report exercise.Report) {
+	if store == nil {
+		return
+	}
+	for _, res := range report.Results {
+		if !res.Started && !res.Passed {
+			continue
+		}
+		if err := store.RecordAttempt(report.Exercise, res.Function, res.Passed); err != nil {
+			fmt.Fprintf(os.Stderr, "demo: saving progress: %v\n", err)
+			return
+		}
+	}
+}
+
+// completion returns how many of a lesson's steps are done: running the
+// example once, plus passing each function of its exercise, if any.
+func completion(root string, l lessons.Lesson, p *progress.Lesson) (done, total int) {
+	total = 1
+	if p != nil && p.Ran() {
+		done = 1
+	}
+
+	ex, err := exercise.Load(root, l)
+	if err != nil {
+		// Most often exercise.ErrNoExercise: only the run counts.
+		return done, total
+	}
+	total += len(ex.Functions)
+	if p == nil {
+		return done, total
+	}
+	for _, fn := range ex.Functions {
+		if p.Functions[fn].Passed {
+			done++
+		}
+	}
+	return done, total
+}
+
+// percent returns done/total as a whole percentage.
+func percent(done, total int) int {
+	if total == 0 {
+		return 0
+	}
+	return done * 100 / total
+}
+
+// lessonReport is one lesson of `demo progress`.
+type lessonReport struct {
+	Lesson    string           `json:"lesson"`
+	Topic     string           `json:"topic"`
+	Runs      int              `json:"runs"`
+	Percent   int              `json:"percent"`
+	Quizzes   int              `json:"quizzes"`
+	QuizBest  int              `json:"quiz_best"`
+	QuizTotal int              `json:"quiz_total"`
+	Functions []functionReport `json:"functions,omitempty"`
+}
+
+// functionReport is one exercise function of `demo progress`.
+type functionReport struct {
+	Function         string `json:"function"`
+	Passed           bool   `json:"passed"`
+	Attempts         int    `json:"attempts"`
+	HintsRevealed    int    `json:"hints_revealed"`
+	HintsTotal       int    `json:"hints_total"`
+	SolutionRevealed bool   `json:"solution_revealed"`
+}
+
+func runProgress(root string, args []string) error {
+	fs := flag.NewFlagSet("progress", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "print the report as JSON")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 0 {
+		return errUsage
+	}
+
+	store := openProgress()
+	if store == nil {
+		return errors.New("the progress database could not be opened")
+	}
+	defer store.Close()
+	recorded, err := store.Lessons()
+	if err != nil {
+		return err
+	}
+	catalog, err := lessons.Discover(root)
+	if err != nil {
+		return err
+	}
+
+	reports := []lessonReport{}
+	for _, l := range catalog {
+		p := recorded[l.ID]
+		if p == nil {
+			p = &progress.Lesson{}
+		}
+		done, total := completion(root, l, p)
+		r := lessonReport{
+			Lesson:    l.ID,
+			Topic:     l.Topic,
+			Runs:      p.Runs,
+			Percent:   percent(done, total),
+			Quizzes:   p.Quizzes,
+			QuizBest:  p.QuizBest,
+			QuizTotal: p.QuizTotal,
+		}
+
+		if ex, err := exercise.Load(root, l); err == nil {
+			hints, err := ex.Hints(root)
+			if err != nil {
+				return err
+			}
+			for _, fn := range ex.Functions {
+				f := p.Functions[fn]
+				r.Functions = append(r.Functions, functionReport{
+					Function:         fn,
+					Passed:           f.Passed,
+					Attempts:         f.Attempts,
+					HintsRevealed:    f.HintsRevealed,
+					HintsTotal:       len(hints.Functions[fn]),
+					SolutionRevealed: f.SolutionRevealed,
+				})
+			}
+		}
+		reports = append(reports, r)
+	}
+
+	if *asJSON {
+		return writeJSON(reports)
+	}
+	for _, r := range reports {
+		line := fmt.Sprintf("%-28s %3d%%  (%d runs", r.Lesson, r.Percent, r.Runs)
+		if r.Quizzes > 0 {
+			line += fmt.Sprintf(", best quiz %d/%d", r.QuizBest, r.QuizTotal)
+		}
+		fmt.Println(line + ")")
+		for _, f := range r.Functions {
+			status := "  "
+			if f.Passed {
+				status = "✅"
+			} else if f.Attempts > 0 {
+				status = "❌"
+			}
+			line := fmt.Sprintf("    %s %-20s %2d attempts   hints %d/%d", status, f.Function, f.Attempts, f.HintsRevealed, f.HintsTotal)
+			if f.SolutionRevealed {
+				line += "   solution revealed"
+			}
+			fmt.Println(line)
+		}
+	}
+	return nil
+}
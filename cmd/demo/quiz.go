@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang-learning-project/internal/quiz"
+)
+
+func runQuiz(root string, args []string) error {
+	fs := flag.NewFlagSet("quiz", flag.ContinueOnError)
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	l, err := lessonArg(root, args)
+	if err != nil {
+		return err
+	}
+	q, err := quiz.Load(root, l)
+	if err != nil {
+		return err
+	}
+
+	// "Predict the output" answers come from the example itself, so
+	// they can never drift from what the code really prints.
+	if q.NeedsOutput() {
+		fmt.Println("⏳ Running the example to collect its output...")
+		var stdout, stderr bytes.Buffer
+		res, err := l.Run(root, &stdout, &stderr)
+		if err == nil && res.ExitCode != 0 {
+			err = fmt.Errorf("exit status %d: %s", res.ExitCode, strings.TrimSpace(stderr.String()))
+		}
+		if err != nil {
+			return fmt.Errorf("running %s: %w", l.ID, err)
+		}
+		if err := q.ResolveAnswers(stdout.String()); err != nil {
+			return fmt.Errorf("%s/%s: %w", l.Dir, quiz.File, err)
+		}
+	}
+
+	fmt.Printf("\n📝 Quiz: %s (%d questions)\n", l.Topic, len(q.Questions))
+	in := bufio.NewScanner(os.Stdin)
+	score := 0
+	for i, question := range q.Questions {
+		fmt.Printf("\n%d. [%s] %s\n", i+1, question.Concept, question.Question)
+		if question.Code != "" {
+			fmt.Println()
+			fmt.Println(indent(question.Code, "       "))
+		}
+		for j, opt := range question.Options {
+			fmt.Printf("     %d) %s\n", j+1, opt)
+		}
+
+		fmt.Print("   > ")
+		if !in.Scan() {
+			fmt.Println()
+			return fmt.Errorf("quiz abandoned after %d of %d questions", i, len(q.Questions))
+		}
+		if question.Correct(in.Text()) {
+			score++
+			fmt.Println("   ✅ Correct!")
+		} else {
+			fmt.Printf("   ❌ The answer is: %s\n", question.CorrectAnswer())
+		}
+		if question.Explanation != "" {
+			fmt.Printf("      %s\n", question.Explanation)
+		}
+	}
+
+	fmt.Printf("\nScore: %d/%d\n", score, len(q.Questions))
+	if store := openProgress(); store != nil {
+		if err := store.RecordQuiz(l.ID, score, len(q.Questions)); err != nil {
+			fmt.Fprintf(os.Stderr, "demo: saving progress: %v\n", err)
+		}
+		store.Close()
+	}
+	return nil
+}
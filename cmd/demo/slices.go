@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"golang-learning-project/pkg/slicetrace"
+)
+
+// sliceScenario is one `demo slices` walkthrough.
+type sliceScenario struct {
+	Name    string
+	Summary string
+	Run     func() string // returns the rendered trace
+}
+
+// sliceScenarios follow the SLICE INTERNALS and SLICE OPERATIONS
+// sections of examples/01-basics/collections.
+func sliceScenarios() []sliceScenario {
+	return []sliceScenario{
+		{"growth", "append within capacity, then past it", func() string {
+			var t slicetrace.Trace[int]
+			numbers := t.Make("numbers", 3, 5)
+			numbers = t.Append("numbers", numbers, 10, 20)
+			t.Append("numbers", numbers, 30)
+			return t.String()
+		}},
+		{"alias", "a sub-slice shares its array, so appending to it overwrites the original", func() string {
+			var t slicetrace.Trace[int]
+			nums := t.Literal("nums", 1, 2, 3, 4, 5)
+			head := t.Slice("head", nums, 0, 2)
+			head = t.Append("head", head, 99)
+			t.Append("head", head, 100, 101, 102)
+			return t.String()
+		}},
+		{"remove", `the "remove by append" trap: append(s[:i], s[i+1:]...) rewrites s`, func() string {
+			var t slicetrace.Trace[string]
+			colors := t.Literal("colors", "red", "green", "blue", "yellow", "purple")
+			t.AppendSlice("rest", colors[:2], colors[3:])
+			// Removing into a copy leaves colors alone.
+			safe := t.Assign("safe", slices.Clone(colors[:2]), "slices.Clone(colors[:2])")
+			t.AppendSlice("safe", safe, colors[3:])
+			return t.String()
+		}},
+		{"copy", "copy between arrays, overlapping copy, and a three-index slice", func() string {
+			var t slicetrace.Trace[int]
+			src := t.Literal("src", 1, 2, 3, 4)
+			dst := t.Make("dst", 3, 3)
+			t.Copy(dst, src)
+			t.Copy(src[1:], src)
+			head := t.Slice3("head", src, 0, 2, 2)
+			t.Append("head", head, 99)
+			return t.String()
+		}},
+	}
+}
+
+func runSlices(root string, args []string) error {
+	all := sliceScenarios()
+	if len(args) == 0 {
+		for i, s := range all {
+			if i > 0 {
+				fmt.Println()
+			}
+			printScenario(s)
+		}
+		return nil
+	}
+	for i, name := range args {
+		j := slices.IndexFunc(all, func(s sliceScenario) bool { return s.Name == name })
+		if j < 0 {
+			var names []string
+			for _, s := range all {
+				names = append(names, s.Name)
+			}
+			return fmt.Errorf("unknown scenario %q (have %s)", name, strings.Join(names, ", "))
+		}
+		if i > 0 {
+			fmt.Println()
+		}
+		printScenario(all[j])
+	}
+	return nil
+}
+
+func printScenario(s sliceScenario) {
+	title := fmt.Sprintf("%s: %s", s.Name, s.Summary)
+	fmt.Printf("%s\n%s\n\n", title, strings.Repeat("=", len(title)))
+	fmt.Print(s.Run())
+}
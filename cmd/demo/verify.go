@@ -0,0 +1,62 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"golang-learning-project/internal/golden"
+	"golang-learning-project/internal/lessons"
+)
+
+func runVerify(root string, args []string) error {
+	fs := flag.NewFlagSet("verify", flag.ContinueOnError)
+	update := fs.Bool("update", false, "rewrite the golden files from the current output")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	catalog, err := lessons.Discover(root)
+	if err != nil {
+		return err
+	}
+
+	// Without arguments every lesson is checked, but -update only
+	// rewrites existing golden files: a long-running example such as a
+	// server must never get one by accident. Name a lesson to create it.
+	targets := catalog
+	if len(args) > 0 {
+		targets = nil
+		for _, a := range args {
+			l, err := lessons.Find(catalog, a)
+			if err != nil {
+				return err
+			}
+			targets = append(targets, l)
+		}
+	}
+
+	failed := 0
+	for _, l := range targets {
+		res := golden.Check(root, l, *update && (len(args) > 0 || golden.Has(root, l)))
+		switch res.Status {
+		case golden.StatusSkip:
+			fmt.Printf("%-7s %s (no %s; run demo verify -update %s)\n", res.Status, l.ID, golden.GoldenFile, l.Name)
+		case golden.StatusFail:
+			failed++
+			if res.Err != nil {
+				fmt.Printf("%-7s %s: %v\n", res.Status, l.ID, res.Err)
+			} else {
+				fmt.Printf("%-7s %s\n%s", res.Status, l.ID, res.Diff)
+			}
+		default:
+			fmt.Printf("%-7s %s\n", res.Status, l.ID)
+		}
+	}
+
+	if failed > 0 {
+		fmt.Printf("\n%d of %d lessons failed\n", failed, len(targets))
+		return errReported
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+// Command wordfreq prints the most frequent words or n-grams of its
+// input files, or of standard input when none are given.
+//
+// Usage:
+//
+//	go run ./cmd/wordfreq [flags] [file ...]
+//
+// Flags:
+//
+//	-k 10            number of terms to print
+//	-format table    table, csv or json
+//	-fold=true       case fold words, so "The" and "the" are one word
+//	-stop en         stop words to drop: en, none, or a file with one per line
+//	-ngram 1         count runs of N words instead of single words
+//	-mem 64MiB       memory budget for exact counts, or none (0) for no limit
+//
+// Counts are exact while they fit in -mem. Past it wordfreq switches to
+// a count-min sketch of that size and a top-K heap: memory stays fixed,
+// and counts may be slightly too high but never too low. The output
+// says when that happened.
+//
+// Examples:
+//
+//	go run ./cmd/wordfreq -k 20 README.md
+//	cat *.md | go run ./cmd/wordfreq -ngram 2 -format csv
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"golang-learning-project/pkg/collections"
+	"golang-learning-project/pkg/textstat"
+)
+
+// report is the -format json output.
+type report struct {
+	Tokens      int              `json:"tokens"`
+	Distinct    int              `json:"distinct,omitempty"` // unknown once approximate
+	Approximate bool             `json:"approximate"`
+	Top         []textstat.Entry `json:"top"`
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, "wordfreq:", err)
+		}
+		os.Exit(2)
+	}
+}
+
+func run(args []string, stdin io.Reader, stdout io.Writer) error {
+	fs := flag.NewFlagSet("wordfreq", flag.ContinueOnError)
+	k := fs.Int("k", 10, "number of terms to print")
+	format := fs.String("format", "table", "output format: table, csv or json")
+	fold := fs.Bool("fold", true, "case fold words")
+	stop := fs.String("stop", "en", "stop words: en, none, or a file with one word per line")
+	ngram := fs.Int("ngram", 1, "count runs of `N` words")
+	mem := fs.String("mem", "64MiB", "memory budget for exact counts, e.g. 512KiB or 1GiB; none or 0 for no limit")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *k < 1 || *ngram < 1 {
+		return errors.New("-k and -ngram must be at least 1")
+	}
+	if *format != "table" && *format != "csv" && *format != "json" {
+		return fmt.Errorf("unknown -format %q", *format)
+	}
+	budget, err := parseSize(*mem)
+	if err != nil {
+		return fmt.Errorf("-mem: %w", err)
+	}
+	stopWords, err := loadStopWords(*stop)
+	if err != nil {
+		return err
+	}
+
+	a := textstat.New(textstat.Options{
+		TopK:      *k,
+		Fold:      *fold,
+		StopWords: stopWords,
+		NGram:     *ngram,
+		MaxMemory: budget,
+	})
+	files := fs.Args()
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+	for _, name := range files {
+		if err := readFile(a, name, stdin); err != nil {
+			return err
+		}
+	}
+
+	top := a.Top()
+	switch *format {
+	case "json":
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report{
+			Tokens:      a.Tokens(),
+			Distinct:    max(a.Distinct(), 0),
+			Approximate: a.Approximate(),
+			Top:         top,
+		})
+	case "csv":
+		w := csv.NewWriter(stdout)
+		w.Write([]string{"term", "count"})
+		for _, e := range top {
+			w.Write([]string{e.Term, strconv.Itoa(e.Count)})
+		}
+		w.Flush()
+		return w.Error()
+	}
+	return printTable(stdout, a, top)
+}
+
+func readFile(a *textstat.Analyzer, name string, stdin io.Reader) error {
+	if name == "-" {
+		return a.Read(stdin)
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := a.Read(f); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
+
+func printTable(out io.Writer, a *textstat.Analyzer, top []textstat.Entry) error {
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "rank\tcount\tshare\t  term")
+	for i, e := range top {
+		share := 100 * float64(e.Count) / float64(max(a.Tokens(), 1))
+		fmt.Fprintf(tw, "%d\t%d\t%.2f%%\t  %s\n", i+1, e.Count, share, e.Term)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if a.Approximate() {
+		fmt.Fprintf(out, "\n%d words; over the memory budget, so counts are estimates (never too low)\n", a.Tokens())
+	} else {
+		fmt.Fprintf(out, "\n%d words, %d distinct\n", a.Tokens(), a.Distinct())
+	}
+	return nil
+}
+
+func loadStopWords(spec string) (*collections.Set[string], error) {
+	switch spec {
+	case "", "none":
+		return nil, nil
+	case "en":
+		return textstat.EnglishStopWords(), nil
+	}
+	f, err := os.Open(spec)
+	if err != nil {
+		return nil, fmt.Errorf("-stop: %w", err)
+	}
+	defer f.Close()
+	return textstat.ReadStopWords(f)
+}
+
+// parseSize parses a byte count with an optional B, KiB, MiB or GiB
+// suffix (KB, MB and GB are accepted as the same binary units). "none"
+// is 0, which textstat takes as no limit.
+func parseSize(s string) (int64, error) {
+	if strings.EqualFold(strings.TrimSpace(s), "none") {
+		return 0, nil
+	}
+	units := []struct {
+		suffix string
+		scale  int64
+	}{
+		{"KiB", 1 << 10}, {"MiB", 1 << 20}, {"GiB", 1 << 30},
+		{"KB", 1 << 10}, {"MB", 1 << 20}, {"GB", 1 << 30},
+		{"K", 1 << 10}, {"M", 1 << 20}, {"G", 1 << 30}, {"B", 1},
+	}
+	scale := int64(1)
+	num := strings.TrimSpace(s)
+	for _, u := range units {
+		if strings.HasSuffix(strings.ToUpper(num), strings.ToUpper(u.suffix)) {
+			num, scale = strings.TrimSpace(num[:len(num)-len(u.suffix)]), u.scale
+			break
+		}
+	}
+	n, err := strconv.ParseInt(num, 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	if n > math.MaxInt64/scale {
+		return 0, fmt.Errorf("size %q is too large", s)
+	}
+	return n * scale, nil
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int64
+		ok   bool
+	}{
+		{"64MiB", 64 << 20, true},
+		{"512kib", 512 << 10, true},
+		{"1 GB", 1 << 30, true},
+		{"2K", 2 << 10, true},
+		{"100B", 100, true},
+		{"100", 100, true},
+		{"0", 0, true},
+		{"0MiB", 0, true},
+		{"none", 0, true},
+		{" None ", 0, true},
+		{"8589934591GiB", 8589934591 << 30, true}, // math.MaxInt64 >> 30
+		{"8589934592GiB", 0, false},
+		{"9223372036854775807", math.MaxInt64, true},
+		{"9223372036854775808", 0, false},
+		{"-1MiB", 0, false},
+		{"1.5MiB", 0, false},
+		{"MiB", 0, false},
+		{"lots", 0, false},
+		{"", 0, false},
+	}
+	for _, tt := range tests {
+		got, err := parseSize(tt.in)
+		if got != tt.want || (err == nil) != tt.ok {
+			t.Errorf("parseSize(%q) = %d, %v; want %d, ok %v", tt.in, got, err, tt.want, tt.ok)
+		}
+	}
+}
+
+const text = "The cat sat on the mat. The cat ran!\n"
+
+func TestRun(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "stop.txt")
+	if err := os.WriteFile(file, []byte("cat\nsat\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{
+			"table",
+			[]string{"-k", "2"},
+			"  rank  count   share  term\n" +
+				"     1      2  40.00%  cat\n" +
+				"     2      1  20.00%  mat\n" +
+				"\n5 words, 4 distinct\n",
+		},
+		{"csv", []string{"-k", "3", "-format", "csv", "-stop", "none"}, "term,count\nthe,3\ncat,2\nmat,1\n"},
+		{"no folding", []string{"-k", "2", "-format", "csv", "-stop", "none", "-fold=false"}, "term,count\nThe,2\ncat,2\n"},
+		{"stop word file", []string{"-k", "2", "-format", "csv", "-stop", file}, "term,count\nthe,3\nmat,1\n"},
+		{"bigrams", []string{"-k", "1", "-format", "csv", "-stop", "none", "-ngram", "2"}, "term,count\nthe cat,2\n"},
+		{"no memory limit", []string{"-k", "1", "-format", "csv", "-mem", "none"}, "term,count\ncat,2\n"},
+	}
+	for _, tt := range tests {
+		var out bytes.Buffer
+		if err := run(tt.args, strings.NewReader(text), &out); err != nil {
+			t.Errorf("%s: run(%q): %v", tt.name, tt.args, err)
+			continue
+		}
+		if out.String() != tt.want {
+			t.Errorf("%s: run(%q) printed\n%s\nwant\n%s", tt.name, tt.args, out.String(), tt.want)
+		}
+	}
+}
+
+func TestRunJSON(t *testing.T) {
+	// A budget of one byte is exceeded at once, so the counts come from
+	// the sketch
+	for _, mem := range []string{"64MiB", "1B"} {
+		var out bytes.Buffer
+		if err := run([]string{"-k", "1", "-format", "json", "-mem", mem}, strings.NewReader(text), &out); err != nil {
+			t.Fatal(err)
+		}
+		var r report
+		if err := json.Unmarshal(out.Bytes(), &r); err != nil {
+			t.Fatalf("-mem %s: %v\n%s", mem, err, out.String())
+		}
+		approx := mem == "1B"
+		if r.Tokens != 5 || r.Approximate != approx || len(r.Top) != 1 || r.Top[0].Term != "cat" || r.Top[0].Count < 2 {
+			t.Errorf("-mem %s: %+v", mem, r)
+		}
+		if (r.Distinct == 0) != approx {
+			t.Errorf("-mem %s: distinct %d", mem, r.Distinct)
+		}
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"-k", "0"}, "-k and -ngram must be at least 1"},
+		{[]string{"-ngram", "0"}, "-k and -ngram must be at least 1"},
+		{[]string{"-format", "xml"}, `unknown -format "xml"`},
+		{[]string{"-mem", "-1"}, `-mem: invalid size "-1"`},
+		{[]string{"-mem", "9999999999GiB"}, "too large"},
+		{[]string{"-stop", filepath.Join(t.TempDir(), "missing")}, "-stop:"},
+		{[]string{filepath.Join(t.TempDir(), "missing.txt")}, "missing.txt"},
+	}
+	for _, tt := range tests {
+		err := run(tt.args, strings.NewReader(text), new(bytes.Buffer))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("run(%q) = %v, want %q", tt.args, err, tt.want)
+		}
+	}
+}
@@ -1,6 +1,10 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+
+	"golang-learning-project/pkg/collections"
+)
 
 /*
 =============================================================================
@@ -20,7 +24,8 @@ Key Concepts:
 */
 
 func main() {
-	fmt.Println("=== Arrays, Slices, and Maps ===\n")
+	fmt.Println("=== Arrays, Slices, and Maps ===")
+	fmt.Println()
 	
 	// ========================================
 	// 1. ARRAYS
@@ -98,6 +103,8 @@ func main() {
 	fmt.Printf("Copied slice: %v\n", colorsCopy)
 	
 	// Remove element (no built-in remove, use slicing)
+	// Careful: this shifts the tail left inside colors' own array, so any
+	// other slice of that array sees the change (go run ./cmd/demo slices remove)
 	indexToRemove := 2
 	colors = append(colors[:indexToRemove], colors[indexToRemove+1:]...)
 	fmt.Printf("After removing index %d: %v\n", indexToRemove, colors)
@@ -116,6 +123,7 @@ func main() {
 		numbers2, len(numbers2), cap(numbers2))
 	
 	// When capacity is exceeded, a new underlying array is allocated
+	// (go run ./cmd/demo slices growth draws the arrays step by step)
 	numbers2 = append(numbers2, 30)  // exceeds capacity
 	fmt.Printf("After exceeding cap: %v (len: %d, cap: %d)\n", 
 		numbers2, len(numbers2), cap(numbers2))
@@ -124,7 +132,9 @@ func main() {
 	// 5. MULTIDIMENSIONAL SLICES
 	// ========================================
 	
-	// 2D slice
+	// 2D slice: each row is a separate slice with its own array
+	// (pkg/matrix stores a matrix in one flat slice and adds Mul, Det,
+	// Inverse and Solve; go test -bench . ./pkg/matrix compares the two)
 	matrix := [][]int{
 		{1, 2, 3},
 		{4, 5, 6},
@@ -193,11 +203,23 @@ func main() {
 		fmt.Printf("  %s -> %s\n", country, capital)
 	}
 	
+	// Map order is random; collections.OrderedMap keeps insertion order
+	var ordered collections.OrderedMap[string, string]
+	ordered.Set("USA", "Washington D.C.")
+	ordered.Set("France", "Paris")
+	ordered.Set("Japan", "Tokyo")
+	fmt.Println("Iterating over OrderedMap:")
+	for country, capital := range ordered.All() {
+		fmt.Printf("  %s -> %s\n", country, capital)
+	}
+	
 	// ========================================
 	// 8. PRACTICAL EXAMPLES
 	// ========================================
 	
 	// Example: Count word frequency
+	// (cmd/wordfreq grows this into a real tool: Unicode words, stop
+	// words, n-grams and a fixed memory budget for huge inputs)
 	words := []string{"apple", "banana", "apple", "cherry", "banana", "apple"}
 	frequency := make(map[string]int)
 	
@@ -210,6 +232,16 @@ func main() {
 		fmt.Printf("  %s: %d\n", word, count)
 	}
 	
+	// collections.Counter can also list the most common words
+	var counter collections.Counter[string]
+	for _, word := range words {
+		counter.Add(word)
+	}
+	fmt.Println("Top 2 words:")
+	for _, e := range counter.MostCommon(2) {
+		fmt.Printf("  %s: %d\n", e.Item, e.Count)
+	}
+	
 	// Example: Filter slice
 	numbers3 := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
 	evens := []int{}
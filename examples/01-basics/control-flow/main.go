@@ -20,7 +20,8 @@ Key Concepts:
 */
 
 func main() {
-	fmt.Println("=== Control Flow in Go ===\n")
+	fmt.Println("=== Control Flow in Go ===")
+	fmt.Println()
 	
 	// ========================================
 	// 1. IF-ELSE STATEMENTS
@@ -166,7 +167,8 @@ func main() {
 	}
 	fmt.Println()
 	
-	// Range over string (iterates over runes)
+	// Range over string (iterates over runes; index is the byte offset,
+	// which skips ahead for multi-byte runes: try go run ./cmd/demo inspect)
 	fmt.Print("Range over string: ")
 	for index, char := range "Go!" {
 		fmt.Printf("[%d:%c] ", index, char)
@@ -3,6 +3,9 @@ package main
 import (
 	"errors"
 	"fmt"
+
+	"golang-learning-project/pkg/errs"
+	"golang-learning-project/pkg/mathx"
 )
 
 /*
@@ -26,7 +29,8 @@ Key Concepts:
 */
 
 func main() {
-	fmt.Println("=== Functions in Go ===\n")
+	fmt.Println("=== Functions in Go ===")
+	fmt.Println()
 	
 	// ========================================
 	// 1. BASIC FUNCTIONS
@@ -55,6 +59,15 @@ func main() {
 		fmt.Println("Error:", err)
 	}
 	
+	// The error is typed, so callers match it without comparing strings
+	if errors.Is(err, errs.ErrDivideByZero) {
+		fmt.Println("errors.Is(err, errs.ErrDivideByZero): true")
+	}
+	var opErr *errs.OpError
+	if errors.As(err, &opErr) {
+		fmt.Printf("errors.As: op=%s operands=%v\n", opErr.Op, opErr.Operands)
+	}
+	
 	// Named return values
 	q, r := divideWithRemainder(17, 5)
 	fmt.Printf("17 / 5 = %d remainder %d\n", q, r)
@@ -122,6 +135,15 @@ func main() {
 	fmt.Printf("Factorial of 5: %d\n", factorial(5))
 	fmt.Printf("Fibonacci of 8: %d\n", fibonacci(8))
 	
+	// factorial(25) silently overflows int; pkg/mathx reports it instead
+	if _, err := mathx.Factorial(25); err != nil {
+		fmt.Println(err)
+	}
+	bigFact, _ := mathx.FactorialBig(25)
+	fmt.Printf("Factorial of 25 (math/big): %s\n", bigFact)
+	bigFib, _ := mathx.FibonacciDoubling(100)
+	fmt.Printf("Fibonacci of 100 (fast doubling): %s\n", bigFib)
+	
 	// ========================================
 	// 8. DEFER IN FUNCTIONS
 	// ========================================
@@ -156,9 +178,10 @@ func subtract(a, b int) int {
 }
 
 // Multiple return values (idiomatic Go for error handling)
+// The error records the operation and operands and wraps a sentinel
 func divide(a, b float64) (float64, error) {
 	if b == 0 {
-		return 0, errors.New("division by zero")
+		return 0, errs.Op("divide", errs.ErrDivideByZero, a, b)
 	}
 	return a / b, nil
 }
@@ -197,6 +220,7 @@ func makeCounter() func() int {
 }
 
 // Higher-order function: takes a function as parameter
+// (pkg/fn has generic versions of mapInts and filterInts for any type)
 func mapInts(numbers []int, fn func(int) int) []int {
 	result := make([]int, len(numbers))
 	for i, n := range numbers {
@@ -217,6 +241,7 @@ func filterInts(numbers []int, predicate func(int) bool) []int {
 }
 
 // Recursive function
+// (wraps around past 20! on 64-bit; see mathx.Factorial)
 func factorial(n int) int {
 	if n <= 1 {
 		return 1
@@ -225,6 +250,7 @@ func factorial(n int) int {
 }
 
 // Another recursive example
+// (exponential time: it recomputes the same values; see mathx.Fibonacci)
 func fibonacci(n int) int {
 	if n <= 1 {
 		return n
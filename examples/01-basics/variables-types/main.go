@@ -1,6 +1,10 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+
+	"golang-learning-project/pkg/checked"
+)
 
 /*
 =============================================================================
@@ -20,7 +24,8 @@ Key Concepts:
 */
 
 func main() {
-	fmt.Println("=== Variables and Types in Go ===\n")
+	fmt.Println("=== Variables and Types in Go ===")
+	fmt.Println()
 	
 	// ========================================
 	// 1. VARIABLE DECLARATION METHODS
@@ -69,6 +74,17 @@ func main() {
 	fmt.Printf("Unsigned: uint8: %d, uint16: %d, uint32: %d, uint64: %d\n",
 		unsignedByte, unsignedSmall, unsignedBig, unsignedHuge)
 	
+	// At the limits the built-in operators wrap around silently;
+	// pkg/checked reports the overflow or clamps instead
+	fmt.Printf("\nAt the limits:\nint8 127 + 1 = %d (wrapped)\n", smallInt+1)
+	if _, err := checked.Add(smallInt, 1); err != nil {
+		fmt.Println("checked.Add:", err)
+	}
+	fmt.Printf("checked.AddSat: %d\n", checked.AddSat(smallInt, 1))
+	if _, err := checked.Convert[uint8](-1); err != nil {
+		fmt.Println("checked.Convert[uint8](-1):", err)
+	}
+	
 	// Floating point numbers
 	var price float32 = 19.99          // 32-bit floating point
 	var precise float64 = 3.14159265359 // 64-bit floating point (preferred)
@@ -126,6 +142,8 @@ using backticks`
 		integer, floating, unsigned)
 	
 	// String to bytes and vice versa
+	// (for multi-byte text, go run ./cmd/demo inspect "héllo 👍🏽" shows every
+	// byte, rune and UTF-8 encoding step)
 	text := "Hello"
 	bytes := []byte(text)           // string to []byte
 	backToString := string(bytes)   // []byte to string
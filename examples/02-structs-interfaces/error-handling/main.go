@@ -0,0 +1,298 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+/*
+=============================================================================
+TOPIC: Error Handling in Go
+=============================================================================
+
+Go has no exceptions. A function that can fail returns an error as its
+last result, and the caller checks it right away. Errors are ordinary
+values: they can be compared, wrapped with context, inspected and
+combined.
+
+The shop's warehouse reserves stock for orders and reports every way
+that can go wrong.
+
+Key Concepts:
+- The error interface
+- Sentinel errors
+- Custom error types
+- Wrapping with %w
+- errors.Is and errors.As
+- Joining errors
+- defer for cleanup and rollback
+- panic and recover
+=============================================================================
+*/
+
+// Sentinel errors: package-level values that callers match with
+// errors.Is. By convention their names start with Err and their messages
+// are lower case without a final period
+var (
+	ErrUnknownProduct  = errors.New("unknown product")
+	ErrInvalidQuantity = errors.New("quantity must be positive")
+)
+
+func main() {
+	fmt.Println("=== Error Handling in Go ===")
+	fmt.Println()
+
+	wh := NewWarehouse(map[string]int{
+		"KB-01": 5,
+		"MS-02": 2,
+		"CB-03": 40,
+	})
+	fmt.Println("Stock:", wh)
+
+	// ========================================
+	// 1. CHECKING ERRORS
+	// ========================================
+	// The error is the last result and nil means success
+
+	if err := wh.Reserve("KB-01", 2); err != nil {
+		fmt.Println("Error:", err)
+	} else {
+		fmt.Println("Reserved 2 x KB-01, stock now:", wh)
+	}
+
+	// ========================================
+	// 2. SENTINEL ERRORS AND WRAPPING
+	// ========================================
+	// Reserve wraps ErrUnknownProduct with %w, adding the SKU. The
+	// message gains context and the sentinel can still be matched
+
+	err := wh.Reserve("XX-99", 1)
+	fmt.Println("Error:", err)
+	fmt.Println("err == ErrUnknownProduct:         ", err == ErrUnknownProduct)
+	fmt.Println("errors.Is(err, ErrUnknownProduct):", errors.Is(err, ErrUnknownProduct))
+	fmt.Println("Unwrapped:", errors.Unwrap(err))
+
+	err = wh.Reserve("CB-03", 0)
+	fmt.Println("Error:", err)
+	fmt.Println("errors.Is(err, ErrInvalidQuantity):", errors.Is(err, ErrInvalidQuantity))
+
+	// ========================================
+	// 3. CUSTOM ERROR TYPES
+	// ========================================
+	// Any type with an Error() string method is an error. A struct can
+	// carry the details a caller needs to recover
+
+	err = wh.Reserve("MS-02", 5)
+	fmt.Println("Error:", err)
+
+	var oos *OutOfStockError
+	if errors.As(err, &oos) {
+		fmt.Printf("errors.As: %s is short by %d, %d in stock\n", oos.SKU, oos.Short(), oos.Available)
+	}
+
+	// Wrapping again keeps the whole chain searchable
+	wrapped := fmt.Errorf("checkout for order #1001: %w", err)
+	fmt.Println("Error:", wrapped)
+	fmt.Println("errors.As still finds it:", errors.As(wrapped, &oos))
+
+	// ========================================
+	// 4. JOINING ERRORS
+	// ========================================
+	// Validation reports every problem at once instead of stopping at
+	// the first; errors.Is and errors.As look inside joined errors too
+
+	bad := []Line{{"KB-01", 1}, {"XX-99", 1}, {"MS-02", 9}, {"CB-03", -2}}
+	err = wh.Validate(bad)
+	fmt.Printf("Validate found problems:\n%v\n", err)
+	fmt.Println("Has unknown product: ", errors.Is(err, ErrUnknownProduct))
+	fmt.Println("Has invalid quantity:", errors.Is(err, ErrInvalidQuantity))
+	fmt.Println("Has out of stock:    ", errors.As(err, &oos))
+	fmt.Println("Valid order:", wh.Validate([]Line{{"KB-01", 1}, {"CB-03", 10}}))
+
+	// ========================================
+	// 5. DEFER FOR ROLLBACK
+	// ========================================
+	// PlaceOrder reserves line by line. If a later line fails, a
+	// deferred function puts back what the earlier lines took
+
+	fmt.Println("\nBefore:", wh)
+	err = wh.PlaceOrder([]Line{{"KB-01", 1}, {"CB-03", 10}, {"MS-02", 3}})
+	fmt.Println("PlaceOrder:", err)
+	fmt.Println("After: ", wh, "(unchanged)")
+
+	err = wh.PlaceOrder([]Line{{"KB-01", 1}, {"CB-03", 10}})
+	fmt.Println("PlaceOrder:", err)
+	fmt.Println("After: ", wh)
+
+	// ========================================
+	// 6. PANIC AND RECOVER
+	// ========================================
+	// panic is for bugs and impossible states, not for expected failures.
+	// recover, called in a deferred function, stops a panic; a server
+	// uses it so that one bad request cannot crash the whole process
+
+	err = safely(func() { wh.MustReserve("XX-99", 1) })
+	fmt.Println("Recovered:", err)
+	fmt.Println("Still an ErrUnknownProduct:", errors.Is(err, ErrUnknownProduct))
+
+	err = safely(func() {
+		var lines []Line
+		_ = lines[3] // index out of range
+	})
+	fmt.Println("Recovered:", err)
+
+	err = safely(func() { wh.MustReserve("CB-03", 1) })
+	fmt.Println("No panic:", err)
+
+	fmt.Println("\n✅ Error Handling completed!")
+}
+
+// ========================================
+// ERROR TYPES
+// ========================================
+
+// OutOfStockError reports a reservation larger than the stock.
+type OutOfStockError struct {
+	SKU       string
+	Requested int
+	Available int
+}
+
+// Error implements the error interface. The pointer receiver means only
+// *OutOfStockError is an error, so errors.As needs a
+// **OutOfStockError target
+func (e *OutOfStockError) Error() string {
+	return fmt.Sprintf("%s: out of stock: requested %d, available %d", e.SKU, e.Requested, e.Available)
+}
+
+// Short is how many units are missing.
+func (e *OutOfStockError) Short() int {
+	return e.Requested - e.Available
+}
+
+// ========================================
+// WAREHOUSE
+// ========================================
+
+// Line is one product and quantity of an order.
+type Line struct {
+	SKU string
+	Qty int
+}
+
+// Warehouse tracks the stock of every product.
+type Warehouse struct {
+	stock map[string]int
+}
+
+func NewWarehouse(stock map[string]int) *Warehouse {
+	w := &Warehouse{stock: make(map[string]int, len(stock))}
+	for sku, n := range stock {
+		w.stock[sku] = n
+	}
+	return w
+}
+
+// check returns nil if qty units of sku can be reserved
+func (w *Warehouse) check(sku string, qty int) error {
+	if qty <= 0 {
+		return fmt.Errorf("%s: %d: %w", sku, qty, ErrInvalidQuantity)
+	}
+	available, ok := w.stock[sku]
+	if !ok {
+		return fmt.Errorf("%s: %w", sku, ErrUnknownProduct)
+	}
+	if qty > available {
+		return &OutOfStockError{SKU: sku, Requested: qty, Available: available}
+	}
+	return nil
+}
+
+// Reserve takes qty units of sku out of stock.
+func (w *Warehouse) Reserve(sku string, qty int) error {
+	if err := w.check(sku, qty); err != nil {
+		return err
+	}
+	w.stock[sku] -= qty
+	return nil
+}
+
+// release puts back units taken by Reserve.
+func (w *Warehouse) release(sku string, qty int) {
+	w.stock[sku] += qty
+}
+
+// Validate checks every line without reserving anything and returns all
+// the problems joined into one error, or nil.
+func (w *Warehouse) Validate(lines []Line) error {
+	var errs []error
+	for _, l := range lines {
+		errs = append(errs, w.check(l.SKU, l.Qty)) // nil entries are dropped
+	}
+	return errors.Join(errs...)
+}
+
+// PlaceOrder reserves every line, or none of them.
+//
+// The named result err lets the deferred function see whether the
+// order failed after the return statement has set it
+func (w *Warehouse) PlaceOrder(lines []Line) (err error) {
+	var done []Line
+	defer func() {
+		if err != nil {
+			for _, l := range done {
+				w.release(l.SKU, l.Qty)
+			}
+		}
+	}()
+
+	for i, l := range lines {
+		if err = w.Reserve(l.SKU, l.Qty); err != nil {
+			return fmt.Errorf("line %d: %w", i+1, err)
+		}
+		done = append(done, l)
+	}
+	return nil
+}
+
+// MustReserve is Reserve for callers that know the reservation cannot
+// fail; it panics if it does. Must functions are common for setup code,
+// like regexp.MustCompile
+func (w *Warehouse) MustReserve(sku string, qty int) {
+	if err := w.Reserve(sku, qty); err != nil {
+		panic(err)
+	}
+}
+
+func (w *Warehouse) String() string {
+	skus := make([]string, 0, len(w.stock))
+	for sku := range w.stock {
+		skus = append(skus, sku)
+	}
+	sort.Strings(skus) // map order is random; sort for stable output
+	parts := make([]string, len(skus))
+	for i, sku := range skus {
+		parts[i] = fmt.Sprintf("%s=%d", sku, w.stock[sku])
+	}
+	return strings.Join(parts, " ")
+}
+
+// safely runs fn and turns a panic into an error. If the panic value
+// is itself an error it is wrapped, so errors.Is still sees through it
+func safely(fn func()) (err error) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+		if e, ok := r.(error); ok {
+			err = fmt.Errorf("panic: %w", e)
+		} else {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	fn()
+	return nil
+}
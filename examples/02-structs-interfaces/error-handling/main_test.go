@@ -0,0 +1,85 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+var (
+	_ error        = (*OutOfStockError)(nil) // not OutOfStockError{}: Error has a pointer receiver
+	_ fmt.Stringer = (*Warehouse)(nil)
+)
+
+func TestReserve(t *testing.T) {
+	w := NewWarehouse(map[string]int{"TEE": 3})
+	tests := []struct {
+		sku    string
+		qty    int
+		target error
+	}{
+		{"TEE", 0, ErrInvalidQuantity},
+		{"HAT", 1, ErrUnknownProduct},
+		{"TEE", 2, nil},
+	}
+	for _, tt := range tests {
+		if err := w.Reserve(tt.sku, tt.qty); !errors.Is(err, tt.target) {
+			t.Errorf("Reserve(%s, %d) = %v, want %v", tt.sku, tt.qty, err, tt.target)
+		}
+	}
+
+	err := w.Reserve("TEE", 5)
+	var oos *OutOfStockError
+	if !errors.As(err, &oos) {
+		t.Fatalf("Reserve beyond stock = %v, want an *OutOfStockError", err)
+	}
+	if oos.Available != 1 || oos.Short() != 4 {
+		t.Errorf("available %d, short %d; want 1 and 4", oos.Available, oos.Short())
+	}
+}
+
+// TestPlaceOrder checks that a failed order puts back what its earlier
+// lines reserved.
+func TestPlaceOrder(t *testing.T) {
+	w := NewWarehouse(map[string]int{"TEE": 3, "MUG": 1})
+	err := w.PlaceOrder([]Line{{"TEE", 2}, {"MUG", 2}})
+	var oos *OutOfStockError
+	if !errors.As(err, &oos) || oos.SKU != "MUG" {
+		t.Errorf("PlaceOrder = %v, want MUG out of stock", err)
+	}
+	if got := w.String(); got != "MUG=1 TEE=3" {
+		t.Errorf("stock after a failed order: %s, want MUG=1 TEE=3", got)
+	}
+	if err := w.PlaceOrder([]Line{{"TEE", 2}, {"MUG", 1}}); err != nil {
+		t.Fatal(err)
+	}
+	if got := w.String(); got != "MUG=0 TEE=1" {
+		t.Errorf("stock after an order: %s, want MUG=0 TEE=1", got)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	w := NewWarehouse(map[string]int{"TEE": 3})
+	if err := w.Validate([]Line{{"TEE", 1}}); err != nil {
+		t.Errorf("Validate of a good order = %v", err)
+	}
+	err := w.Validate([]Line{{"HAT", 1}, {"TEE", -1}, {"TEE", 9}})
+	var oos *OutOfStockError
+	if !errors.Is(err, ErrUnknownProduct) || !errors.Is(err, ErrInvalidQuantity) || !errors.As(err, &oos) {
+		t.Errorf("Validate = %v, want all three problems", err)
+	}
+}
+
+func TestSafely(t *testing.T) {
+	w := NewWarehouse(map[string]int{"TEE": 1})
+	if err := safely(func() { w.MustReserve("TEE", 1) }); err != nil {
+		t.Errorf("safely of a good reservation = %v", err)
+	}
+	err := safely(func() { w.MustReserve("HAT", 1) })
+	if !errors.Is(err, ErrUnknownProduct) {
+		t.Errorf("safely = %v, want the panic's error wrapped", err)
+	}
+	if err := safely(func() { panic("boom") }); err == nil || err.Error() != "panic: boom" {
+		t.Errorf("safely = %v, want panic: boom", err)
+	}
+}
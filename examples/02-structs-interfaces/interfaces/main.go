@@ -0,0 +1,454 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+/*
+=============================================================================
+TOPIC: Interfaces in Go
+=============================================================================
+
+An interface is a set of method signatures. Any type that has those
+methods implements the interface automatically; there is no "implements"
+keyword. Code written against an interface works with every type that
+satisfies it, including types written later.
+
+The shop prices items through a Pricer and quotes delivery through a
+Shipper, and each has several implementations.
+
+Key Concepts:
+- Implicit implementation
+- Polymorphism
+- Embedding interfaces
+- Decorators by embedding
+- Type assertions and type switches
+- Optional interfaces
+- Empty interface (any)
+- Nil interface values
+=============================================================================
+*/
+
+// Pricer prices a quantity of something.
+type Pricer interface {
+	PriceFor(qty int) Money
+}
+
+// Shipper quotes the cost of delivering a parcel, or an error if it
+// cannot deliver it.
+type Shipper interface {
+	Name() string
+	Cost(p Parcel) (Money, error)
+}
+
+// Compile-time checks that each implementation satisfies its interface.
+var (
+	_ Pricer  = Product{}
+	_ Pricer  = BulkPrice{}
+	_ Pricer  = Sale{}
+	_ Pricer  = (*Bundle)(nil) // var _ Pricer = Bundle{} does not compile
+	_ Shipper = FlatRate{}
+	_ Shipper = ByWeight{}
+	_ Shipper = FreeOver{}
+	_ Shipper = Pickup{}
+)
+
+func main() {
+	fmt.Println("=== Interfaces in Go ===")
+	fmt.Println()
+
+	keyboard := Product{SKU: "KB-01", Name: "Mechanical Keyboard", Price: 8999}
+	mouse := Product{SKU: "MS-02", Name: "Wireless Mouse", Price: 2499}
+	cable := Product{SKU: "CB-03", Name: "USB-C Cable", Price: 999}
+
+	// ========================================
+	// 1. IMPLICIT IMPLEMENTATION
+	// ========================================
+	// Product has a PriceFor method, so it is a Pricer. Nothing else
+	// needs to be declared
+
+	var pr Pricer = keyboard
+	fmt.Println("Keyboard x2:", pr.PriceFor(2))
+
+	// ========================================
+	// 2. POLYMORPHISM
+	// ========================================
+	// One loop prices very different things through the same interface
+
+	starter := &Bundle{Name: "Desk starter kit", PercentOff: 10}
+	starter.Add(keyboard)
+	starter.Add(mouse)
+
+	cables := BulkPrice{Unit: cable.Price, MinQty: 10, PercentOff: 20}
+	clearance := Sale{Pricer: mouse, PercentOff: 30}
+
+	items := []struct {
+		label string
+		p     Pricer
+		qty   int
+	}{
+		{"keyboard", keyboard, 1},
+		{"cables (bulk)", cables, 5},
+		{"cables (bulk)", cables, 12},
+		{"mouse (sale)", clearance, 2},
+		{"starter bundle", starter, 1},
+	}
+	var total Money
+	for _, it := range items {
+		price := it.p.PriceFor(it.qty)
+		total += price
+		fmt.Printf("  %-15s x%-3d %9s   %T\n", it.label, it.qty, price, it.p)
+	}
+	fmt.Printf("  %-20s %9s\n", "total", total)
+
+	// ========================================
+	// 3. EMBEDDING INTERFACES
+	// ========================================
+	// Item is Pricer and fmt.Stringer together. Product has both methods,
+	// a BulkPrice only prices and has no name
+
+	var item Item = keyboard
+	fmt.Println("Item:", item, "->", item.PriceFor(1))
+	_, ok := Pricer(cables).(Item)
+	fmt.Println("BulkPrice is an Item:", ok)
+
+	// ========================================
+	// 4. SHIPPING: MANY IMPLEMENTATIONS
+	// ========================================
+
+	courier := ByWeight{Base: 499, PerKg: 150, MaxGrams: 20000}
+	shippers := []Shipper{
+		FlatRate{Fee: 799, Country: "US"},
+		courier,
+		FreeOver{Threshold: 10000, Shipper: courier},
+		Pickup{Store: "Springfield"},
+	}
+
+	parcels := []Parcel{
+		{Grams: 1200, Value: 11498, Country: "US", City: "Springfield"},
+		{Grams: 1200, Value: 3498, Country: "CA", City: "Toronto"},
+		{Grams: 25000, Value: 45000, Country: "US", City: "Shelbyville"},
+	}
+	for _, parcel := range parcels {
+		fmt.Printf("\n%s:\n", parcel)
+		for _, s := range shippers {
+			cost, err := s.Cost(parcel)
+			if err != nil {
+				fmt.Printf("  %-40s unavailable: %v\n", s.Name(), err)
+				continue
+			}
+			fmt.Printf("  %-40s %s\n", s.Name(), cost)
+		}
+		if s, cost, err := cheapest(parcel, shippers); err == nil {
+			fmt.Printf("  cheapest: %s (%s)\n", s.Name(), cost)
+		} else {
+			fmt.Println("  cheapest:", err)
+		}
+	}
+	fmt.Println()
+
+	// ========================================
+	// 5. TYPE ASSERTIONS AND TYPE SWITCHES
+	// ========================================
+	// x.(T) gets the concrete value back out of an interface. The
+	// two-result form reports failure instead of panicking
+
+	var s Shipper = courier
+	if bw, ok := s.(ByWeight); ok {
+		fmt.Println("Courier charges per kg:", bw.PerKg)
+	}
+	if _, ok := s.(FlatRate); !ok {
+		fmt.Println("Courier is not a FlatRate")
+	}
+
+	for _, sh := range shippers {
+		fmt.Println("describe:", describe(sh))
+	}
+
+	// ========================================
+	// 6. OPTIONAL INTERFACES
+	// ========================================
+	// Some shippers can also track parcels. Asserting to a smaller
+	// interface discovers the extra capability at run time, the way
+	// net/http checks whether a ResponseWriter is also a Flusher
+
+	for _, sh := range shippers {
+		if t, ok := sh.(Tracker); ok {
+			fmt.Printf("%s tracks parcels: %s\n", sh.Name(), t.TrackingURL("PX-42"))
+		} else {
+			fmt.Printf("%s cannot track parcels\n", sh.Name())
+		}
+	}
+	fmt.Println()
+
+	// ========================================
+	// 7. EMPTY INTERFACE
+	// ========================================
+	// any (interface{}) has no methods, so every type implements it
+
+	values := []any{42, "gift card", keyboard, cables, starter, nil}
+	for _, v := range values {
+		fmt.Printf("%-15T %s\n", v, describe(v))
+	}
+	fmt.Println()
+
+	// ========================================
+	// 8. NIL INTERFACE VALUES
+	// ========================================
+	// An interface value is a (type, value) pair. It is nil only when
+	// both are nil; an interface holding a nil pointer is not nil
+
+	s = findShipperBroken("drone")
+	fmt.Printf("findShipperBroken(\"drone\") == nil: %v (type %T)\n", s == nil, s)
+	s = findShipper("drone")
+	fmt.Printf("findShipper(\"drone\") == nil:       %v\n", s == nil)
+	s = findShipper("express")
+	fmt.Printf("findShipper(\"express\"):            %s\n", s.Name())
+
+	fmt.Println("\n✅ Interfaces completed!")
+}
+
+// ========================================
+// PRICING
+// ========================================
+
+// Money is an amount in cents.
+type Money int64
+
+func (m Money) String() string {
+	sign := ""
+	if m < 0 {
+		sign, m = "-", -m
+	}
+	return fmt.Sprintf("%s$%d.%02d", sign, m/100, m%100)
+}
+
+// percentOff returns m reduced by percent; the discount is rounded down
+// to the cent.
+func percentOff(m Money, percent int) Money {
+	return m - m*Money(percent)/100
+}
+
+// Product is something the shop sells at a fixed unit price.
+type Product struct {
+	SKU   string
+	Name  string
+	Price Money
+}
+
+func (p Product) PriceFor(qty int) Money { return p.Price * Money(qty) }
+
+func (p Product) String() string { return p.Name }
+
+// BulkPrice takes PercentOff the unit price from MinQty units up.
+type BulkPrice struct {
+	Unit       Money
+	MinQty     int
+	PercentOff int
+}
+
+func (b BulkPrice) PriceFor(qty int) Money {
+	total := b.Unit * Money(qty)
+	if qty >= b.MinQty {
+		total = percentOff(total, b.PercentOff)
+	}
+	return total
+}
+
+// Sale discounts any other Pricer. Embedding the interface gives Sale
+// the embedded value's methods; PriceFor below overrides the one it
+// would otherwise promote
+type Sale struct {
+	Pricer
+	PercentOff int
+}
+
+func (s Sale) PriceFor(qty int) Money {
+	return percentOff(s.Pricer.PriceFor(qty), s.PercentOff)
+}
+
+// Bundle sells several products together at a discount. Add changes
+// the bundle, so its methods have pointer receivers, and only *Bundle
+// is a Pricer
+type Bundle struct {
+	Name       string
+	Items      []Pricer
+	PercentOff int
+}
+
+func (b *Bundle) Add(p Pricer) { b.Items = append(b.Items, p) }
+
+func (b *Bundle) PriceFor(qty int) Money {
+	var total Money
+	for _, it := range b.Items {
+		total += it.PriceFor(qty)
+	}
+	return percentOff(total, b.PercentOff)
+}
+
+func (b *Bundle) String() string { return b.Name }
+
+// Item is a Pricer that can also be printed. Interfaces embed other
+// interfaces to combine their method sets, like io.ReadWriter does
+type Item interface {
+	Pricer
+	fmt.Stringer
+}
+
+// ========================================
+// SHIPPING
+// ========================================
+
+// Parcel is what a Shipper is asked to deliver.
+type Parcel struct {
+	Grams   int
+	Value   Money
+	Country string
+	City    string
+}
+
+func (p Parcel) String() string {
+	return fmt.Sprintf("%.1f kg worth %s to %s, %s", float64(p.Grams)/1000, p.Value, p.City, p.Country)
+}
+
+// Tracker is implemented by shippers that can track a parcel.
+type Tracker interface {
+	TrackingURL(id string) string
+}
+
+// FlatRate charges the same fee for any parcel within one country.
+type FlatRate struct {
+	Fee     Money
+	Country string
+}
+
+func (f FlatRate) Name() string { return "flat rate (" + f.Country + " only)" }
+
+func (f FlatRate) Cost(p Parcel) (Money, error) {
+	if p.Country != f.Country {
+		return 0, fmt.Errorf("does not ship to %s", p.Country)
+	}
+	return f.Fee, nil
+}
+
+// ByWeight is a courier that charges a base fee plus a rate for every
+// started kilogram, up to a weight limit.
+type ByWeight struct {
+	Base     Money
+	PerKg    Money
+	MaxGrams int
+}
+
+func (b ByWeight) Name() string { return "courier" }
+
+func (b ByWeight) Cost(p Parcel) (Money, error) {
+	if p.Grams > b.MaxGrams {
+		return 0, fmt.Errorf("%d g is over the %d g limit", p.Grams, b.MaxGrams)
+	}
+	kg := (p.Grams + 999) / 1000
+	return b.Base + b.PerKg*Money(kg), nil
+}
+
+func (b ByWeight) TrackingURL(id string) string {
+	return "https://courier.example/track/" + id
+}
+
+// FreeOver ships for free when the parcel is worth at least Threshold,
+// and otherwise charges what the embedded Shipper charges. Name
+// overrides the promoted method; TrackingURL is not promoted, because
+// the embedded field is a Shipper and Shipper has no such method
+type FreeOver struct {
+	Threshold Money
+	Shipper
+}
+
+func (f FreeOver) Name() string {
+	return fmt.Sprintf("free over %s, else %s", f.Threshold, f.Shipper.Name())
+}
+
+func (f FreeOver) Cost(p Parcel) (Money, error) {
+	cost, err := f.Shipper.Cost(p)
+	if err != nil {
+		return 0, err
+	}
+	if p.Value >= f.Threshold {
+		return 0, nil
+	}
+	return cost, nil
+}
+
+// Pickup is free, but only in the city with the store.
+type Pickup struct {
+	Store string
+}
+
+func (p Pickup) Name() string { return "pickup in " + p.Store }
+
+func (p Pickup) Cost(parcel Parcel) (Money, error) {
+	if parcel.City != p.Store {
+		return 0, errors.New("no store in " + parcel.City)
+	}
+	return 0, nil
+}
+
+// cheapest works with any Shipper, including ones written after it
+func cheapest(p Parcel, shippers []Shipper) (Shipper, Money, error) {
+	var best Shipper
+	var bestCost Money
+	for _, s := range shippers {
+		cost, err := s.Cost(p)
+		if err != nil {
+			continue
+		}
+		if best == nil || cost < bestCost {
+			best, bestCost = s, cost
+		}
+	}
+	if best == nil {
+		return nil, 0, errors.New("no shipper can deliver this parcel")
+	}
+	return best, bestCost, nil
+}
+
+// describe uses a type switch to branch on the dynamic type. A case can
+// name an interface, which matches every type that implements it
+func describe(v any) string {
+	switch x := v.(type) {
+	case nil:
+		return "nothing"
+	case int:
+		return fmt.Sprintf("an int, %d", x)
+	case string:
+		return fmt.Sprintf("a string of %d bytes", len(x))
+	case FreeOver:
+		return "free shipping wrapped around " + describe(x.Shipper)
+	case Shipper:
+		return fmt.Sprintf("a shipper, %q", x.Name())
+	case Item:
+		return fmt.Sprintf("%s, priced at %s", x, x.PriceFor(1))
+	case Pricer:
+		return fmt.Sprintf("an unnamed price, %s each", x.PriceFor(1))
+	default:
+		return fmt.Sprintf("something else: %v", x)
+	}
+}
+
+// findShipperBroken returns a nil *ByWeight when nothing matches. The
+// result is a non-nil Shipper holding a nil pointer, so callers that
+// check for nil are fooled
+func findShipperBroken(name string) Shipper {
+	var s *ByWeight
+	if name == "express" {
+		s = &ByWeight{Base: 1499, PerKg: 300, MaxGrams: 5000}
+	}
+	return s
+}
+
+// findShipper returns a literal nil when nothing matches
+func findShipper(name string) Shipper {
+	if name == "express" {
+		return ByWeight{Base: 1499, PerKg: 300, MaxGrams: 5000}
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+// main.go checks the Pricer and Shipper implementations; these are the
+// other interfaces the lesson relies on.
+var (
+	_ Item         = Product{}
+	_ Item         = (*Bundle)(nil)
+	_ Tracker      = ByWeight{}
+	_ fmt.Stringer = Money(0)
+	_ fmt.Stringer = Parcel{}
+	_ Shipper      = (*ByWeight)(nil) // a pointer has its value's methods too
+)
+
+func TestPricers(t *testing.T) {
+	tee := Product{SKU: "TEE", Name: "T-shirt", Price: 1999}
+	mug := Product{SKU: "MUG", Name: "Mug", Price: 899}
+	bundle := &Bundle{Name: "starter kit", PercentOff: 10}
+	bundle.Add(tee)
+	bundle.Add(mug)
+
+	tests := []struct {
+		name string
+		p    Pricer
+		qty  int
+		want Money
+	}{
+		{"product", tee, 3, 5997},
+		{"bulk below minimum", BulkPrice{Unit: 100, MinQty: 10, PercentOff: 20}, 9, 900},
+		{"bulk at minimum", BulkPrice{Unit: 100, MinQty: 10, PercentOff: 20}, 10, 800},
+		{"sale", Sale{Pricer: tee, PercentOff: 50}, 1, 1000},
+		{"sale of a sale", Sale{Pricer: Sale{Pricer: mug, PercentOff: 50}, PercentOff: 50}, 1, 225},
+		{"bundle", bundle, 1, 2609},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.p.PriceFor(tt.qty); got != tt.want {
+				t.Errorf("PriceFor(%d) = %s, want %s", tt.qty, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoneyString(t *testing.T) {
+	for m, want := range map[Money]string{0: "$0.00", 5: "$0.05", 1999: "$19.99", -250: "-$2.50"} {
+		if got := m.String(); got != want {
+			t.Errorf("Money(%d) = %q, want %q", int64(m), got, want)
+		}
+	}
+}
+
+func TestCheapest(t *testing.T) {
+	courier := ByWeight{Base: 499, PerKg: 200, MaxGrams: 20000}
+	shippers := []Shipper{
+		FlatRate{Fee: 990, Country: "DE"},
+		courier,
+		FreeOver{Threshold: 5000, Shipper: courier},
+		Pickup{Store: "Berlin"},
+	}
+	tests := []struct {
+		name   string
+		parcel Parcel
+		want   string
+		cost   Money
+	}{
+		{"pickup is free", Parcel{Grams: 1500, Value: 1000, Country: "DE", City: "Berlin"}, "pickup in Berlin", 0},
+		{"courier beats flat rate", Parcel{Grams: 1500, Value: 1000, Country: "DE", City: "Munich"}, "courier", 899},
+		{"free over threshold", Parcel{Grams: 1500, Value: 6000, Country: "FR", City: "Paris"}, "free over $50.00, else courier", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, cost, err := cheapest(tt.parcel, shippers)
+			if err != nil || s.Name() != tt.want || cost != tt.cost {
+				t.Errorf("cheapest = %v, %s, %v; want %s, %s", s, cost, err, tt.want, tt.cost)
+			}
+		})
+	}
+	if _, _, err := cheapest(Parcel{Grams: 30000, Country: "FR", City: "Paris"}, shippers); err == nil {
+		t.Error("cheapest found a shipper for a parcel none can take")
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	tests := []struct {
+		v    any
+		want string
+	}{
+		{nil, "nothing"},
+		{7, "an int, 7"},
+		{"héllo", "a string of 6 bytes"},
+		{Pickup{Store: "Berlin"}, `a shipper, "pickup in Berlin"`},
+		{FreeOver{Threshold: 100, Shipper: Pickup{Store: "Berlin"}}, `free shipping wrapped around a shipper, "pickup in Berlin"`},
+		{Product{Name: "Mug", Price: 899}, "Mug, priced at $8.99"},
+		{BulkPrice{Unit: 100}, "an unnamed price, $1.00 each"},
+		{3.5, "something else: 3.5"},
+	}
+	for _, tt := range tests {
+		if got := describe(tt.v); got != tt.want {
+			t.Errorf("describe(%#v) = %q, want %q", tt.v, got, tt.want)
+		}
+	}
+}
+
+// TestNilInterface checks the lesson's trap: an interface holding a nil
+// pointer is not a nil interface.
+func TestNilInterface(t *testing.T) {
+	broken := findShipperBroken("overnight")
+	if broken == nil {
+		t.Fatal("findShipperBroken returned a nil interface; the lesson expects a typed nil")
+	}
+	if p, ok := broken.(*ByWeight); !ok || p != nil {
+		t.Errorf("findShipperBroken holds %#v, want a nil *ByWeight", broken)
+	}
+	if s := findShipper("overnight"); s != nil {
+		t.Errorf("findShipper = %#v, want nil", s)
+	}
+	if s := findShipper("express"); s == nil || s.Name() != "courier" {
+		t.Errorf("findShipper(express) = %v, want the courier", s)
+	}
+}
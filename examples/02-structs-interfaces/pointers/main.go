@@ -0,0 +1,255 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+/*
+=============================================================================
+TOPIC: Pointers in Go
+=============================================================================
+
+A pointer holds the address of a value. Go passes everything by value,
+so a function that must change its caller's data takes a pointer to it.
+Go has no pointer arithmetic, and the garbage collector keeps a value
+alive for as long as any pointer to it exists.
+
+The shop restocks products, reprices them and keeps an order history,
+all of which change data in place.
+
+Key Concepts:
+- Address-of (&) and dereference (*)
+- Passing by value vs by pointer
+- Pointers to structs
+- new and returning pointers
+- Range loop copies
+- Maps of pointers
+- Nil pointers
+- Linked structures and nil receivers
+=============================================================================
+*/
+
+func main() {
+	fmt.Println("=== Pointers in Go ===")
+	fmt.Println()
+
+	// ========================================
+	// 1. ADDRESS-OF AND DEREFERENCE
+	// ========================================
+
+	stock := 10
+	p := &stock // p has type *int and points at stock
+	fmt.Println("stock:", stock, "| *p:", *p)
+	*p = 25 // writing through the pointer changes stock
+	fmt.Println("After *p = 25, stock:", stock)
+
+	var q *int // the zero value of a pointer is nil
+	fmt.Println("q == nil:", q == nil)
+	q = p
+	fmt.Println("q == p (same address):", q == p)
+
+	// ========================================
+	// 2. PASSING BY VALUE VS BY POINTER
+	// ========================================
+	// The argument is copied into the parameter. Copying a pointer still
+	// points at the caller's value
+
+	keyboard := Product{SKU: "KB-01", Name: "Mechanical Keyboard", Price: 8999, Stock: 3}
+	restockCopy(keyboard, 10)
+	fmt.Println("After restockCopy:", keyboard.Stock)
+	restock(&keyboard, 10)
+	fmt.Println("After restock:    ", keyboard.Stock)
+
+	// ========================================
+	// 3. POINTERS TO STRUCTS
+	// ========================================
+	// kb.Price is shorthand for (*kb).Price, and methods with pointer
+	// receivers can be called on an addressable value directly
+
+	kb := &keyboard
+	kb.Price = 7999
+	fmt.Println("keyboard.Price via kb:", keyboard.Price)
+	keyboard.Reprice(10) // Go calls (&keyboard).Reprice(10)
+	fmt.Println("After Reprice(10):", keyboard.Price)
+
+	// A pointer to a single field
+	price := &keyboard.Price
+	*price += 100
+	fmt.Println("After *price += 100:", keyboard.Price)
+
+	// Copying a struct copies its fields; copying a pointer shares it
+	copied := keyboard
+	shared := &keyboard
+	keyboard.Stock = 0
+	fmt.Println("copied.Stock:", copied.Stock, "| shared.Stock:", shared.Stock)
+
+	// ========================================
+	// 4. NEW AND RETURNING POINTERS
+	// ========================================
+	// new(T) allocates a zero T and returns *T. A function may return
+	// the address of a local variable: it moves to the heap if needed
+
+	blank := new(Product)
+	fmt.Printf("new(Product): %+v\n", *blank)
+
+	mouse := NewProduct("MS-02", "Wireless Mouse", 2499)
+	fmt.Printf("NewProduct:   %+v\n", *mouse)
+
+	// ========================================
+	// 5. RANGE LOOP COPIES
+	// ========================================
+	// The loop variable is a copy of the element, so changing it does
+	// not change the slice. Index into the slice instead
+
+	catalog := []Product{
+		{SKU: "KB-01", Name: "Mechanical Keyboard", Price: 8999, Stock: 3},
+		{SKU: "MS-02", Name: "Wireless Mouse", Price: 2499, Stock: 0},
+		{SKU: "CB-03", Name: "USB-C Cable", Price: 999, Stock: 40},
+	}
+	for _, prod := range catalog {
+		prod.Stock += 5 // changes the copy only
+	}
+	fmt.Println("After range by value:", stockLevels(catalog))
+	for i := range catalog {
+		catalog[i].Stock += 5
+	}
+	fmt.Println("After range by index:", stockLevels(catalog))
+
+	// A pointer to an element stays valid until the slice is reallocated
+	first := &catalog[0]
+	first.Stock = 1
+	fmt.Println("After first.Stock = 1:", stockLevels(catalog))
+
+	// ========================================
+	// 6. MAPS OF POINTERS
+	// ========================================
+	// Map elements are not addressable: bySKU["KB-01"].Stock++ does not
+	// compile for a map[string]Product. Storing pointers allows it,
+	// and the pointers share the products in catalog
+
+	bySKU := make(map[string]*Product, len(catalog))
+	for i := range catalog {
+		bySKU[catalog[i].SKU] = &catalog[i]
+	}
+	bySKU["MS-02"].Stock += 20
+	fmt.Println("After bySKU[\"MS-02\"].Stock += 20:", stockLevels(catalog))
+
+	// ========================================
+	// 7. NIL POINTERS
+	// ========================================
+	// Reading through a nil pointer panics. Check before using a pointer
+	// that may be nil, such as a failed lookup
+
+	missing := bySKU["XX-99"] // absent key: the zero value, nil
+	fmt.Println("missing == nil:", missing == nil)
+	if missing != nil {
+		fmt.Println(missing.Name)
+	}
+	fmt.Println("Dereferencing nil:", tryStock(missing))
+	fmt.Println("Dereferencing mouse:", tryStock(mouse))
+
+	// ========================================
+	// 8. LINKED STRUCTURES AND NIL RECEIVERS
+	// ========================================
+	// Each event points at the one before it. The methods handle a nil
+	// *Event, so an empty history needs no special case
+
+	var history *Event
+	fmt.Println("Empty history:", history.Len(), "events,", history)
+	history = history.Push("order #1001 placed")
+	history = history.Push("order #1001 paid")
+	history = history.Push("order #1001 shipped")
+	fmt.Println("History:", history.Len(), "events")
+	fmt.Println(history)
+
+	fmt.Println("\n✅ Pointers completed!")
+}
+
+// ========================================
+// TYPES AND FUNCTIONS
+// ========================================
+
+// Money is an amount in cents.
+type Money int64
+
+// Product is something the shop sells, with the units in stock.
+type Product struct {
+	SKU   string
+	Name  string
+	Price Money
+	Stock int
+}
+
+// NewProduct returns a pointer to a new product with no stock.
+func NewProduct(sku, name string, price Money) *Product {
+	p := Product{SKU: sku, Name: name, Price: price}
+	return &p // safe: p outlives the function
+}
+
+// Reprice lowers the price by percent; it needs a pointer receiver to
+// change the product.
+func (p *Product) Reprice(percent int) {
+	p.Price -= p.Price * Money(percent) / 100
+}
+
+// restockCopy gets a copy of the product, so the caller sees no change
+func restockCopy(p Product, n int) {
+	p.Stock += n
+}
+
+// restock gets a pointer to the caller's product
+func restock(p *Product, n int) {
+	p.Stock += n
+}
+
+func stockLevels(products []Product) string {
+	parts := make([]string, len(products))
+	for i, p := range products {
+		parts[i] = fmt.Sprintf("%s=%d", p.SKU, p.Stock)
+	}
+	return strings.Join(parts, " ")
+}
+
+// tryStock reads p.Stock, turning the nil dereference panic into a
+// message
+func tryStock(p *Product) (result string) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = fmt.Sprint("panic: ", r)
+		}
+	}()
+	return fmt.Sprint(p.Stock)
+}
+
+// Event is one entry of an order history, linked to the entry before.
+type Event struct {
+	Text string
+	Prev *Event
+}
+
+// Push returns a new head of the history. It works on a nil history,
+// which is how the first event is added.
+func (e *Event) Push(text string) *Event {
+	return &Event{Text: text, Prev: e}
+}
+
+// Len counts the events, following Prev until the nil at the end.
+func (e *Event) Len() int {
+	n := 0
+	for ; e != nil; e = e.Prev {
+		n++
+	}
+	return n
+}
+
+// String lists the events oldest first.
+func (e *Event) String() string {
+	if e == nil {
+		return "(none)"
+	}
+	if e.Prev == nil {
+		return "  - " + e.Text
+	}
+	return e.Prev.String() + "\n  - " + e.Text
+}
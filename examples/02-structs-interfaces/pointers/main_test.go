@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+var _ fmt.Stringer = (*Event)(nil)
+
+// TestNilEvent calls every Event method on a nil history.
+func TestNilEvent(t *testing.T) {
+	var h *Event
+	if h.Len() != 0 {
+		t.Errorf("nil Len = %d, want 0", h.Len())
+	}
+	if got := h.String(); got != "(none)" {
+		t.Errorf("nil String = %q, want (none)", got)
+	}
+	h = h.Push("created").Push("paid").Push("shipped")
+	if h.Len() != 3 {
+		t.Errorf("Len = %d, want 3", h.Len())
+	}
+	if got, want := h.String(), "  - created\n  - paid\n  - shipped"; got != want {
+		t.Errorf("String = %q, want %q", got, want)
+	}
+}
+
+func TestRestock(t *testing.T) {
+	p := NewProduct("TEE", "T-shirt", 2000)
+	restockCopy(*p, 5)
+	if p.Stock != 0 {
+		t.Errorf("restockCopy changed the caller's stock to %d", p.Stock)
+	}
+	restock(p, 5)
+	if p.Stock != 5 {
+		t.Errorf("restock: stock %d, want 5", p.Stock)
+	}
+	p.Reprice(10)
+	if p.Price != 1800 {
+		t.Errorf("Reprice(10): price %d, want 1800", p.Price)
+	}
+}
+
+func TestTryStock(t *testing.T) {
+	if got := tryStock(&Product{Stock: 3}); got != "3" {
+		t.Errorf("tryStock = %q, want 3", got)
+	}
+	if got := tryStock(nil); !strings.HasPrefix(got, "panic: ") {
+		t.Errorf("tryStock(nil) = %q, want the recovered panic", got)
+	}
+}
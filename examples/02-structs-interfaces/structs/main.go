@@ -0,0 +1,321 @@
+package main
+
+import "fmt"
+
+/*
+=============================================================================
+TOPIC: Structs and Methods in Go
+=============================================================================
+
+Structs group related fields into one type, and methods attach behavior
+to any named type. Go has no classes and no inheritance: bigger types
+are built by embedding smaller ones, and the receiver of each method
+decides whether it works on a copy or on the original.
+
+The lessons of this module share one small domain: the products,
+customers and orders of an online shop.
+
+Key Concepts:
+- Struct declaration and literals
+- Zero values and comparison
+- Value receivers
+- Pointer receivers
+- Constructor functions
+- Embedded structs and promoted fields
+- Method sets
+- Nil receivers
+=============================================================================
+*/
+
+// Compile-time checks: the build fails if a type loses a method that an
+// interface needs. They cost nothing at run time.
+var (
+	_ fmt.Stringer = Money(0)
+	_ fmt.Stringer = Product{}
+	_ fmt.Stringer = (*Order)(nil) // Order's String has a pointer receiver
+)
+
+func main() {
+	fmt.Println("=== Structs and Methods in Go ===")
+	fmt.Println()
+
+	// ========================================
+	// 1. STRUCT LITERALS
+	// ========================================
+
+	// Named fields: order does not matter and omitted fields are zero
+	keyboard := Product{SKU: "KB-01", Name: "Mechanical Keyboard", Price: 8999, Weight: 950}
+	mouse := Product{SKU: "MS-02", Name: "Wireless Mouse", Price: 2499, Weight: 120}
+	cable := Product{SKU: "CB-03", Name: "USB-C Cable", Price: 999}
+
+	fmt.Printf("%#v\n", keyboard)      // %#v shows the fields as Go syntax
+	fmt.Println("String():", keyboard) // fmt calls the String method
+	fmt.Println("Cable weight (omitted):", cable.Weight)
+
+	// Anonymous struct: a one-off type, handy for tables and test cases
+	summary := struct {
+		Products int
+		Cheapest string
+	}{3, cable.Name}
+	fmt.Printf("Summary: %+v\n", summary)
+
+	// ========================================
+	// 2. ZERO VALUES AND COMPARISON
+	// ========================================
+
+	var empty Product
+	fmt.Printf("Zero Product: %#v\n", empty)
+	fmt.Println("Zero Product is empty:", empty == Product{})
+
+	// Structs whose fields are all comparable can be compared with ==
+	copyOfMouse := mouse
+	fmt.Println("mouse == copyOfMouse:", mouse == copyOfMouse)
+	copyOfMouse.Price = 1999
+	fmt.Println("After changing the copy:", mouse.Price, "vs", copyOfMouse.Price)
+	// Order holds a slice, so Order values cannot be compared with ==
+
+	// ========================================
+	// 3. VALUE RECEIVERS
+	// ========================================
+	// A value receiver gets a copy: it can read the fields, and changes
+	// it makes are lost when it returns
+
+	sale := keyboard.WithDiscount(25)
+	fmt.Println("Keyboard:", keyboard.Price, "On sale:", sale.Price)
+
+	keyboard.discountInPlaceBroken(50)
+	fmt.Println("After discountInPlaceBroken(50):", keyboard.Price)
+
+	// ========================================
+	// 4. POINTER RECEIVERS
+	// ========================================
+	// A pointer receiver can change the original. Go takes the address
+	// for you: keyboard.SetPrice(...) means (&keyboard).SetPrice(...)
+
+	keyboard.SetPrice(7999)
+	fmt.Println("After SetPrice(7999):", keyboard.Price)
+
+	// ========================================
+	// 5. CONSTRUCTORS
+	// ========================================
+	// Go has no constructors; a NewX function that returns a ready-to-use
+	// value (often a pointer) is the convention
+
+	alice := &Customer{
+		ID:    1,
+		Name:  "Alice",
+		Email: "alice@example.com",
+		Address: Address{
+			Street:  "12 Main St",
+			City:    "Springfield",
+			Country: "US",
+		},
+	}
+	order := NewOrder(1001, alice)
+	order.Add(keyboard, 1)
+	order.Add(mouse, 2)
+	order.Add(cable, 3)
+	order.Add(mouse, 1) // same SKU: the quantity grows, no new line
+	fmt.Println(order)
+	for _, line := range order.Lines {
+		fmt.Printf("  %-20s %d x %-7s = %s\n", line.Name, line.Quantity, line.Price, line.Subtotal())
+	}
+
+	// ========================================
+	// 6. EMBEDDED STRUCTS
+	// ========================================
+	// Customer embeds Address, so Address's fields and methods are
+	// promoted: alice.City is alice.Address.City
+
+	fmt.Println("City (promoted field):", alice.City)
+	fmt.Println("City (full path):", alice.Address.City)
+	fmt.Println("Label (promoted method):", alice.Label())
+
+	// LineItem embeds Product, so every line has the product's fields
+	line := order.Lines[0]
+	fmt.Println("Line name:", line.Name, "| shipping weight:", line.Weight, "g each")
+
+	// ========================================
+	// 7. METHOD SETS
+	// ========================================
+	// The method set of a value type T holds only its value-receiver
+	// methods; *T holds both. Only methods in the set count towards
+	// implementing an interface.
+
+	var p Product
+	var o Order
+	_, ok := any(p).(fmt.Stringer)
+	fmt.Println("Product  is a fmt.Stringer:", ok)
+	_, ok = any(&p).(fmt.Stringer)
+	fmt.Println("*Product is a fmt.Stringer:", ok)
+	_, ok = any(p).(priceSetter)
+	fmt.Println("Product  has SetPrice:     ", ok)
+	_, ok = any(&p).(priceSetter)
+	fmt.Println("*Product has SetPrice:     ", ok)
+	_, ok = any(o).(fmt.Stringer)
+	fmt.Println("Order    is a fmt.Stringer:", ok)
+	_, ok = any(&o).(fmt.Stringer)
+	fmt.Println("*Order   is a fmt.Stringer:", ok)
+
+	// Printing an Order value, not a pointer, does not use its String
+	// method, so fmt falls back to the fields
+	fmt.Printf("Order value:   %v\n", Order{ID: 7})
+	fmt.Printf("Order pointer: %v\n", &Order{ID: 7})
+
+	// ========================================
+	// 8. NIL RECEIVERS
+	// ========================================
+	// A method with a pointer receiver can be called on a nil pointer;
+	// it is just a function whose first argument is nil
+
+	var guest *Customer
+	fmt.Println("guest == nil:", guest == nil)
+	fmt.Println("guest.DisplayName():", guest.DisplayName())
+
+	guestOrder := NewOrder(1002, nil)
+	guestOrder.Add(cable, 1)
+	fmt.Println(guestOrder)
+
+	fmt.Println("\n✅ Structs and Methods completed!")
+}
+
+// ========================================
+// TYPES AND METHODS
+// ========================================
+
+// Money is an amount in cents. Whole cents in an integer avoid the
+// rounding errors of float64, where 0.1 + 0.2 != 0.3.
+type Money int64
+
+// String formats m as dollars: Money(1999) is "$19.99".
+func (m Money) String() string {
+	sign := ""
+	if m < 0 {
+		sign, m = "-", -m
+	}
+	return fmt.Sprintf("%s$%d.%02d", sign, m/100, m%100)
+}
+
+// Product is something the shop sells.
+type Product struct {
+	SKU    string
+	Name   string
+	Price  Money
+	Weight int // grams
+}
+
+// Value receiver: String only reads the product
+func (p Product) String() string {
+	return fmt.Sprintf("%s (%s) %s", p.Name, p.SKU, p.Price)
+}
+
+// Value receiver returning a modified copy; the original is untouched
+func (p Product) WithDiscount(percent int) Product {
+	p.Price -= p.Price * Money(percent) / 100
+	return p
+}
+
+// A common mistake: the value receiver changes a copy, so the caller's
+// product keeps its price
+func (p Product) discountInPlaceBroken(percent int) {
+	p.Price -= p.Price * Money(percent) / 100
+}
+
+// Pointer receiver: SetPrice changes the caller's product
+func (p *Product) SetPrice(m Money) {
+	p.Price = m
+}
+
+// priceSetter is satisfied only by *Product, because SetPrice has a
+// pointer receiver
+type priceSetter interface {
+	SetPrice(Money)
+}
+
+// Address is a postal address.
+type Address struct {
+	Street  string
+	City    string
+	Country string
+}
+
+// Label formats a for a shipping label.
+func (a Address) Label() string {
+	return fmt.Sprintf("%s, %s, %s", a.Street, a.City, a.Country)
+}
+
+// Customer embeds Address: its fields and Label are promoted.
+type Customer struct {
+	ID    int
+	Name  string
+	Email string
+	Address
+}
+
+// DisplayName handles a nil receiver, so an order without a customer
+// (a guest checkout) can still be printed
+func (c *Customer) DisplayName() string {
+	if c == nil {
+		return "guest"
+	}
+	return c.Name
+}
+
+// LineItem is one product on an order, with the quantity ordered.
+type LineItem struct {
+	Product
+	Quantity int
+}
+
+// Subtotal is the price of the whole line.
+func (li LineItem) Subtotal() Money {
+	return li.Price * Money(li.Quantity)
+}
+
+// Order is a customer's order. Customer is nil for a guest checkout.
+//
+// All methods of Order have pointer receivers: Add must change the order,
+// and mixing receiver kinds on one type makes its method set confusing.
+type Order struct {
+	ID       int
+	Customer *Customer
+	Lines    []LineItem
+}
+
+// Constructor function
+func NewOrder(id int, c *Customer) *Order {
+	return &Order{ID: id, Customer: c}
+}
+
+// Add adds qty of p, merging with an existing line for the same SKU.
+func (o *Order) Add(p Product, qty int) {
+	for i := range o.Lines {
+		if o.Lines[i].SKU == p.SKU {
+			o.Lines[i].Quantity += qty // o.Lines[i], not a range copy
+			return
+		}
+	}
+	o.Lines = append(o.Lines, LineItem{Product: p, Quantity: qty})
+}
+
+// Items is the number of units on the order.
+func (o *Order) Items() int {
+	n := 0
+	for _, line := range o.Lines {
+		n += line.Quantity
+	}
+	return n
+}
+
+// Total is the sum of the line subtotals.
+func (o *Order) Total() Money {
+	var total Money
+	for _, line := range o.Lines {
+		total += line.Subtotal()
+	}
+	return total
+}
+
+func (o *Order) String() string {
+	return fmt.Sprintf("Order #%d for %s: %d items, %s", o.ID, o.Customer.DisplayName(), o.Items(), o.Total())
+}
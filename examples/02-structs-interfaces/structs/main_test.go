@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+var (
+	_ priceSetter  = (*Product)(nil) // not Product{}: SetPrice has a pointer receiver
+	_ fmt.Stringer = Product{}
+	_ fmt.Stringer = LineItem{} // promoted from the embedded Product
+	_ fmt.Stringer = (*Order)(nil)
+)
+
+// TestNilCustomer calls DisplayName on a nil *Customer, as a guest
+// order does.
+func TestNilCustomer(t *testing.T) {
+	var c *Customer
+	if got := c.DisplayName(); got != "guest" {
+		t.Errorf("nil DisplayName = %q, want guest", got)
+	}
+	o := NewOrder(7, nil)
+	o.Add(Product{SKU: "MUG", Name: "Mug", Price: 899}, 2)
+	if got, want := o.String(), "Order #7 for guest: 2 items, $17.98"; got != want {
+		t.Errorf("String = %q, want %q", got, want)
+	}
+}
+
+func TestReceivers(t *testing.T) {
+	p := Product{SKU: "TEE", Name: "T-shirt", Price: 2000}
+	if got := p.WithDiscount(25); got.Price != 1500 {
+		t.Errorf("WithDiscount(25).Price = %s, want $15.00", got.Price)
+	}
+	p.discountInPlaceBroken(25)
+	if p.Price != 2000 {
+		t.Errorf("a value receiver changed the price to %s", p.Price)
+	}
+	p.SetPrice(999)
+	if p.Price != 999 {
+		t.Errorf("SetPrice(999) left the price at %s", p.Price)
+	}
+}
+
+func TestOrder(t *testing.T) {
+	c := &Customer{ID: 1, Name: "Ada", Address: Address{Street: "1 Main St", City: "Springfield", Country: "US"}}
+	if got, want := c.Label(), "1 Main St, Springfield, US"; got != want {
+		t.Errorf("promoted Label = %q, want %q", got, want)
+	}
+	o := NewOrder(1, c)
+	tee := Product{SKU: "TEE", Name: "T-shirt", Price: 1999}
+	o.Add(tee, 1)
+	o.Add(Product{SKU: "MUG", Name: "Mug", Price: 899}, 1)
+	o.Add(tee, 2) // merges with the first line
+	if len(o.Lines) != 2 {
+		t.Fatalf("%d lines, want 2: %v", len(o.Lines), o.Lines)
+	}
+	if o.Lines[0].Quantity != 3 || o.Items() != 4 || o.Total() != 3*1999+899 {
+		t.Errorf("got %d tees, %d items, total %s", o.Lines[0].Quantity, o.Items(), o.Total())
+	}
+}
@@ -0,0 +1,460 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"embed"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"html/template"
+	"io"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+/*
+=============================================================================
+TOPIC: HTTP Servers in Go
+SERVER: http://localhost:8080/
+=============================================================================
+
+The standard library's net/http is a production-grade HTTP server. Since
+Go 1.22 its ServeMux routes on the method and on path wildcards, so a
+small site needs no framework at all: handlers, a few middleware
+functions that wrap them, html/template for pages and an embedded file
+system for static assets.
+
+This server is a storefront for the shop of the structs and interfaces
+lessons. It runs until Ctrl+C (SIGINT) or SIGTERM, then finishes the
+requests in flight before exiting. main_test.go sends requests to every
+route and middleware through httptest.
+
+Key Concepts:
+- Handlers and HandlerFunc
+- Method and wildcard routing
+- Templates and embedded files
+- Static files
+- JSON responses
+- Middleware chains
+- Request IDs in the context
+- Panic recovery
+- Timeouts
+- Graceful shutdown
+- Testing with httptest
+=============================================================================
+*/
+
+// The templates and static files are compiled into the binary, so the
+// server works from any directory.
+//
+//go:embed templates static
+var content embed.FS
+
+func main() {
+	addr := flag.String("addr", "localhost:8080", "address to listen on")
+	timeout := flag.Duration("timeout", 2*time.Second, "time limit for each request")
+	flag.Parse()
+	
+	app := newApp(log.New(os.Stderr, "", log.LstdFlags), *timeout)
+	
+	// ========================================
+	// 1. THE SERVER
+	// ========================================
+	// http.ListenAndServe has no timeouts at all; a slow client could hold
+	// a connection forever. Set them on an http.Server instead
+	
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           app.routes(),
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       10 * time.Second,
+		WriteTimeout:      *timeout + 5*time.Second, // longer than any handler may take
+		IdleTimeout:       time.Minute,
+	}
+	
+	// ========================================
+	// 2. GRACEFUL SHUTDOWN
+	// ========================================
+	// ctx is cancelled on the first SIGINT or SIGTERM. Shutdown then stops
+	// accepting connections and waits for active requests to finish
+	
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- srv.ListenAndServe()
+	}()
+	fmt.Printf("Gopher Shop at http://%s/ (Ctrl+C to stop)\n", *addr)
+	
+	select {
+	case err := <-serveErr:
+		// The server never started, e.g. because the port is in use
+		log.Fatal(err)
+	case <-ctx.Done():
+	}
+	stop() // restore the default: a second Ctrl+C exits at once
+	
+	fmt.Println("\nShutting down, waiting for requests in flight...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Printf("shutdown: %v", err)
+	}
+	if err := <-serveErr; !errors.Is(err, http.ErrServerClosed) {
+		log.Printf("serve: %v", err)
+	}
+	fmt.Println("Server stopped")
+}
+
+// ========================================
+// APPLICATION AND ROUTES
+// ========================================
+
+// product is one item of the catalog. The struct tags name the JSON
+// fields; Description is left out of the JSON with "-".
+type product struct {
+	SKU         string `json:"sku"`
+	Name        string `json:"name"`
+	Price       int64  `json:"price_cents"`
+	Stock       int    `json:"stock"`
+	Description string `json:"-"`
+}
+
+// app holds what the handlers share. Handlers are methods on it, so no
+// global variables are needed.
+type app struct {
+	log       *log.Logger
+	templates *template.Template
+	static    fs.FS
+	products  []product
+	timeout   time.Duration
+}
+
+func newApp(logger *log.Logger, timeout time.Duration) *app {
+	// template.Must panics on a parse error: templates are part of the
+	// program, so a broken one is a bug to catch at startup
+	tmpl := template.Must(template.New("").Funcs(template.FuncMap{
+		"money": formatCents,
+	}).ParseFS(content, "templates/*.html"))
+	static, err := fs.Sub(content, "static")
+	if err != nil {
+		panic(err)
+	}
+	return &app{
+		log:       logger,
+		templates: tmpl,
+		static:    static,
+		timeout:   timeout,
+		products: []product{
+			{"KB-01", "Mechanical Keyboard", 8999, 5, "Tactile switches and a detachable USB-C cable."},
+			{"MS-02", "Wireless Mouse", 2499, 0, "Two buttons, a wheel and a battery that lasts months."},
+			{"CB-03", "USB-C Cable", 999, 40, "One metre, braided, 60 W."},
+		},
+	}
+}
+
+// routes registers every handler and wraps the mux in the middleware.
+//
+// A pattern is "[METHOD ]PATH". {name} matches one path segment and is
+// read with r.PathValue; {$} matches the end of the path, so "/{$}" is
+// only the home page. A request for a known path with the wrong method
+// gets 405 Method Not Allowed, with an Allow header, automatically.
+func (a *app) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", a.handleIndex)
+	mux.HandleFunc("GET /products/{sku}", a.handleProductPage)
+	mux.HandleFunc("GET /api/products", a.handleListProducts)
+	mux.HandleFunc("GET /api/products/{sku}", a.handleGetProduct)
+	mux.HandleFunc("POST /api/echo", a.handleEcho)
+	mux.HandleFunc("GET /slow", a.handleSlow)
+	mux.HandleFunc("GET /panic", a.handlePanic)
+	mux.HandleFunc("GET /healthz", a.handleHealth)
+	mux.Handle("GET /static/", http.StripPrefix("/static/", http.FileServerFS(a.static)))
+
+	// The first middleware is the outermost: it sees the request first
+	// and the response last
+	return chain(mux,
+		a.requestID,
+		a.logRequests,
+		a.recoverPanics,
+		a.limitTime,
+	)
+}
+
+// ========================================
+// HANDLERS
+// ========================================
+
+func (a *app) handleIndex(w http.ResponseWriter, r *http.Request) {
+	a.render(w, http.StatusOK, "index.html", map[string]any{
+		"Products": a.products,
+		"Timeout":  a.timeout,
+	})
+}
+
+func (a *app) handleProductPage(w http.ResponseWriter, r *http.Request) {
+	sku := r.PathValue("sku")
+	p, ok := a.findProduct(sku)
+	if !ok {
+		a.render(w, http.StatusNotFound, "notfound.html", sku)
+		return
+	}
+	a.render(w, http.StatusOK, "product.html", p)
+}
+
+// handleListProducts returns the catalog, or with ?max_price=N only the
+// products that cost at most N cents.
+func (a *app) handleListProducts(w http.ResponseWriter, r *http.Request) {
+	list := a.products
+	if s := r.URL.Query().Get("max_price"); s != "" {
+		limit, err := strconv.ParseInt(s, 10, 64)
+		if err != nil || limit < 0 {
+			writeError(w, r, http.StatusBadRequest, "max_price must be a whole number of cents")
+			return
+		}
+		list = []product{} // [] in the JSON, not null, when nothing matches
+		for _, p := range a.products {
+			if p.Price <= limit {
+				list = append(list, p)
+			}
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"products": list, "count": len(list)})
+}
+
+func (a *app) handleGetProduct(w http.ResponseWriter, r *http.Request) {
+	p, ok := a.findProduct(r.PathValue("sku"))
+	if !ok {
+		writeError(w, r, http.StatusNotFound, "no product with SKU "+r.PathValue("sku"))
+		return
+	}
+	writeJSON(w, http.StatusOK, p)
+}
+
+// handleEcho decodes a JSON object from the body and sends it back with
+// the request ID. MaxBytesReader stops a client from sending gigabytes.
+func (a *app) handleEcho(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, 1<<20)
+	var body map[string]any
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(w, r, http.StatusRequestEntityTooLarge, "body larger than 1 MiB")
+			return
+		}
+		writeError(w, r, http.StatusBadRequest, "body must be a JSON object: "+err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"request_id": requestIDFrom(r.Context()), "echo": body})
+}
+
+// handleSlow works for ?d=duration (1s by default) but stops early when
+// the request's context is cancelled: when the client goes away, or
+// when the timeout middleware gives up on the request.
+func (a *app) handleSlow(w http.ResponseWriter, r *http.Request) {
+	d := time.Second
+	if s := r.URL.Query().Get("d"); s != "" {
+		var err error
+		if d, err = time.ParseDuration(s); err != nil || d < 0 {
+			writeError(w, r, http.StatusBadRequest, "d must be a duration such as 500ms or 3s")
+			return
+		}
+	}
+	select {
+	case <-time.After(d):
+		fmt.Fprintf(w, "done after %s\n", d)
+	case <-r.Context().Done():
+		a.log.Printf("%s slow request abandoned: %v", requestIDFrom(r.Context()), r.Context().Err())
+	}
+}
+
+func (a *app) handlePanic(w http.ResponseWriter, r *http.Request) {
+	var p *product
+	fmt.Fprintln(w, p.Name) // nil pointer dereference
+}
+
+func (a *app) handleHealth(w http.ResponseWriter, r *http.Request) {
+	fmt.Fprintln(w, "ok")
+}
+
+func (a *app) findProduct(sku string) (product, bool) {
+	for _, p := range a.products {
+		if p.SKU == sku {
+			return p, true
+		}
+	}
+	return product{}, false
+}
+
+// render executes a template into a buffer first, so that a template
+// error can still become a clean 500 instead of half a page.
+func (a *app) render(w http.ResponseWriter, status int, name string, data any) {
+	var buf strings.Builder
+	if err := a.templates.ExecuteTemplate(&buf, name, data); err != nil {
+		a.log.Printf("render %s: %v", name, err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	io.WriteString(w, buf.String())
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(v)
+}
+
+func writeError(w http.ResponseWriter, r *http.Request, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg, "request_id": requestIDFrom(r.Context())})
+}
+
+// formatCents formats 1999 as "$19.99".
+func formatCents(c int64) string {
+	return fmt.Sprintf("$%d.%02d", c/100, c%100)
+}
+
+// ========================================
+// MIDDLEWARE
+// ========================================
+// A middleware takes a handler and returns one that does something
+// before and/or after calling it
+
+type middleware func(http.Handler) http.Handler
+
+func chain(h http.Handler, mws ...middleware) http.Handler {
+	for i := len(mws) - 1; i >= 0; i-- {
+		h = mws[i](h)
+	}
+	return h
+}
+
+// contextKey is unexported, so no other package can read or overwrite
+// the values stored under it.
+type contextKey int
+
+const requestIDKey contextKey = iota
+
+// requestID gives every request an ID, taken from a well-formed
+// X-Request-ID header (set by a proxy, say) or made up. It is stored in
+// the request's context for the handlers and logs, and sent back in the
+// response header so a user can quote it in a bug report.
+func (a *app) requestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get("X-Request-ID")
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+		w.Header().Set("X-Request-ID", id)
+		ctx := context.WithValue(r.Context(), requestIDKey, id)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func requestIDFrom(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+func newRequestID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// validRequestID accepts short IDs of letters, digits, '-' and '_', so a
+// client cannot inject anything odd into the logs.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > 64 {
+		return false
+	}
+	for _, c := range id {
+		ok := c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_'
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// statusRecorder remembers the status code and size of a response,
+// which http.ResponseWriter does not expose.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func (s *statusRecorder) WriteHeader(code int) {
+	if s.status == 0 {
+		s.status = code
+	}
+	s.ResponseWriter.WriteHeader(code)
+}
+
+func (s *statusRecorder) Write(b []byte) (int, error) {
+	if s.status == 0 {
+		s.status = http.StatusOK // Write without WriteHeader means 200
+	}
+	n, err := s.ResponseWriter.Write(b)
+	s.bytes += n
+	return n, err
+}
+
+// Unwrap lets http.ResponseController reach the real ResponseWriter.
+func (s *statusRecorder) Unwrap() http.ResponseWriter { return s.ResponseWriter }
+
+// logRequests logs one line per request, after it is served.
+func (a *app) logRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w}
+		next.ServeHTTP(rec, r)
+		a.log.Printf("%s %s %s %d %dB %s", requestIDFrom(r.Context()), r.Method, r.URL.RequestURI(),
+			rec.status, rec.bytes, time.Since(start).Round(time.Microsecond))
+	})
+}
+
+// recoverPanics turns a panicking handler into a 500 response and a log
+// entry with the stack. Without it net/http would still recover, but
+// the client would only see its connection drop.
+func (a *app) recoverPanics(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			v := recover()
+			if v == nil {
+				return
+			}
+			if v == http.ErrAbortHandler {
+				panic(v) // a deliberate abort; let net/http handle it
+			}
+			id := requestIDFrom(r.Context())
+			a.log.Printf("%s panic: %v\n%s", id, v, debug.Stack())
+			w.Header().Set("Connection", "close")
+			writeError(w, r, http.StatusInternalServerError, "internal server error")
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
+
+// limitTime gives each request a.timeout. http.TimeoutHandler cancels
+// the request's context when the time is up and replies 503 itself;
+// handlers that watch r.Context() stop working then.
+func (a *app) limitTime(next http.Handler) http.Handler {
+	return http.TimeoutHandler(next, a.timeout, "request timed out\n")
+}
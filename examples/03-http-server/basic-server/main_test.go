@@ -0,0 +1,263 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// syncBuffer collects log output. The timeout middleware runs handlers
+// in their own goroutine, which may still log after the response.
+type syncBuffer struct {
+	mu sync.Mutex
+	b  strings.Builder
+}
+
+func (s *syncBuffer) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.b.Write(p)
+}
+
+func (s *syncBuffer) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.b.String()
+}
+
+func newTestApp(timeout time.Duration) (*app, *syncBuffer) {
+	logs := new(syncBuffer)
+	return newApp(log.New(logs, "", 0), timeout), logs
+}
+
+// serve sends one request through h and returns the response.
+func serve(h http.Handler, method, target, body string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(method, target, strings.NewReader(body)))
+	return rec
+}
+
+type routeCase struct {
+	name         string
+	method, path string
+	body         string
+	status       int
+	contains     string
+}
+
+// testRoutes sends each case through the full middleware chain.
+func testRoutes(t *testing.T, cases []routeCase) {
+	t.Helper()
+	a, _ := newTestApp(200 * time.Millisecond)
+	h := a.routes()
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			rec := serve(h, c.method, c.path, c.body)
+			if rec.Code != c.status {
+				t.Errorf("%s %s: status %d, want %d", c.method, c.path, rec.Code, c.status)
+			}
+			if !strings.Contains(rec.Body.String(), c.contains) {
+				t.Errorf("%s %s: body does not contain %q:\n%s", c.method, c.path, c.contains, rec.Body)
+			}
+		})
+	}
+}
+
+// ========================================
+// ROUTES
+// ========================================
+
+func TestIndex(t *testing.T) {
+	testRoutes(t, []routeCase{
+		{"catalog", "GET", "/", "", 200, "Mechanical Keyboard"},
+		{"only the root", "GET", "/no/such/page", "", 404, "not found"},
+	})
+}
+
+func TestProductPage(t *testing.T) {
+	testRoutes(t, []routeCase{
+		{"in stock", "GET", "/products/KB-01", "", 200, "Tactile switches"},
+		{"sold out", "GET", "/products/MS-02", "", 200, "sold out"},
+		{"unknown", "GET", "/products/XX-99", "", 404, "no product with SKU <code>XX-99</code>"},
+		{"escaped", "GET", "/products/%3Cb%3E", "", 404, "&lt;b&gt;"},
+	})
+}
+
+func TestListProducts(t *testing.T) {
+	testRoutes(t, []routeCase{
+		{"all", "GET", "/api/products", "", 200, `"count": 3`},
+		{"max price", "GET", "/api/products?max_price=3000", "", 200, `"count": 2`},
+		{"none match", "GET", "/api/products?max_price=0", "", 200, `"products": []`},
+		{"bad max price", "GET", "/api/products?max_price=cheap", "", 400, "max_price"},
+		{"negative max price", "GET", "/api/products?max_price=-1", "", 400, "max_price"},
+		{"wrong method", "POST", "/api/products", "", 405, "Method Not Allowed"},
+	})
+}
+
+func TestGetProduct(t *testing.T) {
+	testRoutes(t, []routeCase{
+		{"found", "GET", "/api/products/CB-03", "", 200, `"price_cents": 999`},
+		{"unknown", "GET", "/api/products/XX-99", "", 404, "no product with SKU XX-99"},
+	})
+	a, _ := newTestApp(time.Second)
+	rec := serve(a.routes(), "GET", "/api/products/KB-01", "")
+	if strings.Contains(rec.Body.String(), "Tactile") {
+		t.Error(`the description is sent, despite json:"-"`)
+	}
+}
+
+func TestEcho(t *testing.T) {
+	testRoutes(t, []routeCase{
+		{"object", "POST", "/api/echo", `{"sku":"KB-01","qty":2}`, 200, `"sku": "KB-01"`},
+		{"not json", "POST", "/api/echo", `not json`, 400, "JSON object"},
+		{"too large", "POST", "/api/echo", `{"pad":"` + strings.Repeat("x", 1<<20) + `"}`, 413, "1 MiB"},
+		{"wrong method", "GET", "/api/echo", "", 405, "Method Not Allowed"},
+	})
+}
+
+func TestSlow(t *testing.T) {
+	testRoutes(t, []routeCase{
+		{"default is too slow", "GET", "/slow", "", 503, "request timed out"},
+		{"short", "GET", "/slow?d=10ms", "", 200, "done after 10ms"},
+		{"bad duration", "GET", "/slow?d=soon", "", 400, "duration"},
+	})
+}
+
+func TestPanic(t *testing.T) {
+	testRoutes(t, []routeCase{
+		{"recovered", "GET", "/panic", "", 500, "internal server error"},
+	})
+}
+
+func TestHealth(t *testing.T) {
+	testRoutes(t, []routeCase{
+		{"ok", "GET", "/healthz", "", 200, "ok"},
+	})
+}
+
+func TestStatic(t *testing.T) {
+	testRoutes(t, []routeCase{
+		{"stylesheet", "GET", "/static/style.css", "", 200, "font-family"},
+		{"missing", "GET", "/static/missing.css", "", 404, "not found"},
+	})
+}
+
+// ========================================
+// MIDDLEWARE
+// ========================================
+
+func TestRequestID(t *testing.T) {
+	a, _ := newTestApp(time.Second)
+	var seen string
+	h := a.requestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = requestIDFrom(r.Context())
+	}))
+	generated := regexp.MustCompile(`^[0-9a-f]{16}$`)
+
+	tests := []struct {
+		name, header string
+		keep         bool // the header is used as the ID
+	}{
+		{"none sent", "", false},
+		{"well formed", "req-42_A", true},
+		{"bad characters", "id\nforged log line", false},
+		{"too long", strings.Repeat("a", 65), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/", nil)
+			if tt.header != "" {
+				req.Header.Set("X-Request-ID", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			id := rec.Header().Get("X-Request-ID")
+			if id != seen {
+				t.Errorf("header %q, context %q: want the same ID", id, seen)
+			}
+			if tt.keep && id != tt.header || !tt.keep && !generated.MatchString(id) {
+				t.Errorf("sent %q, got ID %q", tt.header, id)
+			}
+		})
+	}
+}
+
+func TestLogRequests(t *testing.T) {
+	a, logs := newTestApp(time.Second)
+	h := a.requestID(a.logRequests(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/created" {
+			w.WriteHeader(http.StatusCreated)
+			w.WriteHeader(http.StatusTeapot) // ignored: the first status counts
+		}
+		w.Write([]byte("hello"))
+	})))
+
+	req := httptest.NewRequest("POST", "/created?x=1", nil)
+	req.Header.Set("X-Request-ID", "req-1")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	serve(h, "GET", "/implicit", "")
+
+	for _, want := range []string{"req-1 POST /created?x=1 201 5B", "GET /implicit 200 5B"} {
+		if !strings.Contains(logs.String(), want) {
+			t.Errorf("log has no %q:\n%s", want, logs)
+		}
+	}
+}
+
+func TestRecoverPanics(t *testing.T) {
+	a, logs := newTestApp(time.Second)
+	h := a.requestID(a.recoverPanics(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	})))
+	rec := serve(h, "GET", "/", "")
+	if rec.Code != http.StatusInternalServerError || rec.Header().Get("Connection") != "close" {
+		t.Errorf("status %d, Connection %q; want 500 and close", rec.Code, rec.Header().Get("Connection"))
+	}
+	var body map[string]string
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil || body["request_id"] != rec.Header().Get("X-Request-ID") {
+		t.Errorf("body %s (%v): want the error with the request ID", rec.Body, err)
+	}
+	if !strings.Contains(logs.String(), "panic: boom") || !strings.Contains(logs.String(), "goroutine") {
+		t.Errorf("log has no panic and stack:\n%s", logs)
+	}
+
+	// http.ErrAbortHandler is passed on for net/http to handle.
+	abort := a.recoverPanics(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+	defer func() {
+		if v := recover(); v != http.ErrAbortHandler {
+			t.Errorf("recovered %v, want http.ErrAbortHandler", v)
+		}
+	}()
+	serve(abort, "GET", "/", "")
+}
+
+func TestLimitTime(t *testing.T) {
+	a, _ := newTestApp(50 * time.Millisecond)
+	canceled := make(chan error, 1)
+	h := a.limitTime(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		canceled <- r.Context().Err()
+	}))
+	rec := serve(h, "GET", "/", "")
+	if rec.Code != http.StatusServiceUnavailable || !strings.Contains(rec.Body.String(), "request timed out") {
+		t.Errorf("status %d, body %q; want 503 and the timeout message", rec.Code, rec.Body)
+	}
+	select {
+	case err := <-canceled:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("handler's context ended with %v, want the deadline", err)
+		}
+	case <-time.After(time.Second):
+		t.Error("handler's context was not canceled")
+	}
+}
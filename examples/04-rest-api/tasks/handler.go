@@ -0,0 +1,338 @@
+package tasks
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang-learning-project/pkg/errs"
+)
+
+// MaxBodyBytes is the largest request body the handlers read.
+const MaxBodyBytes = 64 << 10
+
+// MergePatchContentType is the media type of an RFC 7396 JSON merge
+// patch: a JSON object holding only the members to change, where null
+// clears a member.
+const MergePatchContentType = "application/merge-patch+json"
+
+// Page is the body of a list response.
+type Page struct {
+	Items  []Task `json:"items"`
+	Total  int    `json:"total"` // matching tasks on all pages
+	Limit  int    `json:"limit"`
+	Offset int    `json:"offset"`
+}
+
+// Handler serves the task API:
+//
+//	GET    /tasks       list, with the query parameters of ParseQuery
+//	POST   /tasks       create; 201 with a Location header
+//	GET    /tasks/{id}  fetch one, with its ETag
+//	PUT    /tasks/{id}  replace every writable field
+//	PATCH  /tasks/{id}  change some fields, as a JSON merge patch
+//	DELETE /tasks/{id}  delete; 204
+//
+// PUT, PATCH and DELETE need an If-Match header with the task's ETag,
+// or *. This is optimistic concurrency: two clients that fetched the
+// same version cannot both change it, the second gets 412 Precondition
+// Failed instead of silently overwriting the first.
+//
+// Every error is an RFC 7807 problem, see Problem.
+type Handler struct {
+	repo Repository
+	log  *log.Logger
+	mux  *http.ServeMux
+}
+
+// NewHandler returns a Handler serving the tasks in repo. Failures that
+// are the server's fault are logged to logger.
+func NewHandler(repo Repository, logger *log.Logger) *Handler {
+	h := &Handler{repo: repo, log: logger, mux: http.NewServeMux()}
+	h.mux.HandleFunc("GET /tasks", h.handleList)
+	h.mux.HandleFunc("POST /tasks", h.handleCreate)
+	h.mux.HandleFunc("GET /tasks/{id}", h.handleGet)
+	h.mux.HandleFunc("PUT /tasks/{id}", h.handleReplace)
+	h.mux.HandleFunc("PATCH /tasks/{id}", h.handlePatch)
+	h.mux.HandleFunc("DELETE /tasks/{id}", h.handleDelete)
+	h.mux.HandleFunc("GET /problems/{name}", h.handleProblemDoc)
+
+	// Patterns without a method catch what the ones above do not, so
+	// that 404 and 405 are problems too rather than the mux's plain text
+	h.mux.HandleFunc("/tasks", methodNotAllowed("GET, HEAD, POST"))
+	h.mux.HandleFunc("/tasks/{id}", methodNotAllowed("GET, HEAD, PUT, PATCH, DELETE"))
+	h.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeStatusProblem(w, r, http.StatusNotFound, "no resource at "+r.URL.Path)
+	})
+	return h
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+func methodNotAllowed(allow string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Allow", allow)
+		writeStatusProblem(w, r, http.StatusMethodNotAllowed, r.Method+" is not supported here; use "+allow)
+	}
+}
+
+// ========================================
+// HANDLERS
+// ========================================
+
+// handleList sends one page of tasks. A Link header points at the next
+// and previous pages, so a client can walk them without doing the
+// arithmetic.
+func (h *Handler) handleList(w http.ResponseWriter, r *http.Request) {
+	q, err := ParseQuery(r.URL.Query())
+	if err != nil {
+		writeProblem(w, r, problemInvalidQuery, "", FieldErrors(err))
+		return
+	}
+	page, total, err := h.repo.List(r.Context(), q)
+	if err != nil {
+		h.serverError(w, r, err)
+		return
+	}
+
+	var links []string
+	if q.Offset+q.Limit < total {
+		next := q
+		next.Offset += q.Limit
+		links = append(links, fmt.Sprintf(`<%s?%s>; rel="next"`, r.URL.Path, next.Values().Encode()))
+	}
+	if q.Offset > 0 {
+		prev := q
+		prev.Offset = max(q.Offset-q.Limit, 0)
+		links = append(links, fmt.Sprintf(`<%s?%s>; rel="prev"`, r.URL.Path, prev.Values().Encode()))
+	}
+	if len(links) > 0 {
+		w.Header().Set("Link", strings.Join(links, ", "))
+	}
+	writeJSON(w, http.StatusOK, Page{Items: page, Total: total, Limit: q.Limit, Offset: q.Offset})
+}
+
+func (h *Handler) handleCreate(w http.ResponseWriter, r *http.Request) {
+	var in Input
+	if !h.decode(w, r, &in, "application/json") {
+		return
+	}
+	var t Task
+	if err := in.Apply(&t, true); err != nil {
+		writeProblem(w, r, problemValidation, "", FieldErrors(err))
+		return
+	}
+	t, err := h.repo.Create(r.Context(), t)
+	if err != nil {
+		h.serverError(w, r, err)
+		return
+	}
+	w.Header().Set("Location", "/tasks/"+strconv.FormatInt(t.ID, 10))
+	w.Header().Set("ETag", t.ETag())
+	writeJSON(w, http.StatusCreated, t)
+}
+
+// handleGet answers If-None-Match with 304 Not Modified when the client
+// already has the current version, which saves sending the body again.
+func (h *Handler) handleGet(w http.ResponseWriter, r *http.Request) {
+	t, ok := h.load(w, r)
+	if !ok {
+		return
+	}
+	w.Header().Set("ETag", t.ETag())
+	if inm := r.Header.Get("If-None-Match"); inm != "" && etagMatches(inm, t.ETag(), true) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	writeJSON(w, http.StatusOK, t)
+}
+
+func (h *Handler) handleReplace(w http.ResponseWriter, r *http.Request) {
+	h.update(w, r, true, "application/json")
+}
+
+func (h *Handler) handlePatch(w http.ResponseWriter, r *http.Request) {
+	h.update(w, r, false, MergePatchContentType, "application/json")
+}
+
+// update is PUT with replace set and PATCH without.
+func (h *Handler) update(w http.ResponseWriter, r *http.Request, replace bool, contentTypes ...string) {
+	t, ok := h.loadForWrite(w, r)
+	if !ok {
+		return
+	}
+	var in Input
+	if !h.decode(w, r, &in, contentTypes...) {
+		return
+	}
+	version := t.Version
+	if err := in.Apply(&t, replace); err != nil {
+		writeProblem(w, r, problemValidation, "", FieldErrors(err))
+		return
+	}
+	t, err := h.repo.Update(r.Context(), t, version)
+	if err != nil {
+		h.writeRepoError(w, r, err)
+		return
+	}
+	w.Header().Set("ETag", t.ETag())
+	writeJSON(w, http.StatusOK, t)
+}
+
+func (h *Handler) handleDelete(w http.ResponseWriter, r *http.Request) {
+	t, ok := h.loadForWrite(w, r)
+	if !ok {
+		return
+	}
+	if err := h.repo.Delete(r.Context(), t.ID, t.Version); err != nil {
+		h.writeRepoError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) handleProblemDoc(w http.ResponseWriter, r *http.Request) {
+	for _, pt := range problemTypes {
+		if pt.URI == r.URL.Path {
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			fmt.Fprintf(w, "%s (%d %s)\n\n%s\n", pt.Title, pt.Status, http.StatusText(pt.Status), pt.Doc)
+			return
+		}
+	}
+	writeStatusProblem(w, r, http.StatusNotFound, "no problem type "+r.PathValue("name"))
+}
+
+// ========================================
+// HELPERS
+// ========================================
+
+// load fetches the task named by the {id} wildcard. If it cannot, it
+// has written the error response and returns false.
+func (h *Handler) load(w http.ResponseWriter, r *http.Request) (Task, bool) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || id < 1 {
+		// Not a malformed request: there simply is no such task
+		writeStatusProblem(w, r, http.StatusNotFound, "no task "+r.PathValue("id"))
+		return Task{}, false
+	}
+	t, err := h.repo.Get(r.Context(), id)
+	if err != nil {
+		h.writeRepoError(w, r, err)
+		return Task{}, false
+	}
+	return t, true
+}
+
+// loadForWrite is load plus the If-Match check. The repository checks
+// the version again when writing, in case another request changes the
+// task in between.
+func (h *Handler) loadForWrite(w http.ResponseWriter, r *http.Request) (Task, bool) {
+	im := r.Header.Get("If-Match")
+	if im == "" {
+		writeProblem(w, r, problemMissingPrecondition, "", nil)
+		return Task{}, false
+	}
+	t, ok := h.load(w, r)
+	if !ok {
+		return Task{}, false
+	}
+	if !etagMatches(im, t.ETag(), false) {
+		w.Header().Set("ETag", t.ETag())
+		writeProblem(w, r, problemEditConflict,
+			fmt.Sprintf("If-Match is %s but the task is now at %s", im, t.ETag()), nil)
+		return Task{}, false
+	}
+	return t, true
+}
+
+// etagMatches reports whether a comma-separated If-Match or
+// If-None-Match list names etag, or is "*". If-Match compares strongly:
+// a weak tag (W/"...") never matches. If-None-Match compares weakly,
+// ignoring the W/ prefix.
+func etagMatches(list, etag string, weak bool) bool {
+	for _, tag := range strings.Split(list, ",") {
+		tag = strings.TrimSpace(tag)
+		if weak {
+			tag = strings.TrimPrefix(tag, "W/")
+		}
+		if tag == "*" || tag == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// decode reads a JSON object from the body into v. The Content-Type
+// must be one of contentTypes; a second value after the object and
+// bodies over MaxBodyBytes are rejected. If decoding fails it
+// has written the error response and returns false.
+func (h *Handler) decode(w http.ResponseWriter, r *http.Request, v any, contentTypes ...string) bool {
+	mt, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if !slices.Contains(contentTypes, mt) {
+		if r.Method == http.MethodPatch {
+			w.Header().Set("Accept-Patch", strings.Join(contentTypes, ", "))
+		}
+		writeStatusProblem(w, r, http.StatusUnsupportedMediaType,
+			"Content-Type must be "+strings.Join(contentTypes, " or "))
+		return false
+	}
+
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, MaxBodyBytes))
+	err := dec.Decode(v)
+	if err == nil && dec.More() {
+		err = errors.New("body must hold a single JSON object")
+	}
+	if err == nil {
+		return true
+	}
+
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.As(err, &tooLarge):
+		writeStatusProblem(w, r, http.StatusRequestEntityTooLarge,
+			fmt.Sprintf("body is larger than %d bytes", MaxBodyBytes))
+	case len(FieldErrors(err)) > 0:
+		writeProblem(w, r, problemInvalidJSON, "", FieldErrors(err))
+	case errors.Is(err, io.EOF):
+		writeProblem(w, r, problemInvalidJSON, "body is empty", nil)
+	default:
+		writeProblem(w, r, problemInvalidJSON, err.Error(), nil)
+	}
+	return false
+}
+
+// writeRepoError maps the errors of a Repository to responses.
+func (h *Handler) writeRepoError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, errs.ErrNotFound):
+		writeStatusProblem(w, r, http.StatusNotFound, err.Error())
+	case errors.Is(err, errs.ErrConflict):
+		writeProblem(w, r, problemEditConflict, err.Error(), nil)
+	default:
+		h.serverError(w, r, err)
+	}
+}
+
+// serverError logs err and sends a 500 that does not reveal it: the
+// details of a database error are for the operator, not the client.
+func (h *Handler) serverError(w http.ResponseWriter, r *http.Request, err error) {
+	h.log.Printf("%s %s: %v", r.Method, r.URL.Path, err)
+	writeStatusProblem(w, r, http.StatusInternalServerError, "")
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(v)
+}
@@ -0,0 +1,266 @@
+package tasks_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"golang-learning-project/api"
+	"golang-learning-project/api/openapi"
+	"golang-learning-project/examples/04-rest-api/tasks"
+)
+
+// syncBuffer collects the server log, which handlers write to from
+// their own goroutines.
+type syncBuffer struct {
+	mu sync.Mutex
+	b  strings.Builder
+}
+
+func (s *syncBuffer) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.b.Write(p)
+}
+
+func (s *syncBuffer) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.b.String()
+}
+
+// newServer serves repo over HTTP. Every response is checked against
+// api/openapi.json as well; nothing the tests send is the server's
+// fault, so when the test ends the log must be empty.
+func newServer(t *testing.T, repo tasks.Repository) *httptest.Server {
+	t.Helper()
+	doc, err := api.Document()
+	if err != nil {
+		t.Fatal(err)
+	}
+	logs := new(syncBuffer)
+	logger := log.New(logs, "", 0)
+	check := doc.Middleware(openapi.Options{
+		SkipRequests: true,
+		OnResponseError: func(r *http.Request, err error) {
+			logger.Printf("openapi: %v", err)
+		},
+	})
+	srv := httptest.NewServer(check(tasks.NewHandler(repo, logger)))
+	t.Cleanup(func() {
+		srv.Close()
+		if logs.String() != "" {
+			t.Errorf("server log:\n%s", logs)
+		}
+	})
+	return srv
+}
+
+// exchange is one request and what its response must hold. header is
+// an extra request header "Name: value"; contains is looked for in the
+// response headers and body; titles, if set, are the titles the list
+// must return, in order.
+type exchange struct {
+	method, path string
+	ifMatch      string
+	header       string
+	body         string
+	status       int
+	contains     string
+	titles       string
+}
+
+func (e exchange) send(t *testing.T, srv *httptest.Server) {
+	t.Helper()
+	req, err := http.NewRequest(e.method, srv.URL+e.path, strings.NewReader(e.body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if e.ifMatch != "" {
+		req.Header.Set("If-Match", e.ifMatch)
+	}
+	if name, value, ok := strings.Cut(e.header, ": "); ok {
+		req.Header.Set(name, value)
+	}
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if resp.StatusCode != e.status {
+		t.Errorf("status %d, want %d:\n%s", resp.StatusCode, e.status, body)
+	}
+	if got := dumpHeader(resp.Header) + string(body); !strings.Contains(got, e.contains) {
+		t.Errorf("response does not contain %q:\n%s", e.contains, got)
+	}
+	if e.titles != "" {
+		var page tasks.Page
+		if err := json.Unmarshal(body, &page); err != nil {
+			t.Fatalf("list: %v", err)
+		}
+		if got := titlesOf(page.Items); got != e.titles {
+			t.Errorf("titles %q, want %q", got, e.titles)
+		}
+	}
+}
+
+// dumpHeader writes h as sorted "Name: value" lines, for contains to
+// search.
+func dumpHeader(h http.Header) string {
+	var lines []string
+	for name, values := range h {
+		for _, v := range values {
+			lines = append(lines, name+": "+v)
+		}
+	}
+	slices.Sort(lines)
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// runExchanges sends the exchanges in order, each as a subtest, to a
+// server for each repository.
+func runExchanges(t *testing.T, setup []string, exchanges []exchange) {
+	forEachRepo(t, func(t *testing.T, repo tasks.Repository) {
+		srv := newServer(t, repo)
+		for _, body := range setup {
+			exchange{method: "POST", path: "/tasks", body: body, status: 201}.send(t, srv)
+		}
+		for _, e := range exchanges {
+			t.Run(e.method+" "+e.path, func(t *testing.T) { e.send(t, srv) })
+		}
+	})
+}
+
+const (
+	milk      = `{"title":"Buy milk","priority":2,"due":"2026-01-10"}`
+	reportDoc = `{"title":"Write report","notes":"Quarterly numbers","status":"doing","priority":5,"due":"2026-01-05"}`
+	plumber   = `{"title":"Call plumber","priority":5}`
+	flights   = `{"title":"Book flights","status":"done","priority":1}`
+	merge     = "Content-Type: " + tasks.MergePatchContentType
+	blank     = "about:blank"
+)
+
+var fourTasks = []string{milk, reportDoc, plumber, flights}
+
+func TestCreate(t *testing.T) {
+	runExchanges(t, nil, []exchange{
+		{"GET", "/tasks", "", "", "", 200, `"items": []`, ""},
+		{"POST", "/tasks", "", "", milk, 201, "Location: /tasks/1", ""},
+		{"POST", "/tasks", "", "", reportDoc, 201, `Etag: "2-1"`, ""},
+		{"POST", "/tasks", "", "", plumber, 201, `"status": "todo"`, ""},
+		{"POST", "/tasks", "", "", `{"title":"  ","status":"later","priority":9,"due":"soon"}`, 422, `"name": "due"`, ""},
+		{"POST", "/tasks", "", "", `{"title":"Paint","colour":"red"}`, 400, `"name": "colour"`, ""},
+		{"POST", "/tasks", "", "", `{"title":5,"priority":"high"}`, 400, "must be a whole number", ""},
+		{"POST", "/tasks", "", "", `{"title":"a"} {"title":"b"}`, 400, "single JSON object", ""},
+		{"POST", "/tasks", "", "", `{"title":`, 400, "/problems/invalid-json", ""},
+		{"POST", "/tasks", "", "Content-Type: application/json", ``, 400, "body is empty", ""},
+		{"POST", "/tasks", "", "Content-Type: text/plain", plumber, 415, "application/problem+json", ""},
+		{"POST", "/tasks", "", "", `{"notes":"` + strings.Repeat("x", tasks.MaxBodyBytes) + `"}`, 413, "larger than", ""},
+		{"POST", "/tasks", "", "", flights, 201, `"id": 4`, ""},
+	})
+}
+
+func TestList(t *testing.T) {
+	runExchanges(t, fourTasks, []exchange{
+		{"GET", "/tasks?status=todo,doing&sort=-priority", "", "", "", 200, `"total": 3`, "Write report,Call plumber,Buy milk"},
+		{"GET", "/tasks?sort=due", "", "", "", 200, "", "Call plumber,Book flights,Write report,Buy milk"},
+		{"GET", "/tasks?q=NUMBERS", "", "", "", 200, "", "Write report"},
+		{"GET", "/tasks?q=%25", "", "", "", 200, `"total": 0`, ""},
+		{"GET", "/tasks?q=_", "", "", "", 200, `"total": 0`, ""},
+		{"GET", "/tasks?due_before=2026-01-07", "", "", "", 200, "", "Write report"},
+		{"GET", "/tasks?priority=5&sort=-id", "", "", "", 200, "", "Call plumber,Write report"},
+		{"GET", "/tasks?sort=notes", "", "", "", 400, `"name": "sort"`, ""},
+		{"GET", "/tasks?stauts=done&limit=500", "", "", "", 400, `"name": "limit"`, ""},
+	})
+}
+
+func TestPagination(t *testing.T) {
+	runExchanges(t, fourTasks, []exchange{
+		{"GET", "/tasks?sort=title&limit=2", "", "", "", 200, `"total": 4`, "Book flights,Buy milk"},
+		{"GET", "/tasks?sort=title&limit=2", "", "", "", 200, `offset=2&sort=title>; rel="next"`, ""},
+		{"GET", "/tasks?sort=title&limit=2&offset=1", "", "", "", 200, `offset=0&sort=title>; rel="prev"`, "Buy milk,Call plumber"},
+		{"GET", "/tasks?sort=title&limit=2&offset=1", "", "", "", 200, `offset=3&sort=title>; rel="next"`, ""},
+		{"GET", "/tasks?sort=title&limit=2&offset=2", "", "", "", 200, `"items": [`, "Call plumber,Write report"},
+		{"GET", "/tasks?sort=title&limit=2&offset=4", "", "", "", 200, `"items": []`, ""},
+	})
+}
+
+func TestETags(t *testing.T) {
+	runExchanges(t, fourTasks, []exchange{
+		// Conditional GET
+		{"GET", "/tasks/1", "", "", "", 200, `Etag: "1-1"`, ""},
+		{"GET", "/tasks/1", "", `If-None-Match: "1-1"`, "", 304, "", ""},
+		{"GET", "/tasks/1", "", `If-None-Match: W/"1-1"`, "", 304, "", ""},
+		{"GET", "/tasks/1", "", `If-None-Match: "1-0", "9-9"`, "", 200, `"version": 1`, ""},
+
+		// Writes need a current If-Match
+		{"PUT", "/tasks/1", "", "", `{"title":"Buy oat milk","priority":2}`, 428, "/problems/missing-precondition", ""},
+		{"PUT", "/tasks/1", `"1-7"`, "", `{"title":"Buy oat milk","priority":2}`, 412, "/problems/edit-conflict", ""},
+		{"PUT", "/tasks/1", `"1-1"`, "", `{"title":"Buy oat milk","priority":2}`, 200, `Etag: "1-2"`, ""},
+		{"PUT", "/tasks/1", `"1-1"`, "", `{"title":"Buy soy milk","priority":2}`, 412, `now at \"1-2\"`, ""},
+		{"PATCH", "/tasks/1", `"1-0", "1-2"`, merge, `{"status":"done","due":"2026-02-01"}`, 200, `"due": "2026-02-01"`, ""},
+		{"PATCH", "/tasks/1", "*", merge, `{"priority":null}`, 200, `"priority": 3`, ""},
+		{"PATCH", "/tasks/1", "*", merge, `{"title":null}`, 422, "is required", ""},
+		{"PATCH", "/tasks/1", "*", "Content-Type: text/plain", `{}`, 415, "Accept-Patch: " + tasks.MergePatchContentType, ""},
+		{"GET", "/tasks/1", "", "", "", 200, `"version": 4`, ""},
+		{"DELETE", "/tasks/4", `W/"4-1"`, "", "", 412, "/problems/edit-conflict", ""}, // If-Match compares strongly
+		{"DELETE", "/tasks/4", `"4-1"`, "", "", 204, "", ""},
+		{"GET", "/tasks/4", "", "", "", 404, blank, ""},
+		{"DELETE", "/tasks/4", "*", "", "", 404, blank, ""},
+		{"POST", "/tasks", "", "", flights, 201, `"id": 5`, ""}, // IDs are never reused
+	})
+}
+
+func TestOtherRoutes(t *testing.T) {
+	runExchanges(t, fourTasks, []exchange{
+		{"GET", "/tasks/99", "", "", "", 404, blank, ""},
+		{"GET", "/tasks/abc", "", "", "", 404, blank, ""},
+		{"POST", "/tasks/1", "", "", "", 405, "Allow: GET, HEAD, PUT, PATCH, DELETE", ""},
+		{"GET", "/nowhere", "", "", "", 404, blank, ""},
+		{"GET", "/problems/edit-conflict", "", "", "", 200, "GET it again", ""},
+	})
+}
+
+// TestConcurrentPatch has ten clients that fetched version 1 of a task
+// patch it at the same time. Only one may win; without the version
+// check in the repository, several would pass the If-Match check and
+// the last write would silently erase the others.
+func TestConcurrentPatch(t *testing.T) {
+	forEachRepo(t, func(t *testing.T, repo tasks.Repository) {
+		srv := newServer(t, repo)
+		exchange{method: "POST", path: "/tasks", body: reportDoc, status: 201}.send(t, srv)
+
+		statuses := make([]int, 10)
+		var wg sync.WaitGroup
+		for i := range statuses {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				req, _ := http.NewRequest("PATCH", srv.URL+"/tasks/1", strings.NewReader(fmt.Sprintf(`{"notes":"edit %d"}`, i)))
+				req.Header.Set("Content-Type", tasks.MergePatchContentType)
+				req.Header.Set("If-Match", `"1-1"`)
+				if resp, err := srv.Client().Do(req); err == nil {
+					resp.Body.Close()
+					statuses[i] = resp.StatusCode
+				}
+			}()
+		}
+		wg.Wait()
+		slices.Sort(statuses)
+		if statuses[0] != 200 || statuses[1] != 412 || statuses[9] != 412 {
+			t.Errorf("statuses %v, want one 200 and nine 412", statuses)
+		}
+	})
+}
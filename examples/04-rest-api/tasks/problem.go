@@ -0,0 +1,90 @@
+package tasks
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// ProblemContentType is the media type of an RFC 7807 error response.
+const ProblemContentType = "application/problem+json"
+
+// Problem is an error response in the format of RFC 7807, "Problem
+// Details for HTTP APIs". Clients get one shape for every error and can
+// branch on Type, a URI that names the kind of problem, instead of
+// parsing messages.
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`  // the same for every problem of a type
+	Status   int    `json:"status"` // repeats the HTTP status
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"` // the path that failed
+
+	// InvalidParams is an extension member, named as in the RFC's own
+	// example, listing every invalid field or parameter.
+	InvalidParams []*FieldError `json:"invalid-params,omitempty"`
+}
+
+// problemType describes a kind of problem. The URIs are relative, so
+// they resolve against the API's own address, and GET on one returns
+// its Doc.
+type problemType struct {
+	URI    string
+	Title  string
+	Status int
+	Doc    string
+}
+
+// "about:blank" is the RFC's type for a problem that is nothing more
+// than its HTTP status; its title is the status text.
+var (
+	problemInvalidJSON = problemType{"/problems/invalid-json", "Request body is not valid JSON", http.StatusBadRequest,
+		"The body could not be decoded: it is not JSON, not a single object, or has a member of the wrong type."}
+	problemInvalidQuery = problemType{"/problems/invalid-query", "Invalid query parameters", http.StatusBadRequest,
+		"One or more query parameters are unknown or out of range; invalid-params lists them."}
+	problemValidation = problemType{"/problems/validation", "Task is not valid", http.StatusUnprocessableEntity,
+		"The body is JSON but breaks the rules for a task; invalid-params lists every problem."}
+	problemEditConflict = problemType{"/problems/edit-conflict", "Task has changed", http.StatusPreconditionFailed,
+		"If-Match does not match the task's current ETag: it changed since you fetched it. GET it again, reapply your change and retry."}
+	problemMissingPrecondition = problemType{"/problems/missing-precondition", "If-Match is required", http.StatusPreconditionRequired,
+		"Changing a task needs an If-Match header with the ETag from your last GET, or * to change it whatever its version."}
+)
+
+var problemTypes = []problemType{
+	problemInvalidJSON,
+	problemInvalidQuery,
+	problemValidation,
+	problemEditConflict,
+	problemMissingPrecondition,
+}
+
+// writeProblem sends a problem of type pt.
+func writeProblem(w http.ResponseWriter, r *http.Request, pt problemType, detail string, invalid []*FieldError) {
+	sendProblem(w, Problem{
+		Type:          pt.URI,
+		Title:         pt.Title,
+		Status:        pt.Status,
+		Detail:        detail,
+		Instance:      r.URL.Path,
+		InvalidParams: invalid,
+	})
+}
+
+// writeStatusProblem sends an "about:blank" problem for status.
+func writeStatusProblem(w http.ResponseWriter, r *http.Request, status int, detail string) {
+	sendProblem(w, Problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   detail,
+		Instance: r.URL.Path,
+	})
+}
+
+func sendProblem(w http.ResponseWriter, p Problem) {
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(p.Status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(p)
+}
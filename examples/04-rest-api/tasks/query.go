@@ -0,0 +1,155 @@
+package tasks
+
+import (
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang-learning-project/pkg/errs"
+)
+
+// Page sizes for List.
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+// SortKey is one field to order by.
+type SortKey struct {
+	Field string
+	Desc  bool
+}
+
+// SortFields lists the fields a Query can sort by.
+var SortFields = []string{"id", "title", "status", "priority", "due", "created_at", "updated_at"}
+
+// Query selects, orders and pages the tasks returned by List. The zero
+// value is not valid; start from ParseQuery, which fills in defaults.
+type Query struct {
+	// Filters; a zero value matches every task.
+	Statuses  []Status // status is any of these
+	Priority  int      // priority is exactly this
+	Search    string   // title or notes contain this, ignoring ASCII case
+	DueBefore string   // due on or before this date; tasks without one never match
+
+	// Sort is applied in order, then by ascending ID so that pages never
+	// overlap or skip a task.
+	Sort []SortKey
+
+	Limit  int
+	Offset int
+}
+
+// ParseQuery reads a Query from URL parameters:
+//
+//	status=todo,doing  priority=5  q=milk  due_before=2026-01-31
+//	sort=-priority,due  limit=20  offset=40
+//
+// A "-" before a sort field sorts it in descending order. Unknown
+// parameters are an error rather than silently ignored, so a typo such
+// as ?stauts=done does not return every task. Every problem is
+// reported, as with Input.Apply.
+func ParseQuery(v url.Values) (Query, error) {
+	q := Query{Limit: DefaultLimit}
+	var c errs.Collector
+
+	for name := range v {
+		switch name {
+		case "status", "priority", "q", "due_before", "sort", "limit", "offset":
+		default:
+			c.Add(&FieldError{name, "is not a known parameter"})
+		}
+	}
+
+	if s := v.Get("status"); s != "" {
+		for _, part := range strings.Split(s, ",") {
+			st := Status(part)
+			if !st.valid() {
+				c.Add(&FieldError{"status", "must be a list of todo, doing, done"})
+				break
+			}
+			q.Statuses = append(q.Statuses, st)
+		}
+	}
+	if s := v.Get("priority"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < MinPriority || n > MaxPriority {
+			c.Add(&FieldError{"priority", "must be a whole number from 1 to 5"})
+		}
+		q.Priority = n
+	}
+	q.Search = v.Get("q")
+	if s := v.Get("due_before"); s != "" {
+		if !validDate(s) {
+			c.Add(&FieldError{"due_before", "must be a date such as 2026-01-31"})
+		}
+		q.DueBefore = s
+	}
+
+	if s := v.Get("sort"); s != "" {
+		for _, part := range strings.Split(s, ",") {
+			key := SortKey{Field: part}
+			if rest, ok := strings.CutPrefix(part, "-"); ok {
+				key = SortKey{Field: rest, Desc: true}
+			}
+			if !slices.Contains(SortFields, key.Field) {
+				c.Add(&FieldError{"sort", "fields must be among " + strings.Join(SortFields, ", ")})
+				break
+			}
+			q.Sort = append(q.Sort, key)
+		}
+	}
+
+	if s := v.Get("limit"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 1 || n > MaxLimit {
+			c.Add(&FieldError{"limit", "must be a whole number from 1 to " + strconv.Itoa(MaxLimit)})
+		}
+		q.Limit = n
+	}
+	if s := v.Get("offset"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 0 {
+			c.Add(&FieldError{"offset", "must be a whole number, 0 or more"})
+		}
+		q.Offset = n
+	}
+
+	return q, c.Err()
+}
+
+// Values is the inverse of ParseQuery, for building the links to other
+// pages.
+func (q Query) Values() url.Values {
+	v := url.Values{}
+	if len(q.Statuses) > 0 {
+		parts := make([]string, len(q.Statuses))
+		for i, s := range q.Statuses {
+			parts[i] = string(s)
+		}
+		v.Set("status", strings.Join(parts, ","))
+	}
+	if q.Priority != 0 {
+		v.Set("priority", strconv.Itoa(q.Priority))
+	}
+	if q.Search != "" {
+		v.Set("q", q.Search)
+	}
+	if q.DueBefore != "" {
+		v.Set("due_before", q.DueBefore)
+	}
+	if len(q.Sort) > 0 {
+		parts := make([]string, len(q.Sort))
+		for i, k := range q.Sort {
+			parts[i] = k.Field
+			if k.Desc {
+				parts[i] = "-" + k.Field
+			}
+		}
+		v.Set("sort", strings.Join(parts, ","))
+	}
+	v.Set("limit", strconv.Itoa(q.Limit))
+	v.Set("offset", strconv.Itoa(q.Offset))
+	return v
+}
@@ -0,0 +1,93 @@
+package tasks_test
+
+import (
+	"net/url"
+	"reflect"
+	"slices"
+	"testing"
+
+	"golang-learning-project/examples/04-rest-api/tasks"
+)
+
+func parseValues(t *testing.T, query string) url.Values {
+	t.Helper()
+	v, err := url.ParseQuery(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		want  tasks.Query
+	}{
+		{"", tasks.Query{Limit: tasks.DefaultLimit}},
+		{"status=todo,doing&priority=5&q=milk&due_before=2026-01-31", tasks.Query{
+			Statuses: []tasks.Status{tasks.StatusTodo, tasks.StatusDoing}, Priority: 5,
+			Search: "milk", DueBefore: "2026-01-31", Limit: tasks.DefaultLimit,
+		}},
+		{"sort=-priority,due&limit=100&offset=40", tasks.Query{
+			Sort:  []tasks.SortKey{{Field: "priority", Desc: true}, {Field: "due"}},
+			Limit: tasks.MaxLimit, Offset: 40,
+		}},
+	}
+	for _, tt := range tests {
+		got, err := tasks.ParseQuery(parseValues(t, tt.query))
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseQuery(%q) = %+v, %v; want %+v", tt.query, got, err, tt.want)
+		}
+	}
+}
+
+// TestParseQueryErrors checks that every bad parameter is reported, and
+// that sort takes only the whitelisted fields.
+func TestParseQueryErrors(t *testing.T) {
+	tests := []struct {
+		query  string
+		fields []string // the names of the invalid parameters, sorted
+	}{
+		{"stauts=done", []string{"stauts"}},
+		{"status=later", []string{"status"}},
+		{"priority=6", []string{"priority"}},
+		{"due_before=soon", []string{"due_before"}},
+		{"sort=notes", []string{"sort"}},
+		{"sort=title%3BDROP%20TABLE%20tasks", []string{"sort"}},
+		{"sort=--title", []string{"sort"}},
+		{"limit=0", []string{"limit"}},
+		{"limit=101", []string{"limit"}},
+		{"offset=-1", []string{"offset"}},
+		{"stauts=done&limit=500&sort=x", []string{"limit", "sort", "stauts"}},
+	}
+	for _, tt := range tests {
+		_, err := tasks.ParseQuery(parseValues(t, tt.query))
+		var names []string
+		for _, fe := range tasks.FieldErrors(err) {
+			names = append(names, fe.Name)
+		}
+		slices.Sort(names)
+		if !slices.Equal(names, tt.fields) {
+			t.Errorf("ParseQuery(%q): invalid %v, want %v (%v)", tt.query, names, tt.fields, err)
+		}
+	}
+}
+
+// TestQueryValues checks that the links to other pages give back the
+// same query.
+func TestQueryValues(t *testing.T) {
+	for _, query := range []string{
+		"",
+		"status=done&priority=2&q=a%26b&due_before=2026-02-01",
+		"sort=-updated_at,title&limit=5&offset=10",
+	} {
+		q, err := tasks.ParseQuery(parseValues(t, query))
+		if err != nil {
+			t.Fatal(err)
+		}
+		again, err := tasks.ParseQuery(q.Values())
+		if err != nil || !reflect.DeepEqual(again, q) {
+			t.Errorf("ParseQuery(%q).Values() = %v, which parses as %+v, %v", query, q.Values(), again, err)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package tasks
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"golang-learning-project/pkg/errs"
+)
+
+// Repository stores tasks. The handlers only see this interface, so the
+// storage can change without touching them. Implementations are safe
+// for concurrent use.
+//
+// Errors match errs.ErrNotFound when there is no task with the ID, and
+// errs.ErrConflict when the version passed to Update or Delete is no
+// longer the task's current one: someone else changed it first.
+type Repository interface {
+	// List returns one page of the tasks matching q and how many tasks
+	// match in all.
+	List(ctx context.Context, q Query) (page []Task, total int, err error)
+	Get(ctx context.Context, id int64) (Task, error)
+	// Create stores a new task and returns it with its ID, version and
+	// timestamps set.
+	Create(ctx context.Context, t Task) (Task, error)
+	// Update replaces the stored task with t if the stored version is
+	// still version, and returns t with its new version.
+	Update(ctx context.Context, t Task, version int64) (Task, error)
+	// Delete removes the task if its version is still version.
+	Delete(ctx context.Context, id, version int64) error
+}
+
+// Both implementations satisfy the interface; the build fails if one
+// stops doing so.
+var (
+	_ Repository = (*MemoryRepository)(nil)
+	_ Repository = (*SQLiteRepository)(nil)
+)
+
+func notFound(id int64) error {
+	return errs.New(errs.ErrNotFound, "task %d", id)
+}
+
+func conflict(id, version int64) error {
+	return errs.New(errs.ErrConflict, "task %d is no longer at version %d", id, version)
+}
+
+// ========================================
+// IN MEMORY
+// ========================================
+
+// MemoryRepository keeps tasks in a map. It is lost when the program
+// exits, which is what a test or a demo wants.
+type MemoryRepository struct {
+	mu     sync.RWMutex
+	tasks  map[int64]Task
+	lastID int64
+	now    func() time.Time
+}
+
+// NewMemoryRepository returns an empty repository.
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{tasks: make(map[int64]Task), now: time.Now}
+}
+
+func (r *MemoryRepository) List(ctx context.Context, q Query) ([]Task, int, error) {
+	r.mu.RLock()
+	var matched []Task
+	for _, t := range r.tasks {
+		if q.matches(t) {
+			matched = append(matched, t)
+		}
+	}
+	r.mu.RUnlock()
+
+	slices.SortFunc(matched, q.compare)
+	total := len(matched)
+	start := min(q.Offset, total)
+	end := min(start+q.Limit, total)
+	return append([]Task{}, matched[start:end]...), total, nil // [] not null when empty
+}
+
+func (r *MemoryRepository) Get(ctx context.Context, id int64) (Task, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	t, ok := r.tasks[id]
+	if !ok {
+		return Task{}, notFound(id)
+	}
+	return t, nil
+}
+
+func (r *MemoryRepository) Create(ctx context.Context, t Task) (Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lastID++
+	now := r.now().UTC()
+	t.ID, t.Version, t.CreatedAt, t.UpdatedAt = r.lastID, 1, now, now
+	r.tasks[t.ID] = t
+	return t, nil
+}
+
+func (r *MemoryRepository) Update(ctx context.Context, t Task, version int64) (Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	old, ok := r.tasks[t.ID]
+	if !ok {
+		return Task{}, notFound(t.ID)
+	}
+	if old.Version != version {
+		return Task{}, conflict(t.ID, version)
+	}
+	t.Version, t.CreatedAt, t.UpdatedAt = old.Version+1, old.CreatedAt, r.now().UTC()
+	r.tasks[t.ID] = t
+	return t, nil
+}
+
+func (r *MemoryRepository) Delete(ctx context.Context, id, version int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	old, ok := r.tasks[id]
+	if !ok {
+		return notFound(id)
+	}
+	if old.Version != version {
+		return conflict(id, version)
+	}
+	delete(r.tasks, id)
+	return nil
+}
+
+// matches reports whether t passes every filter of q. The SQLite
+// repository expresses the same filters as a WHERE clause.
+func (q Query) matches(t Task) bool {
+	if len(q.Statuses) > 0 && !slices.Contains(q.Statuses, t.Status) {
+		return false
+	}
+	if q.Priority != 0 && t.Priority != q.Priority {
+		return false
+	}
+	if q.Search != "" && !containsFoldASCII(t.Title, q.Search) && !containsFoldASCII(t.Notes, q.Search) {
+		return false
+	}
+	if q.DueBefore != "" && (t.Due == "" || t.Due > q.DueBefore) {
+		return false
+	}
+	return true
+}
+
+// compare orders tasks by q.Sort, then by ID.
+func (q Query) compare(a, b Task) int {
+	for _, k := range q.Sort {
+		var c int
+		switch k.Field {
+		case "id":
+			c = cmp.Compare(a.ID, b.ID)
+		case "title":
+			c = strings.Compare(a.Title, b.Title)
+		case "status":
+			c = strings.Compare(string(a.Status), string(b.Status))
+		case "priority":
+			c = cmp.Compare(a.Priority, b.Priority)
+		case "due":
+			c = strings.Compare(a.Due, b.Due)
+		case "created_at":
+			c = a.CreatedAt.Compare(b.CreatedAt)
+		case "updated_at":
+			c = a.UpdatedAt.Compare(b.UpdatedAt)
+		}
+		if k.Desc {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(a.ID, b.ID)
+}
+
+// containsFoldASCII is strings.Contains ignoring the case of ASCII
+// letters only, which is what SQLite's LIKE does.
+func containsFoldASCII(s, substr string) bool {
+	return strings.Contains(lowerASCII(s), lowerASCII(substr))
+}
+
+func lowerASCII(s string) string {
+	return strings.Map(func(r rune) rune {
+		if 'A' <= r && r <= 'Z' {
+			return r + 'a' - 'A'
+		}
+		return r
+	}, s)
+}
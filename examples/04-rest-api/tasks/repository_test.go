@@ -0,0 +1,222 @@
+package tasks_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"golang-learning-project/examples/04-rest-api/tasks"
+	"golang-learning-project/pkg/errs"
+)
+
+// forEachRepo runs test once with each Repository, each time empty.
+// Both must give the same answers.
+func forEachRepo(t *testing.T, test func(t *testing.T, repo tasks.Repository)) {
+	t.Run("memory", func(t *testing.T) {
+		test(t, tasks.NewMemoryRepository())
+	})
+	t.Run("sqlite", func(t *testing.T) {
+		test(t, openSQLite(t))
+	})
+}
+
+func openSQLite(t *testing.T) *tasks.SQLiteRepository {
+	t.Helper()
+	db, err := tasks.OpenSQLite(":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func create(t *testing.T, repo tasks.Repository, list ...tasks.Task) {
+	t.Helper()
+	for _, task := range list {
+		if task.Status == "" {
+			task.Status = tasks.StatusTodo
+		}
+		if task.Priority == 0 {
+			task.Priority = tasks.DefaultPriority
+		}
+		if _, err := repo.Create(context.Background(), task); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func titlesOf(list []tasks.Task) string {
+	out := make([]string, len(list))
+	for i, task := range list {
+		out[i] = task.Title
+	}
+	return strings.Join(out, ",")
+}
+
+func TestRepositoryVersions(t *testing.T) {
+	forEachRepo(t, func(t *testing.T, repo tasks.Repository) {
+		ctx := context.Background()
+		created, err := repo.Create(ctx, tasks.Task{Title: "Buy milk", Status: tasks.StatusTodo, Priority: 2})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if created.ID != 1 || created.Version != 1 || created.ETag() != `"1-1"` || created.CreatedAt.IsZero() {
+			t.Errorf("Create = %+v, want ID 1 at version 1", created)
+		}
+
+		created.Title = "Buy oat milk"
+		updated, err := repo.Update(ctx, created, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if updated.Version != 2 || updated.ETag() != `"1-2"` || !updated.CreatedAt.Equal(created.CreatedAt) {
+			t.Errorf("Update = %+v, want version 2 with the same CreatedAt", updated)
+		}
+		if got, _ := repo.Get(ctx, 1); got.Title != "Buy oat milk" || got.Version != 2 {
+			t.Errorf("Get after Update = %+v", got)
+		}
+
+		tests := []struct {
+			name string
+			err  error
+			want error
+		}{
+			{"update at an old version", func() error { _, err := repo.Update(ctx, created, 1); return err }(), errs.ErrConflict},
+			{"delete at an old version", repo.Delete(ctx, 1, 1), errs.ErrConflict},
+			{"update a missing task", func() error { _, err := repo.Update(ctx, tasks.Task{ID: 9}, 1); return err }(), errs.ErrNotFound},
+			{"delete a missing task", repo.Delete(ctx, 9, 1), errs.ErrNotFound},
+			{"get a missing task", func() error { _, err := repo.Get(ctx, 9); return err }(), errs.ErrNotFound},
+			{"delete", repo.Delete(ctx, 1, 2), nil},
+			{"get a deleted task", func() error { _, err := repo.Get(ctx, 1); return err }(), errs.ErrNotFound},
+		}
+		for _, tt := range tests {
+			if !errors.Is(tt.err, tt.want) {
+				t.Errorf("%s: got %v, want %v", tt.name, tt.err, tt.want)
+			}
+		}
+
+		// IDs are never reused
+		if again, _ := repo.Create(ctx, tasks.Task{Title: "Again", Status: tasks.StatusTodo, Priority: 1}); again.ID != 2 {
+			t.Errorf("ID after a delete = %d, want 2", again.ID)
+		}
+	})
+}
+
+// TestRepositoryConcurrentUpdate has ten writers update the same version
+// at once. Only one may win; the others must get a conflict rather than
+// silently overwrite it.
+func TestRepositoryConcurrentUpdate(t *testing.T) {
+	forEachRepo(t, func(t *testing.T, repo tasks.Repository) {
+		ctx := context.Background()
+		task, err := repo.Create(ctx, tasks.Task{Title: "Write report", Status: tasks.StatusTodo, Priority: 3})
+		if err != nil {
+			t.Fatal(err)
+		}
+		results := make([]error, 10)
+		var wg sync.WaitGroup
+		for i := range results {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				edit := task
+				edit.Notes = strings.Repeat("x", i)
+				_, results[i] = repo.Update(ctx, edit, task.Version)
+			}()
+		}
+		wg.Wait()
+		won := 0
+		for _, err := range results {
+			switch {
+			case err == nil:
+				won++
+			case !errors.Is(err, errs.ErrConflict):
+				t.Errorf("Update: %v, want a conflict", err)
+			}
+		}
+		if won != 1 {
+			t.Errorf("%d updates succeeded, want 1", won)
+		}
+	})
+}
+
+func TestRepositoryList(t *testing.T) {
+	seed := []tasks.Task{
+		{Title: "Buy milk", Status: tasks.StatusTodo, Priority: 2, Due: "2026-01-10"},
+		{Title: "Write report", Notes: "Quarterly numbers", Status: tasks.StatusDoing, Priority: 5, Due: "2026-01-05"},
+		{Title: "Call plumber", Status: tasks.StatusTodo, Priority: 5},
+		{Title: "Book flights", Status: tasks.StatusDone, Priority: 1},
+		{Title: "Sell 100% of shares", Priority: 4},
+		{Title: "Rename a_b.txt", Notes: `C:\tmp`, Priority: 4},
+		{Title: "Rename axb.txt", Notes: "Café", Priority: 4},
+	}
+	tests := []struct {
+		name  string
+		query string
+		want  string
+		total int
+	}{
+		{"everything by ID", "", "Buy milk,Write report,Call plumber,Book flights,Sell 100% of shares,Rename a_b.txt,Rename axb.txt", 7},
+		{"statuses", "status=doing,done", "Write report,Book flights", 2},
+		{"priority", "priority=5&sort=-id", "Call plumber,Write report", 2},
+		{"due before", "due_before=2026-01-07", "Write report", 1},
+		{"due sorts empty first", "sort=due&limit=3", "Call plumber,Book flights,Sell 100% of shares", 7},
+		{"descending then ID", "sort=-priority", "Write report,Call plumber,Sell 100% of shares,Rename a_b.txt,Rename axb.txt,Buy milk,Book flights", 7},
+		{"two keys", "sort=status,-title&status=todo", "Sell 100% of shares,Rename axb.txt,Rename a_b.txt,Call plumber,Buy milk", 5},
+
+		// Pages never overlap or skip a task, even among equal keys
+		{"first page", "sort=priority&limit=3", "Book flights,Buy milk,Sell 100% of shares", 7},
+		{"second page", "sort=priority&limit=3&offset=3", "Rename a_b.txt,Rename axb.txt,Write report", 7},
+		{"last page", "sort=priority&limit=3&offset=6", "Call plumber", 7},
+		{"past the end", "limit=3&offset=9", "", 7},
+
+		// Search ignores ASCII case, looks at the notes too, and takes
+		// the LIKE wildcards literally
+		{"search", "q=NUMBERS", "Write report", 1},
+		{"percent", "q=%25", "Sell 100% of shares", 1},
+		{"underscore", "q=A_B", "Rename a_b.txt", 1},
+		{"backslash", `q=\`, `Rename a_b.txt`, 1},
+		{"only ASCII folds", "q=CAFÉ", "", 0},
+		{"search and filter", "q=rename&priority=4&sort=-title", "Rename axb.txt,Rename a_b.txt", 2},
+	}
+	forEachRepo(t, func(t *testing.T, repo tasks.Repository) {
+		create(t, repo, seed...)
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				q, err := tasks.ParseQuery(parseValues(t, tt.query))
+				if err != nil {
+					t.Fatal(err)
+				}
+				page, total, err := repo.List(context.Background(), q)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got := titlesOf(page); got != tt.want || total != tt.total {
+					t.Errorf("List(%s) = %q, total %d; want %q, total %d", tt.query, got, total, tt.want, tt.total)
+				}
+				if page == nil {
+					t.Error("List returned a nil page; the JSON would be null")
+				}
+			})
+		}
+	})
+}
+
+// TestSQLiteSortWhitelist checks that a sort field which did not come
+// through ParseQuery never reaches the SQL.
+func TestSQLiteSortWhitelist(t *testing.T) {
+	db := openSQLite(t)
+	create(t, db, tasks.Task{Title: "Buy milk"})
+	ctx := context.Background()
+	for _, field := range []string{"title; DROP TABLE tasks", "notes", "(SELECT 1)"} {
+		q := tasks.Query{Sort: []tasks.SortKey{{Field: field}}, Limit: 10}
+		if _, _, err := db.List(ctx, q); err == nil {
+			t.Errorf("List sorted by %q: no error", field)
+		}
+	}
+	page, _, err := db.List(ctx, tasks.Query{Limit: 10})
+	if err != nil || titlesOf(page) != "Buy milk" {
+		t.Errorf("List after the bad sorts = %q, %v", titlesOf(page), err)
+	}
+}
@@ -0,0 +1,242 @@
+package tasks
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// timeFormat stores times as fixed-width UTC text, so that sorting the
+// column as text sorts it by time. time.RFC3339Nano drops trailing zeros
+// and would not.
+const timeFormat = "2006-01-02T15:04:05.000000000Z"
+
+const schema = `CREATE TABLE IF NOT EXISTS tasks (
+	id         INTEGER PRIMARY KEY AUTOINCREMENT, -- never reuses the ID of a deleted task
+	title      TEXT    NOT NULL,
+	notes      TEXT    NOT NULL,
+	status     TEXT    NOT NULL CHECK (status IN ('todo', 'doing', 'done')),
+	priority   INTEGER NOT NULL,
+	due        TEXT    NOT NULL, -- '' for none
+	version    INTEGER NOT NULL,
+	created_at TEXT    NOT NULL,
+	updated_at TEXT    NOT NULL
+);
+CREATE INDEX IF NOT EXISTS tasks_status ON tasks (status);`
+
+// columns lists the columns in the order scanTask reads them.
+const columns = `id, title, notes, status, priority, due, version, created_at, updated_at`
+
+// sortColumns maps the sort fields of a Query to columns. Only these
+// names ever reach the ORDER BY clause; a value taken from the request
+// must never be pasted into SQL.
+var sortColumns = map[string]string{
+	"id":         "id",
+	"title":      "title",
+	"status":     "status",
+	"priority":   "priority",
+	"due":        "due",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+}
+
+// SQLiteRepository stores tasks in a SQLite database through the
+// pure-Go modernc.org/sqlite driver.
+type SQLiteRepository struct {
+	db  *sql.DB
+	now func() time.Time
+}
+
+// OpenSQLite opens or creates the database at path and creates the
+// tasks table if needed. The path ":memory:" gives a private database
+// that disappears on Close.
+func OpenSQLite(path string) (*SQLiteRepository, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+	// SQLite allows one writer at a time, and every connection to
+	// ":memory:" would get a database of its own.
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("create schema in %s: %w", path, err)
+	}
+	return &SQLiteRepository{db: db, now: time.Now}, nil
+}
+
+// Close closes the database.
+func (r *SQLiteRepository) Close() error {
+	return r.db.Close()
+}
+
+func (r *SQLiteRepository) List(ctx context.Context, q Query) ([]Task, int, error) {
+	where, args := q.where()
+
+	var total int
+	err := r.db.QueryRowContext(ctx, `SELECT count(*) FROM tasks`+where, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	order := make([]string, 0, len(q.Sort)+1)
+	for _, k := range q.Sort {
+		col, ok := sortColumns[k.Field]
+		if !ok {
+			return nil, 0, fmt.Errorf("sort by %q: unknown field", k.Field)
+		}
+		if k.Desc {
+			col += " DESC"
+		}
+		order = append(order, col)
+	}
+	order = append(order, "id")
+
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+columns+` FROM tasks`+where+` ORDER BY `+strings.Join(order, ", ")+` LIMIT ? OFFSET ?`,
+		append(args, q.Limit, q.Offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	page := []Task{}
+	for rows.Next() {
+		t, err := scanTask(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		page = append(page, t)
+	}
+	return page, total, rows.Err()
+}
+
+// where builds the WHERE clause for q's filters, with a ? placeholder
+// for every value.
+func (q Query) where() (string, []any) {
+	var conds []string
+	var args []any
+	if len(q.Statuses) > 0 {
+		marks := strings.TrimSuffix(strings.Repeat("?, ", len(q.Statuses)), ", ")
+		conds = append(conds, "status IN ("+marks+")")
+		for _, s := range q.Statuses {
+			args = append(args, string(s))
+		}
+	}
+	if q.Priority != 0 {
+		conds = append(conds, "priority = ?")
+		args = append(args, q.Priority)
+	}
+	if q.Search != "" {
+		// % and _ are wildcards in LIKE; escape them to match literally
+		pattern := "%" + strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(q.Search) + "%"
+		conds = append(conds, `(title LIKE ? ESCAPE '\' OR notes LIKE ? ESCAPE '\')`)
+		args = append(args, pattern, pattern)
+	}
+	if q.DueBefore != "" {
+		conds = append(conds, "due != '' AND due <= ?")
+		args = append(args, q.DueBefore)
+	}
+	if len(conds) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conds, " AND "), args
+}
+
+func (r *SQLiteRepository) Get(ctx context.Context, id int64) (Task, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+columns+` FROM tasks WHERE id = ?`, id)
+	t, err := scanTask(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Task{}, notFound(id)
+	}
+	return t, err
+}
+
+func (r *SQLiteRepository) Create(ctx context.Context, t Task) (Task, error) {
+	now := r.now().UTC()
+	t.Version, t.CreatedAt, t.UpdatedAt = 1, now, now
+	res, err := r.db.ExecContext(ctx,
+		`INSERT INTO tasks (title, notes, status, priority, due, version, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		t.Title, t.Notes, string(t.Status), t.Priority, t.Due, t.Version,
+		now.Format(timeFormat), now.Format(timeFormat))
+	if err != nil {
+		return Task{}, err
+	}
+	if t.ID, err = res.LastInsertId(); err != nil {
+		return Task{}, err
+	}
+	return t, nil
+}
+
+// Update checks the version in the UPDATE statement itself, so no other
+// writer can slip in between the check and the write.
+func (r *SQLiteRepository) Update(ctx context.Context, t Task, version int64) (Task, error) {
+	now := r.now().UTC()
+	res, err := r.db.ExecContext(ctx,
+		`UPDATE tasks SET title = ?, notes = ?, status = ?, priority = ?, due = ?,
+			version = version + 1, updated_at = ?
+		WHERE id = ? AND version = ?`,
+		t.Title, t.Notes, string(t.Status), t.Priority, t.Due, now.Format(timeFormat),
+		t.ID, version)
+	if err != nil {
+		return Task{}, err
+	}
+	if err := r.checkChanged(ctx, res, t.ID, version); err != nil {
+		return Task{}, err
+	}
+	return r.Get(ctx, t.ID)
+}
+
+func (r *SQLiteRepository) Delete(ctx context.Context, id, version int64) error {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM tasks WHERE id = ? AND version = ?`, id, version)
+	if err != nil {
+		return err
+	}
+	return r.checkChanged(ctx, res, id, version)
+}
+
+// checkChanged turns a statement that changed no rows into the reason:
+// the task is gone, or it has another version.
+func (r *SQLiteRepository) checkChanged(ctx context.Context, res sql.Result, id, version int64) error {
+	n, err := res.RowsAffected()
+	if err != nil || n > 0 {
+		return err
+	}
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM tasks WHERE id = ?)`, id).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return notFound(id)
+	}
+	return conflict(id, version)
+}
+
+// scanner is the Scan method shared by *sql.Row and *sql.Rows.
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanTask(s scanner) (Task, error) {
+	var t Task
+	var status, created, updated string
+	err := s.Scan(&t.ID, &t.Title, &t.Notes, &status, &t.Priority, &t.Due, &t.Version, &created, &updated)
+	if err != nil {
+		return Task{}, err
+	}
+	t.Status = Status(status)
+	if t.CreatedAt, err = time.Parse(timeFormat, created); err != nil {
+		return Task{}, err
+	}
+	if t.UpdatedAt, err = time.Parse(timeFormat, updated); err != nil {
+		return Task{}, err
+	}
+	return t, nil
+}
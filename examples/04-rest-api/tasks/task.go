@@ -0,0 +1,257 @@
+// Package tasks is the to-do list behind the REST API example: the Task
+// resource and its validation, a Repository with in-memory and SQLite
+// implementations, and the HTTP handlers that serve it as JSON.
+package tasks
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang-learning-project/pkg/errs"
+)
+
+// Status is how far along a task is.
+type Status string
+
+const (
+	StatusTodo  Status = "todo"
+	StatusDoing Status = "doing"
+	StatusDone  Status = "done"
+)
+
+func (s Status) valid() bool {
+	switch s {
+	case StatusTodo, StatusDoing, StatusDone:
+		return true
+	}
+	return false
+}
+
+// Limits and defaults of the writable fields.
+const (
+	MaxTitle        = 200  // characters
+	MaxNotes        = 2000 // characters
+	MinPriority     = 1
+	MaxPriority     = 5
+	DefaultPriority = 3
+)
+
+// Task is the resource the API serves.
+//
+// Version is 1 when the task is created and grows by one with every
+// change; the task's ETag is made from it. Due is a date such as
+// "2026-01-31", or "" for none: dates in that form sort correctly as
+// strings, in Go and in SQLite alike.
+type Task struct {
+	ID        int64     `json:"id"`
+	Title     string    `json:"title"`
+	Notes     string    `json:"notes"`
+	Status    Status    `json:"status"`
+	Priority  int       `json:"priority"`
+	Due       string    `json:"due,omitempty"`
+	Version   int64     `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ETag returns the entity tag of the task's current version. It is a
+// strong tag: two representations with the same tag are byte for byte
+// the same.
+func (t Task) ETag() string {
+	return fmt.Sprintf(`"%d-%d"`, t.ID, t.Version)
+}
+
+// ========================================
+// REQUEST BODIES
+// ========================================
+
+// Field is one member of a request body. Set is false when the member
+// is absent and Null is true when it is JSON null: a partial update
+// needs both to tell "leave it alone" from "clear it".
+type Field[T any] struct {
+	Value T
+	Set   bool
+	Null  bool
+}
+
+func (f *Field[T]) decode(raw json.RawMessage) error {
+	f.Set = true
+	if string(raw) == "null" {
+		f.Null = true
+		return nil
+	}
+	return json.Unmarshal(raw, &f.Value)
+}
+
+// describe names the JSON type a Field holds, for error messages.
+func (f *Field[T]) describe() string {
+	if reflect.TypeFor[T]().Kind() == reflect.Int {
+		return "a whole number"
+	}
+	return "a string"
+}
+
+// Input is the body of a request that creates or changes a task.
+//
+// The read-only members of Task are accepted and ignored, so a client
+// can send back a task it fetched after changing some fields. Any other
+// member is an error, so a misspelt one is not silently dropped.
+type Input struct {
+	Title    Field[string]
+	Notes    Field[string]
+	Status   Field[Status]
+	Priority Field[int]
+	Due      Field[string]
+}
+
+// UnmarshalJSON decodes the members one at a time, so that every
+// unknown member and every member of the wrong type is reported by
+// name: the error is an *errs.MultiError of *FieldError.
+func (in *Input) UnmarshalJSON(b []byte) error {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(b, &members); err != nil {
+		return err
+	}
+	if members == nil {
+		return errors.New("body must be a JSON object, not null")
+	}
+
+	fields := map[string]interface {
+		decode(json.RawMessage) error
+		describe() string
+	}{
+		"title":    &in.Title,
+		"notes":    &in.Notes,
+		"status":   &in.Status,
+		"priority": &in.Priority,
+		"due":      &in.Due,
+	}
+	var c errs.Collector
+	for _, name := range slices.Sorted(maps.Keys(members)) {
+		switch name {
+		case "id", "version", "created_at", "updated_at":
+			continue
+		}
+		f, ok := fields[name]
+		if !ok {
+			c.Add(&FieldError{name, "is not a member of a task"})
+			continue
+		}
+		if err := f.decode(members[name]); err != nil {
+			c.Add(&FieldError{name, "must be " + f.describe()})
+		}
+	}
+	return c.Err()
+}
+
+// FieldError is a problem with one member of a request body or one
+// query parameter. It matches errs.ErrInvalidArgument.
+type FieldError struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+func (e *FieldError) Error() string { return e.Name + ": " + e.Reason }
+
+func (e *FieldError) Unwrap() error { return errs.ErrInvalidArgument }
+
+// FieldErrors returns every *FieldError in err, which may be a single
+// one or an *errs.MultiError of them.
+func FieldErrors(err error) []*FieldError {
+	var list []*FieldError
+	var walk func(error)
+	walk = func(err error) {
+		switch e := err.(type) {
+		case *FieldError:
+			list = append(list, e)
+		case *errs.MultiError:
+			for _, inner := range e.Errors() {
+				walk(inner)
+			}
+		}
+	}
+	walk(err)
+	return list
+}
+
+// Apply validates in and writes it into t.
+//
+// With replace set, as for a create or a full replacement, an absent
+// member takes its default. Otherwise, as for a partial update, it
+// leaves t's field alone. A null member always takes the default; the
+// title has none, so it must not be null.
+//
+// Every problem is reported, not just the first: the error is an
+// *errs.MultiError of *FieldError. On error t is left half updated, so
+// pass a copy.
+func (in *Input) Apply(t *Task, replace bool) error {
+	var c errs.Collector
+
+	switch f := in.Title; {
+	case f.Null || !f.Set && replace:
+		c.Add(&FieldError{"title", "is required"})
+	case f.Set:
+		title := strings.TrimSpace(f.Value)
+		switch {
+		case title == "":
+			c.Add(&FieldError{"title", "must not be blank"})
+		case utf8.RuneCountInString(title) > MaxTitle:
+			c.Add(&FieldError{"title", fmt.Sprintf("must be at most %d characters", MaxTitle)})
+		}
+		t.Title = title
+	}
+
+	switch f := in.Notes; {
+	case f.Null || !f.Set && replace:
+		t.Notes = ""
+	case f.Set:
+		if utf8.RuneCountInString(f.Value) > MaxNotes {
+			c.Add(&FieldError{"notes", fmt.Sprintf("must be at most %d characters", MaxNotes)})
+		}
+		t.Notes = f.Value
+	}
+
+	switch f := in.Status; {
+	case f.Null || !f.Set && replace:
+		t.Status = StatusTodo
+	case f.Set:
+		if !f.Value.valid() {
+			c.Add(&FieldError{"status", "must be one of todo, doing, done"})
+		}
+		t.Status = f.Value
+	}
+
+	switch f := in.Priority; {
+	case f.Null || !f.Set && replace:
+		t.Priority = DefaultPriority
+	case f.Set:
+		if f.Value < MinPriority || f.Value > MaxPriority {
+			c.Add(&FieldError{"priority", fmt.Sprintf("must be between %d and %d", MinPriority, MaxPriority)})
+		}
+		t.Priority = f.Value
+	}
+
+	switch f := in.Due; {
+	case f.Null || !f.Set && replace:
+		t.Due = ""
+	case f.Set:
+		if !validDate(f.Value) {
+			c.Add(&FieldError{"due", "must be a date such as 2026-01-31"})
+		}
+		t.Due = f.Value
+	}
+
+	return c.Err()
+}
+
+func validDate(s string) bool {
+	_, err := time.Parse(time.DateOnly, s)
+	return err == nil
+}
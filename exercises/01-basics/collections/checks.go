@@ -0,0 +1,112 @@
+//go:build kata
+
+package collections
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"testing"
+)
+
+// recoverStub turns a panic, such as an unimplemented stub, into a test
+// failure so the remaining checks still run.
+func recoverStub(t *testing.T, call string) {
+	if r := recover(); r != nil {
+		t.Errorf("%s panicked: %v", call, r)
+	}
+}
+
+func TestRemoveAt(t *testing.T) {
+	tests := []struct {
+		in   []string
+		i    int
+		want []string
+	}{
+		{[]string{"red", "green", "blue", "yellow"}, 2, []string{"red", "green", "yellow"}},
+		{[]string{"red", "green"}, 0, []string{"green"}},
+		{[]string{"red", "green"}, 1, []string{"red"}},
+		{[]string{"only"}, 0, []string{}},
+	}
+	for _, tt := range tests {
+		func() {
+			defer recoverStub(t, fmt.Sprintf("removeAt(%q, %d)", tt.in, tt.i))
+			in := slices.Clone(tt.in)
+			got := removeAt(in, tt.i)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("removeAt(%q, %d) = %q; want %q", tt.in, tt.i, got, tt.want)
+			}
+			if !slices.Equal(in, tt.in) {
+				t.Errorf("removeAt(%q, %d) changed its input to %q", tt.in, tt.i, in)
+			}
+		}()
+	}
+}
+
+func TestWordFrequency(t *testing.T) {
+	tests := []struct {
+		in   []string
+		want map[string]int
+	}{
+		{
+			[]string{"apple", "banana", "apple", "cherry", "banana", "apple"},
+			map[string]int{"apple": 3, "banana": 2, "cherry": 1},
+		},
+		{[]string{"go"}, map[string]int{"go": 1}},
+		{nil, map[string]int{}},
+	}
+	for _, tt := range tests {
+		func() {
+			defer recoverStub(t, fmt.Sprintf("wordFrequency(%q)", tt.in))
+			got := wordFrequency(tt.in)
+			if got == nil {
+				t.Errorf("wordFrequency(%q) = nil; want an empty map", tt.in)
+				return
+			}
+			if !maps.Equal(got, tt.want) {
+				t.Errorf("wordFrequency(%q) = %v; want %v", tt.in, got, tt.want)
+			}
+		}()
+	}
+}
+
+func TestSortedKeys(t *testing.T) {
+	tests := []struct {
+		in   map[string]string
+		want []string
+	}{
+		{
+			map[string]string{"USA": "Washington DC", "UK": "London", "France": "Paris", "Germany": "Berlin"},
+			[]string{"France", "Germany", "UK", "USA"},
+		},
+		{map[string]string{}, []string{}},
+	}
+	for _, tt := range tests {
+		func() {
+			defer recoverStub(t, fmt.Sprintf("sortedKeys(%v)", tt.in))
+			if got := sortedKeys(tt.in); !slices.Equal(got, tt.want) {
+				t.Errorf("sortedKeys(%v) = %q; want %q", tt.in, got, tt.want)
+			}
+		}()
+	}
+}
+
+func TestTranspose(t *testing.T) {
+	tests := []struct {
+		in   [][]int
+		want [][]int
+	}{
+		{[][]int{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}}, [][]int{{1, 4, 7}, {2, 5, 8}, {3, 6, 9}}},
+		{[][]int{{1, 2, 3}}, [][]int{{1}, {2}, {3}}},
+		{[][]int{{1}, {2}}, [][]int{{1, 2}}},
+	}
+	for _, tt := range tests {
+		func() {
+			defer recoverStub(t, fmt.Sprintf("transpose(%v)", tt.in))
+			got := transpose(tt.in)
+			if !slices.EqualFunc(got, tt.want, slices.Equal) {
+				t.Errorf("transpose(%v) = %v; want %v", tt.in, got, tt.want)
+			}
+		}()
+	}
+}
@@ -0,0 +1,30 @@
+// Package collections is the exercise for examples/01-basics/collections.
+//
+// Implement each function below, replacing the panic, then check your
+// work with:
+//
+//	go run ./cmd/demo exercise collections
+package collections
+
+// removeAt returns a new slice with the element at index i removed. Unlike
+// append(s[:i], s[i+1:]...), it must leave s itself unchanged.
+func removeAt(s []string, i int) []string {
+	panic("not implemented")
+}
+
+// wordFrequency counts how often each word occurs in words.
+func wordFrequency(words []string) map[string]int {
+	panic("not implemented")
+}
+
+// sortedKeys returns the keys of m in ascending order, so that printing
+// a map's contents no longer depends on iteration order.
+func sortedKeys(m map[string]string) []string {
+	panic("not implemented")
+}
+
+// transpose returns the transpose of a rectangular matrix: row i of the
+// result is column i of m.
+func transpose(m [][]int) [][]int {
+	panic("not implemented")
+}
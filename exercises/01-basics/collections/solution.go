@@ -0,0 +1,46 @@
+//go:build ignore
+
+// Reference solutions, revealed one function at a time by
+// `go run ./cmd/demo hint --solution`. The ignore build tag keeps them
+// out of the build so they never satisfy the hidden tests by accident.
+
+package collections
+
+import "sort"
+
+func removeAt(s []string, i int) []string {
+	result := make([]string, 0, len(s)-1)
+	result = append(result, s[:i]...)
+	return append(result, s[i+1:]...)
+}
+
+func wordFrequency(words []string) map[string]int {
+	frequency := make(map[string]int)
+	for _, w := range words {
+		frequency[w]++
+	}
+	return frequency
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func transpose(m [][]int) [][]int {
+	if len(m) == 0 {
+		return nil
+	}
+	result := make([][]int, len(m[0]))
+	for i := range result {
+		result[i] = make([]int, len(m))
+		for j := range m {
+			result[i][j] = m[j][i]
+		}
+	}
+	return result
+}
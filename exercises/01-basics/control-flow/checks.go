@@ -0,0 +1,100 @@
+//go:build kata
+
+package controlflow
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+// recoverStub turns a panic, such as an unimplemented stub, into a test
+// failure so the remaining checks still run.
+func recoverStub(t *testing.T, call string) {
+	if r := recover(); r != nil {
+		t.Errorf("%s panicked: %v", call, r)
+	}
+}
+
+func TestGrade(t *testing.T) {
+	tests := []struct {
+		score int
+		want  string
+	}{
+		{100, "A"},
+		{90, "A"},
+		{89, "B"},
+		{80, "B"},
+		{79, "C"},
+		{0, "C"},
+	}
+	for _, tt := range tests {
+		func() {
+			defer recoverStub(t, fmt.Sprintf("grade(%d)", tt.score))
+			if got := grade(tt.score); got != tt.want {
+				t.Errorf("grade(%d) = %q; want %q", tt.score, got, tt.want)
+			}
+		}()
+	}
+}
+
+func TestDescribeDay(t *testing.T) {
+	tests := []struct {
+		day, want string
+	}{
+		{"Monday", "Start of the work week"},
+		{"Tuesday", "Middle of the week"},
+		{"Thursday", "Middle of the week"},
+		{"Friday", "TGIF!"},
+		{"Sunday", "Weekend!"},
+		{"Funday", "Invalid day"},
+	}
+	for _, tt := range tests {
+		func() {
+			defer recoverStub(t, fmt.Sprintf("describeDay(%q)", tt.day))
+			if got := describeDay(tt.day); got != tt.want {
+				t.Errorf("describeDay(%q) = %q; want %q", tt.day, got, tt.want)
+			}
+		}()
+	}
+}
+
+func TestDescribeValue(t *testing.T) {
+	tests := []struct {
+		value any
+		want  string
+	}{
+		{"Hello", "String: Hello"},
+		{42, "Integer: 42"},
+		{true, "Boolean: true"},
+		{3.5, "Unknown type: float64"},
+		{[]int{1}, "Unknown type: []int"},
+	}
+	for _, tt := range tests {
+		func() {
+			defer recoverStub(t, fmt.Sprintf("describeValue(%#v)", tt.value))
+			if got := describeValue(tt.value); got != tt.want {
+				t.Errorf("describeValue(%#v) = %q; want %q", tt.value, got, tt.want)
+			}
+		}()
+	}
+}
+
+func TestFizzBuzz(t *testing.T) {
+	tests := []struct {
+		n    int
+		want []string
+	}{
+		{0, []string{}},
+		{5, []string{"1", "2", "Fizz", "4", "Buzz"}},
+		{15, []string{"1", "2", "Fizz", "4", "Buzz", "Fizz", "7", "8", "Fizz", "Buzz", "11", "Fizz", "13", "14", "FizzBuzz"}},
+	}
+	for _, tt := range tests {
+		func() {
+			defer recoverStub(t, fmt.Sprintf("fizzBuzz(%d)", tt.n))
+			if got := fizzBuzz(tt.n); !slices.Equal(got, tt.want) {
+				t.Errorf("fizzBuzz(%d) = %q; want %q", tt.n, got, tt.want)
+			}
+		}()
+	}
+}
@@ -0,0 +1,33 @@
+// Package controlflow is the exercise for examples/01-basics/control-flow.
+//
+// Implement each function below, replacing the panic, then check your
+// work with:
+//
+//	go run ./cmd/demo exercise control-flow
+package controlflow
+
+// grade returns "A" for scores of 90 and above, "B" for 80 and above and
+// "C" otherwise.
+func grade(score int) string {
+	panic("not implemented")
+}
+
+// describeDay returns "Start of the work week" for Monday, "Middle of the
+// week" for Tuesday to Thursday, "TGIF!" for Friday, "Weekend!" for
+// Saturday and Sunday and "Invalid day" for anything else.
+func describeDay(day string) string {
+	panic("not implemented")
+}
+
+// describeValue returns "String: <v>", "Integer: <v>" or "Boolean: <v>"
+// using a type switch, and "Unknown type: <type>" for anything else.
+func describeValue(value any) string {
+	panic("not implemented")
+}
+
+// fizzBuzz returns the numbers 1 to n as strings, with multiples of 3
+// replaced by "Fizz", multiples of 5 by "Buzz" and multiples of both by
+// "FizzBuzz".
+func fizzBuzz(n int) []string {
+	panic("not implemented")
+}
@@ -0,0 +1,66 @@
+//go:build ignore
+
+// Reference solutions, revealed one function at a time by
+// `go run ./cmd/demo hint --solution`. The ignore build tag keeps them
+// out of the build so they never satisfy the hidden tests by accident.
+
+package controlflow
+
+import (
+	"fmt"
+	"strconv"
+)
+
+func grade(score int) string {
+	if score >= 90 {
+		return "A"
+	} else if score >= 80 {
+		return "B"
+	}
+	return "C"
+}
+
+func describeDay(day string) string {
+	switch day {
+	case "Monday":
+		return "Start of the work week"
+	case "Tuesday", "Wednesday", "Thursday":
+		return "Middle of the week"
+	case "Friday":
+		return "TGIF!"
+	case "Saturday", "Sunday":
+		return "Weekend!"
+	default:
+		return "Invalid day"
+	}
+}
+
+func describeValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return fmt.Sprintf("String: %s", v)
+	case int:
+		return fmt.Sprintf("Integer: %d", v)
+	case bool:
+		return fmt.Sprintf("Boolean: %t", v)
+	default:
+		return fmt.Sprintf("Unknown type: %T", v)
+	}
+}
+
+func fizzBuzz(n int) []string {
+	result := make([]string, 0, n)
+	for i := 1; i <= n; i++ {
+		switch {
+		case i%15 == 0:
+			result = append(result, "FizzBuzz")
+		case i%3 == 0:
+			result = append(result, "Fizz")
+		case i%5 == 0:
+			result = append(result, "Buzz")
+		default:
+			result = append(result, strconv.Itoa(i))
+		}
+	}
+	return result
+}
@@ -0,0 +1,167 @@
+//go:build kata
+
+package functions
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+// recoverStub turns a panic, such as an unimplemented stub, into a test
+// failure so the remaining checks still run.
+func recoverStub(t *testing.T, call string) {
+	if r := recover(); r != nil {
+		t.Errorf("%s panicked: %v", call, r)
+	}
+}
+
+func TestDivide(t *testing.T) {
+	tests := []struct {
+		a, b    float64
+		want    float64
+		wantErr bool
+	}{
+		{10, 2, 5, false},
+		{7, 2, 3.5, false},
+		{-9, 3, -3, false},
+		{0, 5, 0, false},
+		{10, 0, 0, true},
+	}
+	for _, tt := range tests {
+		func() {
+			defer recoverStub(t, fmt.Sprintf("divide(%v, %v)", tt.a, tt.b))
+			got, err := divide(tt.a, tt.b)
+			switch {
+			case tt.wantErr && err == nil:
+				t.Errorf("divide(%v, %v) = %v, <nil>; want an error", tt.a, tt.b, got)
+			case !tt.wantErr && err != nil:
+				t.Errorf("divide(%v, %v) returned error %q; want %v", tt.a, tt.b, err, tt.want)
+			case !tt.wantErr && got != tt.want:
+				t.Errorf("divide(%v, %v) = %v; want %v", tt.a, tt.b, got, tt.want)
+			}
+		}()
+	}
+}
+
+func TestDivideWithRemainder(t *testing.T) {
+	tests := []struct {
+		a, b int
+		q, r int
+	}{
+		{17, 5, 3, 2},
+		{10, 2, 5, 0},
+		{3, 7, 0, 3},
+		{-7, 2, -3, -1},
+	}
+	for _, tt := range tests {
+		func() {
+			defer recoverStub(t, fmt.Sprintf("divideWithRemainder(%d, %d)", tt.a, tt.b))
+			q, r := divideWithRemainder(tt.a, tt.b)
+			if q != tt.q || r != tt.r {
+				t.Errorf("divideWithRemainder(%d, %d) = %d, %d; want %d, %d", tt.a, tt.b, q, r, tt.q, tt.r)
+			}
+		}()
+	}
+}
+
+func TestSumAll(t *testing.T) {
+	tests := []struct {
+		in   []int
+		want int
+	}{
+		{nil, 0},
+		{[]int{5}, 5},
+		{[]int{1, 2, 3, 4, 5}, 15},
+		{[]int{-4, 4, 10}, 10},
+	}
+	for _, tt := range tests {
+		func() {
+			defer recoverStub(t, fmt.Sprintf("sumAll(%v...)", tt.in))
+			if got := sumAll(tt.in...); got != tt.want {
+				t.Errorf("sumAll(%v...) = %d; want %d", tt.in, got, tt.want)
+			}
+		}()
+	}
+}
+
+func TestMakeCounter(t *testing.T) {
+	defer recoverStub(t, "makeCounter()")
+	counter := makeCounter()
+	if counter == nil {
+		t.Fatal("makeCounter() returned nil")
+	}
+	for want := 1; want <= 3; want++ {
+		if got := counter(); got != want {
+			t.Fatalf("call %d of makeCounter() = %d; want %d", want, got, want)
+		}
+	}
+	if got := makeCounter()(); got != 1 {
+		t.Errorf("first call of a second makeCounter() = %d; want 1 (counters must not share state)", got)
+	}
+}
+
+func TestMapInts(t *testing.T) {
+	double := func(n int) int { return n * 2 }
+	tests := []struct {
+		in   []int
+		want []int
+	}{
+		{[]int{1, 2, 3, 4, 5}, []int{2, 4, 6, 8, 10}},
+		{[]int{-1, 0}, []int{-2, 0}},
+		{[]int{}, []int{}},
+	}
+	for _, tt := range tests {
+		func() {
+			defer recoverStub(t, fmt.Sprintf("mapInts(%v, double)", tt.in))
+			in := slices.Clone(tt.in)
+			got := mapInts(in, double)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("mapInts(%v, double) = %v; want %v", tt.in, got, tt.want)
+			}
+			if !slices.Equal(in, tt.in) {
+				t.Errorf("mapInts(%v, double) modified its input to %v", tt.in, in)
+			}
+		}()
+	}
+}
+
+func TestFilterInts(t *testing.T) {
+	even := func(n int) bool { return n%2 == 0 }
+	tests := []struct {
+		in   []int
+		want []int
+	}{
+		{[]int{1, 2, 3, 4, 5}, []int{2, 4}},
+		{[]int{1, 3}, []int{}},
+		{[]int{8, 6, 7}, []int{8, 6}},
+	}
+	for _, tt := range tests {
+		func() {
+			defer recoverStub(t, fmt.Sprintf("filterInts(%v, even)", tt.in))
+			if got := filterInts(tt.in, even); !slices.Equal(got, tt.want) {
+				t.Errorf("filterInts(%v, even) = %v; want %v", tt.in, got, tt.want)
+			}
+		}()
+	}
+}
+
+func TestFactorial(t *testing.T) {
+	tests := []struct {
+		n, want int
+	}{
+		{0, 1},
+		{1, 1},
+		{5, 120},
+		{10, 3628800},
+		{20, 2432902008176640000},
+	}
+	for _, tt := range tests {
+		func() {
+			defer recoverStub(t, fmt.Sprintf("factorial(%d)", tt.n))
+			if got := factorial(tt.n); got != tt.want {
+				t.Errorf("factorial(%d) = %d; want %d", tt.n, got, tt.want)
+			}
+		}()
+	}
+}
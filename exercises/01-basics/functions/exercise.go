@@ -0,0 +1,46 @@
+// Package functions is the exercise for examples/01-basics/functions.
+//
+// Implement each function below, replacing the panic, then check your
+// work with:
+//
+//	go run ./cmd/demo exercise functions
+package functions
+
+// divide returns a / b, or an error if b is zero.
+func divide(a, b float64) (float64, error) {
+	panic("not implemented")
+}
+
+// divideWithRemainder returns the integer quotient and remainder of
+// a / b using named return values and a naked return.
+func divideWithRemainder(a, b int) (quotient int, remainder int) {
+	panic("not implemented")
+}
+
+// sumAll returns the sum of any number of ints; zero for none.
+func sumAll(numbers ...int) int {
+	panic("not implemented")
+}
+
+// makeCounter returns a closure that returns 1, 2, 3, ... on successive
+// calls. Every call to makeCounter starts a new, independent count.
+func makeCounter() func() int {
+	panic("not implemented")
+}
+
+// mapInts returns a new slice holding fn applied to every element of
+// numbers. The input slice must not be modified.
+func mapInts(numbers []int, fn func(int) int) []int {
+	panic("not implemented")
+}
+
+// filterInts returns the elements of numbers for which predicate is
+// true, in their original order.
+func filterInts(numbers []int, predicate func(int) bool) []int {
+	panic("not implemented")
+}
+
+// factorial returns n! recursively; factorial(0) and factorial(1) are 1.
+func factorial(n int) int {
+	panic("not implemented")
+}
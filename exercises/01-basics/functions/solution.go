@@ -0,0 +1,63 @@
+//go:build ignore
+
+// Reference solutions, revealed one function at a time by
+// `go run ./cmd/demo hint --solution`. The ignore build tag keeps them
+// out of the build so they never satisfy the hidden tests by accident.
+
+package functions
+
+import "errors"
+
+func divide(a, b float64) (float64, error) {
+	if b == 0 {
+		return 0, errors.New("division by zero")
+	}
+	return a / b, nil
+}
+
+func divideWithRemainder(a, b int) (quotient int, remainder int) {
+	quotient = a / b
+	remainder = a % b
+	return
+}
+
+func sumAll(numbers ...int) int {
+	total := 0
+	for _, n := range numbers {
+		total += n
+	}
+	return total
+}
+
+func makeCounter() func() int {
+	count := 0
+	return func() int {
+		count++
+		return count
+	}
+}
+
+func mapInts(numbers []int, fn func(int) int) []int {
+	result := make([]int, len(numbers))
+	for i, n := range numbers {
+		result[i] = fn(n)
+	}
+	return result
+}
+
+func filterInts(numbers []int, predicate func(int) bool) []int {
+	result := []int{}
+	for _, n := range numbers {
+		if predicate(n) {
+			result = append(result, n)
+		}
+	}
+	return result
+}
+
+func factorial(n int) int {
+	if n <= 1 {
+		return 1
+	}
+	return n * factorial(n-1)
+}
@@ -0,0 +1,99 @@
+//go:build kata
+
+package variablestypes
+
+import (
+	"fmt"
+	"testing"
+)
+
+// recoverStub turns a panic, such as an unimplemented stub, into a test
+// failure so the remaining checks still run.
+func recoverStub(t *testing.T, call string) {
+	if r := recover(); r != nil {
+		t.Errorf("%s panicked: %v", call, r)
+	}
+}
+
+func TestWeekdayName(t *testing.T) {
+	tests := []struct {
+		d    Weekday
+		want string
+	}{
+		{Sunday, "Sunday"},
+		{Monday, "Monday"},
+		{Friday, "Friday"},
+		{Saturday, "Saturday"},
+		{7, "Invalid"},
+		{-1, "Invalid"},
+	}
+	for _, tt := range tests {
+		func() {
+			defer recoverStub(t, fmt.Sprintf("weekdayName(%d)", tt.d))
+			if got := weekdayName(tt.d); got != tt.want {
+				t.Errorf("weekdayName(%d) = %q; want %q", tt.d, got, tt.want)
+			}
+		}()
+	}
+}
+
+func TestCelsiusToFahrenheit(t *testing.T) {
+	tests := []struct {
+		c, want float64
+	}{
+		{0, 32},
+		{100, 212},
+		{-40, -40},
+		{37, 98.6},
+	}
+	for _, tt := range tests {
+		func() {
+			defer recoverStub(t, fmt.Sprintf("celsiusToFahrenheit(%v)", tt.c))
+			got := celsiusToFahrenheit(tt.c)
+			if diff := got - tt.want; diff > 1e-9 || diff < -1e-9 {
+				t.Errorf("celsiusToFahrenheit(%v) = %v; want %v", tt.c, got, tt.want)
+			}
+		}()
+	}
+}
+
+func TestAverage(t *testing.T) {
+	tests := []struct {
+		in   []int
+		want float64
+	}{
+		{[]int{1, 2}, 1.5},
+		{[]int{10, 20, 30}, 20},
+		{[]int{7}, 7},
+		{nil, 0},
+	}
+	for _, tt := range tests {
+		func() {
+			defer recoverStub(t, fmt.Sprintf("average(%v)", tt.in))
+			if got := average(tt.in); got != tt.want {
+				t.Errorf("average(%v) = %v; want %v", tt.in, got, tt.want)
+			}
+		}()
+	}
+}
+
+func TestByteCount(t *testing.T) {
+	tests := []struct {
+		s            string
+		bytes, runes int
+	}{
+		{"Hello", 5, 5},
+		{"", 0, 0},
+		{"😀", 4, 1},
+		{"héllo", 6, 5},
+	}
+	for _, tt := range tests {
+		func() {
+			defer recoverStub(t, fmt.Sprintf("byteCount(%q)", tt.s))
+			b, r := byteCount(tt.s)
+			if b != tt.bytes || r != tt.runes {
+				t.Errorf("byteCount(%q) = %d, %d; want %d, %d", tt.s, b, r, tt.bytes, tt.runes)
+			}
+		}()
+	}
+}
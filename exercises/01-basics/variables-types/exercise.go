@@ -0,0 +1,45 @@
+// Package variablestypes is the exercise for
+// examples/01-basics/variables-types.
+//
+// Implement each function below, replacing the panic, then check your
+// work with:
+//
+//	go run ./cmd/demo exercise variables-types
+package variablestypes
+
+// Weekday numbers the days of the week from Sunday = 0, like the iota
+// block in the lesson.
+type Weekday int
+
+const (
+	Sunday Weekday = iota
+	Monday
+	Tuesday
+	Wednesday
+	Thursday
+	Friday
+	Saturday
+)
+
+// weekdayName returns the English name of d ("Sunday" ... "Saturday"),
+// or "Invalid" for values outside that range.
+func weekdayName(d Weekday) string {
+	panic("not implemented")
+}
+
+// celsiusToFahrenheit converts a temperature: F = C*9/5 + 32.
+func celsiusToFahrenheit(c float64) float64 {
+	panic("not implemented")
+}
+
+// average returns the mean of nums as a float64 without losing the
+// fraction to integer division; the average of no numbers is 0.
+func average(nums []int) float64 {
+	panic("not implemented")
+}
+
+// byteCount returns how many bytes and how many runes s holds, which
+// differ as soon as s contains non-ASCII text such as "😀".
+func byteCount(s string) (bytes int, runes int) {
+	panic("not implemented")
+}
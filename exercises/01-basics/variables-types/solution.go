@@ -0,0 +1,48 @@
+//go:build ignore
+
+// Reference solutions, revealed one function at a time by
+// `go run ./cmd/demo hint --solution`. The ignore build tag keeps them
+// out of the build so they never satisfy the hidden tests by accident.
+
+package variablestypes
+
+import "unicode/utf8"
+
+type Weekday int
+
+const (
+	Sunday Weekday = iota
+	Monday
+	Tuesday
+	Wednesday
+	Thursday
+	Friday
+	Saturday
+)
+
+func weekdayName(d Weekday) string {
+	names := [...]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"}
+	if d < Sunday || d > Saturday {
+		return "Invalid"
+	}
+	return names[d]
+}
+
+func celsiusToFahrenheit(c float64) float64 {
+	return c*9/5 + 32
+}
+
+func average(nums []int) float64 {
+	if len(nums) == 0 {
+		return 0
+	}
+	total := 0
+	for _, n := range nums {
+		total += n
+	}
+	return float64(total) / float64(len(nums))
+}
+
+func byteCount(s string) (bytes int, runes int) {
+	return len(s), utf8.RuneCountInString(s)
+}
@@ -0,0 +1,239 @@
+//go:build kata
+
+package errorhandling
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"strings"
+	"testing"
+)
+
+// recoverStub turns a panic, such as an unimplemented stub, into a test
+// failure so the remaining checks still run.
+func recoverStub(t *testing.T, call string) {
+	if r := recover(); r != nil {
+		t.Errorf("%s panicked: %v", call, r)
+	}
+}
+
+func newStock() map[string]int {
+	return map[string]int{"KB-01": 5, "MS-02": 2, "CB-03": 40}
+}
+
+func TestReserve(t *testing.T) {
+	tests := []struct {
+		sku     string
+		qty     int
+		wantIs  error // sentinel the error must wrap
+		short   int   // Requested-Available of a *OutOfStockError
+		wantErr bool
+	}{
+		{"KB-01", 5, nil, 0, false},
+		{"CB-03", 1, nil, 0, false},
+		{"XX-99", 1, ErrUnknownProduct, 0, true},
+		{"CB-03", 0, ErrInvalidQuantity, 0, true},
+		{"XX-99", -1, ErrInvalidQuantity, 0, true},
+		{"MS-02", 3, nil, 1, true},
+	}
+	for _, tt := range tests {
+		func() {
+			call := fmt.Sprintf("reserve(stock, %q, %d)", tt.sku, tt.qty)
+			defer recoverStub(t, call)
+			stock := newStock()
+			err := reserve(stock, tt.sku, tt.qty)
+			want := newStock()
+			if !tt.wantErr {
+				want[tt.sku] -= tt.qty
+			}
+			switch {
+			case tt.wantErr && err == nil:
+				t.Errorf("%s = <nil>; want an error", call)
+				return
+			case !tt.wantErr && err != nil:
+				t.Errorf("%s returned error %q; want <nil>", call, err)
+			}
+			if !maps.Equal(stock, want) {
+				t.Errorf("%s left stock %v; want %v", call, stock, want)
+			}
+			if !tt.wantErr {
+				return
+			}
+			if tt.wantIs != nil {
+				if !errors.Is(err, tt.wantIs) {
+					t.Errorf("%s = %q; want an error wrapping %q", call, err, tt.wantIs)
+				}
+				if !strings.Contains(err.Error(), tt.sku) {
+					t.Errorf("%s = %q; want the message to mention %s", call, err, tt.sku)
+				}
+			}
+			if tt.short > 0 {
+				var oos *OutOfStockError
+				if !errors.As(err, &oos) {
+					t.Errorf("%s = %q; want a *OutOfStockError", call, err)
+				} else if oos.SKU != tt.sku || oos.Requested-oos.Available != tt.short {
+					t.Errorf("%s = %+v; want SKU %s, %d short", call, *oos, tt.sku, tt.short)
+				}
+			}
+		}()
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		lines []Line
+		is    []error // sentinels the error must wrap
+		oos   bool    // whether it must contain a *OutOfStockError
+		count int     // number of joined errors
+	}{
+		{nil, nil, false, 0},
+		{[]Line{{"KB-01", 5}, {"MS-02", 2}}, nil, false, 0},
+		{[]Line{{"XX-99", 1}}, []error{ErrUnknownProduct}, false, 1},
+		{[]Line{{"KB-01", 1}, {"XX-99", 1}, {"MS-02", 9}, {"CB-03", -2}}, []error{ErrUnknownProduct, ErrInvalidQuantity}, true, 3},
+	}
+	for _, tt := range tests {
+		func() {
+			call := fmt.Sprintf("validate(stock, %v)", tt.lines)
+			defer recoverStub(t, call)
+			stock := newStock()
+			err := validate(stock, tt.lines)
+			if !maps.Equal(stock, newStock()) {
+				t.Errorf("%s changed stock to %v", call, stock)
+			}
+			if tt.count == 0 {
+				if err != nil {
+					t.Errorf("%s = %q; want <nil>", call, err)
+				}
+				return
+			}
+			if err == nil {
+				t.Errorf("%s = <nil>; want %d problem(s)", call, tt.count)
+				return
+			}
+			for _, want := range tt.is {
+				if !errors.Is(err, want) {
+					t.Errorf("%s = %q; want it to include %q", call, err, want)
+				}
+			}
+			var oos *OutOfStockError
+			if errors.As(err, &oos) != tt.oos {
+				t.Errorf("%s = %q; errors.As for *OutOfStockError = %v, want %v", call, err, !tt.oos, tt.oos)
+			}
+			if joined, ok := err.(interface{ Unwrap() []error }); !ok {
+				t.Errorf("%s = %q; want the problems joined with errors.Join", call, err)
+			} else if n := len(joined.Unwrap()); n != tt.count {
+				t.Errorf("%s joined %d error(s); want %d", call, n, tt.count)
+			}
+		}()
+	}
+}
+
+func TestShortfall(t *testing.T) {
+	oos := &OutOfStockError{SKU: "MS-02", Requested: 5, Available: 2}
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{nil, 0},
+		{oos, 3},
+		{fmt.Errorf("checkout: %w", oos), 3},
+		{errors.Join(io.EOF, fmt.Errorf("line 2: %w", oos)), 3},
+		{ErrUnknownProduct, 0},
+	}
+	for _, tt := range tests {
+		func() {
+			defer recoverStub(t, fmt.Sprintf("shortfall(%v)", tt.err))
+			if got := shortfall(tt.err); got != tt.want {
+				t.Errorf("shortfall(%q) = %d; want %d", tt.err, got, tt.want)
+			}
+		}()
+	}
+}
+
+func TestPlaceOrder(t *testing.T) {
+	tests := []struct {
+		lines   []Line
+		want    map[string]int
+		wantIs  error
+		wantOOS bool
+	}{
+		{[]Line{{"KB-01", 1}, {"CB-03", 10}}, map[string]int{"KB-01": 4, "MS-02": 2, "CB-03": 30}, nil, false},
+		{[]Line{{"KB-01", 1}, {"CB-03", 10}, {"MS-02", 3}}, newStock(), nil, true},
+		{[]Line{{"CB-03", 40}, {"XX-99", 1}}, newStock(), ErrUnknownProduct, false},
+		{[]Line{{"KB-01", 3}, {"KB-01", 3}}, newStock(), nil, true},
+	}
+	for _, tt := range tests {
+		func() {
+			call := fmt.Sprintf("placeOrder(stock, %v)", tt.lines)
+			defer recoverStub(t, call)
+			stock := newStock()
+			err := placeOrder(stock, tt.lines)
+			if !maps.Equal(stock, tt.want) {
+				t.Errorf("%s left stock %v; want %v", call, stock, tt.want)
+			}
+			fails := tt.wantIs != nil || tt.wantOOS
+			switch {
+			case fails && err == nil:
+				t.Errorf("%s = <nil>; want an error", call)
+			case !fails && err != nil:
+				t.Errorf("%s returned error %q; want <nil>", call, err)
+			case tt.wantIs != nil && !errors.Is(err, tt.wantIs):
+				t.Errorf("%s = %q; want an error wrapping %q", call, err, tt.wantIs)
+			case tt.wantOOS && shortfallOf(err) == 0:
+				t.Errorf("%s = %q; want it to wrap a *OutOfStockError", call, err)
+			}
+		}()
+	}
+}
+
+// shortfallOf is the reference shortfall, so TestPlaceOrder does not
+// depend on the learner's.
+func shortfallOf(err error) int {
+	var oos *OutOfStockError
+	if errors.As(err, &oos) {
+		return oos.Requested - oos.Available
+	}
+	return 0
+}
+
+func TestSafely(t *testing.T) {
+	cause := fmt.Errorf("KB-01: %w", ErrUnknownProduct)
+	tests := []struct {
+		name    string
+		fn      func()
+		wantErr bool
+		wantIs  error
+		contain string
+	}{
+		{"no panic", func() {}, false, nil, ""},
+		{"panic(\"boom\")", func() { panic("boom") }, true, nil, "boom"},
+		{"panic(err)", func() { panic(cause) }, true, ErrUnknownProduct, "KB-01"},
+		{"nil map write", func() {
+			var m map[string]int
+			m["KB-01"]++
+		}, true, nil, "nil map"},
+	}
+	for _, tt := range tests {
+		func() {
+			call := fmt.Sprintf("safely(%s)", tt.name)
+			defer func() {
+				if r := recover(); r != nil {
+					t.Errorf("%s let a panic escape: %v", call, r)
+				}
+			}()
+			err := safely(tt.fn)
+			switch {
+			case !tt.wantErr && err != nil:
+				t.Errorf("%s = %q; want <nil>", call, err)
+			case tt.wantErr && err == nil:
+				t.Errorf("%s = <nil>; want an error", call)
+			case tt.wantErr && !strings.Contains(err.Error(), tt.contain):
+				t.Errorf("%s = %q; want it to mention %q", call, err, tt.contain)
+			case tt.wantIs != nil && !errors.Is(err, tt.wantIs):
+				t.Errorf("%s = %q; want errors.Is(err, %q)", call, err, tt.wantIs)
+			}
+		}()
+	}
+}
@@ -0,0 +1,74 @@
+// Package errorhandling is the exercise for
+// examples/02-structs-interfaces/error-handling.
+//
+// The errors and types are given. Implement each function below,
+// replacing the panic, then check your work with:
+//
+//	go run ./cmd/demo exercise error-handling
+package errorhandling
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors, to be wrapped with context and matched with errors.Is.
+var (
+	ErrUnknownProduct  = errors.New("unknown product")
+	ErrInvalidQuantity = errors.New("quantity must be positive")
+)
+
+// OutOfStockError reports a reservation larger than the stock.
+type OutOfStockError struct {
+	SKU       string
+	Requested int
+	Available int
+}
+
+func (e *OutOfStockError) Error() string {
+	return fmt.Sprintf("%s: out of stock: requested %d, available %d", e.SKU, e.Requested, e.Available)
+}
+
+// Line is one product and quantity of an order.
+type Line struct {
+	SKU string
+	Qty int
+}
+
+// reserve takes qty units of sku out of stock. It fails, changing
+// nothing, with
+//   - an error wrapping ErrInvalidQuantity if qty is not positive,
+//   - an error wrapping ErrUnknownProduct if sku is not in stock at all,
+//   - a *OutOfStockError if qty is more than the stock of sku.
+//
+// Wrapped errors must mention the SKU, e.g. "XX-99: unknown product".
+func reserve(stock map[string]int, sku string, qty int) error {
+	panic("not implemented")
+}
+
+// validate checks every line without changing stock and returns all
+// the problems joined into one error (see errors.Join), or nil if the
+// whole order can be reserved. Each line is checked on its own against
+// the current stock.
+func validate(stock map[string]int, lines []Line) error {
+	panic("not implemented")
+}
+
+// shortfall returns how many units are missing if err is, or wraps, a
+// *OutOfStockError, and 0 otherwise.
+func shortfall(err error) int {
+	panic("not implemented")
+}
+
+// placeOrder reserves every line in order, or none of them: if a line
+// fails, the lines reserved before it are put back and the error is
+// returned wrapped, so errors.Is and errors.As still see it.
+func placeOrder(stock map[string]int, lines []Line) error {
+	panic("not implemented")
+}
+
+// safely calls fn and returns nil, or, if fn panics, an error describing
+// the panic. If the panic value is an error, the result wraps it.
+func safely(fn func()) error {
+	panic("not implemented")
+}
@@ -0,0 +1,87 @@
+//go:build ignore
+
+// Reference solutions, revealed one function at a time by
+// `go run ./cmd/demo hint --solution`. The ignore build tag keeps them
+// out of the build so they never satisfy the hidden tests by accident.
+
+package errorhandling
+
+import (
+	"errors"
+	"fmt"
+)
+
+func reserve(stock map[string]int, sku string, qty int) error {
+	if qty <= 0 {
+		return fmt.Errorf("%s: %d: %w", sku, qty, ErrInvalidQuantity)
+	}
+	available, ok := stock[sku]
+	if !ok {
+		return fmt.Errorf("%s: %w", sku, ErrUnknownProduct)
+	}
+	if qty > available {
+		return &OutOfStockError{SKU: sku, Requested: qty, Available: available}
+	}
+	stock[sku] -= qty
+	return nil
+}
+
+func validate(stock map[string]int, lines []Line) error {
+	var errs []error
+	for _, l := range lines {
+		if l.Qty <= 0 {
+			errs = append(errs, fmt.Errorf("%s: %d: %w", l.SKU, l.Qty, ErrInvalidQuantity))
+			continue
+		}
+		available, ok := stock[l.SKU]
+		switch {
+		case !ok:
+			errs = append(errs, fmt.Errorf("%s: %w", l.SKU, ErrUnknownProduct))
+		case l.Qty > available:
+			errs = append(errs, &OutOfStockError{SKU: l.SKU, Requested: l.Qty, Available: available})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func shortfall(err error) int {
+	var oos *OutOfStockError
+	if errors.As(err, &oos) {
+		return oos.Requested - oos.Available
+	}
+	return 0
+}
+
+func placeOrder(stock map[string]int, lines []Line) (err error) {
+	var done []Line
+	defer func() {
+		if err != nil {
+			for _, l := range done {
+				stock[l.SKU] += l.Qty
+			}
+		}
+	}()
+	for i, l := range lines {
+		if err = reserve(stock, l.SKU, l.Qty); err != nil {
+			return fmt.Errorf("line %d: %w", i+1, err)
+		}
+		done = append(done, l)
+	}
+	return nil
+}
+
+func safely(fn func()) (err error) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+		if e, ok := r.(error); ok {
+			err = fmt.Errorf("panic: %w", e)
+		} else {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	fn()
+	return nil
+}
@@ -0,0 +1,154 @@
+//go:build kata
+
+package interfaces
+
+import (
+	"fmt"
+	"testing"
+)
+
+// recoverStub turns a panic, such as an unimplemented stub, into a test
+// failure so the remaining checks still run.
+func recoverStub(t *testing.T, call string) {
+	if r := recover(); r != nil {
+		t.Errorf("%s panicked: %v", call, r)
+	}
+}
+
+var (
+	keyboard = Product{Name: "Keyboard", Price: 8999}
+	mouse    = Product{Name: "Mouse", Price: 2499}
+	express  = &Courier{Label: "express", PerKg: 500, MaxGrams: 5000}
+	freight  = &Courier{Label: "freight", PerKg: 100, MaxGrams: 100000}
+	domestic = FlatRate{Fee: 799, Country: "US"}
+)
+
+func TestTotalPrice(t *testing.T) {
+	kit := &Bundle{Items: []Pricer{keyboard, mouse}}
+	tests := []struct {
+		items []Pricer
+		qty   int
+		want  Money
+	}{
+		{nil, 3, 0},
+		{[]Pricer{keyboard}, 2, 17998},
+		{[]Pricer{keyboard, mouse}, 1, 11498},
+		{[]Pricer{kit, mouse}, 2, 2*11498 + 4998},
+	}
+	for _, tt := range tests {
+		func() {
+			defer recoverStub(t, fmt.Sprintf("totalPrice(%v, %d)", tt.items, tt.qty))
+			if got := totalPrice(tt.items, tt.qty); got != tt.want {
+				t.Errorf("totalPrice(%v, %d) = %d; want %d", tt.items, tt.qty, got, tt.want)
+			}
+		}()
+	}
+}
+
+func TestCheapest(t *testing.T) {
+	tests := []struct {
+		parcel   Parcel
+		shippers []Shipper
+		want     Shipper
+		cost     Money
+	}{
+		{Parcel{1200, "US"}, []Shipper{domestic, express, freight}, freight, 200},
+		{Parcel{1200, "CA"}, []Shipper{domestic, express}, express, 1000},
+		{Parcel{20000, "US"}, []Shipper{express, domestic, freight}, domestic, 799},
+		{Parcel{8000, "US"}, []Shipper{FlatRate{800, "US"}, freight}, FlatRate{800, "US"}, 800},
+	}
+	for _, tt := range tests {
+		func() {
+			defer recoverStub(t, fmt.Sprintf("cheapest(%v, ...)", tt.parcel))
+			got, cost, err := cheapest(tt.parcel, tt.shippers)
+			if err != nil {
+				t.Errorf("cheapest(%v, ...) returned error %q; want %s for %d", tt.parcel, err, tt.want.Name(), tt.cost)
+				return
+			}
+			if got != tt.want || cost != tt.cost {
+				name := "<nil>"
+				if got != nil {
+					name = got.Name()
+				}
+				t.Errorf("cheapest(%v, ...) = %s, %d; want %s, %d", tt.parcel, name, cost, tt.want.Name(), tt.cost)
+			}
+		}()
+	}
+
+	for _, shippers := range [][]Shipper{nil, {domestic, express}} {
+		func() {
+			defer recoverStub(t, "cheapest with no possible shipper")
+			p := Parcel{9000, "FR"}
+			if got, _, err := cheapest(p, shippers); err == nil {
+				t.Errorf("cheapest(%v, %d shippers) = %v, <nil>; want an error, as none can deliver", p, len(shippers), got)
+			}
+		}()
+	}
+}
+
+// label is a fmt.Stringer that is not a Pricer.
+type label string
+
+func (l label) String() string { return "label " + string(l) }
+
+func TestDescribe(t *testing.T) {
+	tests := []struct {
+		in   any
+		want string
+	}{
+		{nil, "nothing"},
+		{keyboard, "product Keyboard"},
+		{&Bundle{Items: []Pricer{mouse}}, "pricer for 2499"},
+		{label("fragile"), "label fragile"},
+		{42, "int"},
+		// Bundle's PriceFor has a pointer receiver: a Bundle value is
+		// not a Pricer
+		{Bundle{}, "interfaces.Bundle"},
+	}
+	for _, tt := range tests {
+		func() {
+			defer recoverStub(t, fmt.Sprintf("describe(%#v)", tt.in))
+			if got := describe(tt.in); got != tt.want {
+				t.Errorf("describe(%#v) = %q; want %q", tt.in, got, tt.want)
+			}
+		}()
+	}
+}
+
+func TestTrackingURL(t *testing.T) {
+	tests := []struct {
+		s    Shipper
+		want string
+	}{
+		{express, "https://express.example/track/PX-42"},
+		{domestic, ""},
+	}
+	for _, tt := range tests {
+		func() {
+			defer recoverStub(t, fmt.Sprintf("trackingURL(%s, \"PX-42\")", tt.s.Name()))
+			if got := trackingURL(tt.s, "PX-42"); got != tt.want {
+				t.Errorf("trackingURL(%s, \"PX-42\") = %q; want %q", tt.s.Name(), got, tt.want)
+			}
+		}()
+	}
+}
+
+func TestLookup(t *testing.T) {
+	couriers := map[string]*Courier{"express": express, "freight": freight}
+	for _, name := range []string{"express", "freight"} {
+		func() {
+			defer recoverStub(t, fmt.Sprintf("lookup(%q)", name))
+			if got := lookup(couriers, name); got != couriers[name] {
+				t.Errorf("lookup(%q) = %v; want the %s courier", name, got, name)
+			}
+		}()
+	}
+	for _, name := range []string{"drone", ""} {
+		func() {
+			defer recoverStub(t, fmt.Sprintf("lookup(%q)", name))
+			if got := lookup(couriers, name); got != nil {
+				t.Errorf("lookup(%q) = %#v, which is not == nil; want a nil Shipper", name, got)
+			}
+		}()
+	}
+}
@@ -0,0 +1,131 @@
+// Package interfaces is the exercise for
+// examples/02-structs-interfaces/interfaces.
+//
+// The types are given. Implement each function below, replacing the
+// panic, then check your work with:
+//
+//	go run ./cmd/demo exercise interfaces
+package interfaces
+
+import "fmt"
+
+// Money is an amount in cents.
+type Money int64
+
+// Pricer prices a quantity of something.
+type Pricer interface {
+	PriceFor(qty int) Money
+}
+
+// Product has a fixed unit price.
+type Product struct {
+	Name  string
+	Price Money
+}
+
+func (p Product) PriceFor(qty int) Money { return p.Price * Money(qty) }
+
+func (p Product) String() string { return p.Name }
+
+// Bundle prices several items together. PriceFor has a pointer receiver,
+// so *Bundle is a Pricer and Bundle is not.
+type Bundle struct {
+	Items []Pricer
+}
+
+func (b *Bundle) PriceFor(qty int) Money {
+	var total Money
+	for _, it := range b.Items {
+		total += it.PriceFor(qty)
+	}
+	return total
+}
+
+// Parcel is what a Shipper is asked to deliver.
+type Parcel struct {
+	Grams   int
+	Country string
+}
+
+// Shipper quotes the cost of delivering a parcel, or an error if it
+// cannot deliver it.
+type Shipper interface {
+	Name() string
+	Cost(p Parcel) (Money, error)
+}
+
+// Tracker is implemented by shippers that can track parcels.
+type Tracker interface {
+	TrackingURL(id string) string
+}
+
+// FlatRate charges Fee for any parcel to Country.
+type FlatRate struct {
+	Fee     Money
+	Country string
+}
+
+func (f FlatRate) Name() string { return "flat rate" }
+
+func (f FlatRate) Cost(p Parcel) (Money, error) {
+	if p.Country != f.Country {
+		return 0, fmt.Errorf("flat rate does not ship to %s", p.Country)
+	}
+	return f.Fee, nil
+}
+
+// Courier charges per started kilogram, up to MaxGrams, and tracks its
+// parcels.
+type Courier struct {
+	Label    string
+	PerKg    Money
+	MaxGrams int
+}
+
+func (c *Courier) Name() string { return c.Label }
+
+func (c *Courier) Cost(p Parcel) (Money, error) {
+	if p.Grams > c.MaxGrams {
+		return 0, fmt.Errorf("%s: %d g is over the limit", c.Label, p.Grams)
+	}
+	return c.PerKg * Money((p.Grams+999)/1000), nil
+}
+
+func (c *Courier) TrackingURL(id string) string {
+	return "https://" + c.Label + ".example/track/" + id
+}
+
+// totalPrice returns the sum of qty units of every item.
+func totalPrice(items []Pricer, qty int) Money {
+	panic("not implemented")
+}
+
+// cheapest returns the shipper with the lowest cost for p and that
+// cost, skipping shippers that return an error. On a tie the earlier
+// shipper wins. If no shipper can deliver p, it returns a non-nil error.
+func cheapest(p Parcel, shippers []Shipper) (Shipper, Money, error) {
+	panic("not implemented")
+}
+
+// describe uses a type switch to describe v:
+//
+//	nil                    "nothing"
+//	a Product              "product " + its name
+//	any other Pricer       "pricer for " + its price for one unit, e.g. "pricer for 1500"
+//	any other fmt.Stringer its String()
+//	anything else          its type, as printed by %T
+func describe(v any) string {
+	panic("not implemented")
+}
+
+// trackingURL returns s's tracking URL for parcel id if s is also a
+// Tracker, and "" otherwise.
+func trackingURL(s Shipper, id string) string {
+	panic("not implemented")
+}
+
+// lookup returns the courier registered under name, or a nil Shipper,
+// one that compares equal to nil, if there is none.
+func lookup(couriers map[string]*Courier, name string) Shipper {
+	panic("not implemented")
+}
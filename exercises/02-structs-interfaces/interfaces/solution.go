@@ -0,0 +1,68 @@
+//go:build ignore
+
+// Reference solutions, revealed one function at a time by
+// `go run ./cmd/demo hint --solution`. The ignore build tag keeps them
+// out of the build so they never satisfy the hidden tests by accident.
+
+package interfaces
+
+import (
+	"errors"
+	"fmt"
+)
+
+func totalPrice(items []Pricer, qty int) Money {
+	var total Money
+	for _, it := range items {
+		total += it.PriceFor(qty)
+	}
+	return total
+}
+
+func cheapest(p Parcel, shippers []Shipper) (Shipper, Money, error) {
+	var best Shipper
+	var bestCost Money
+	for _, s := range shippers {
+		cost, err := s.Cost(p)
+		if err != nil {
+			continue
+		}
+		if best == nil || cost < bestCost {
+			best, bestCost = s, cost
+		}
+	}
+	if best == nil {
+		return nil, 0, errors.New("no shipper can deliver this parcel")
+	}
+	return best, bestCost, nil
+}
+
+func describe(v any) string {
+	switch x := v.(type) {
+	case nil:
+		return "nothing"
+	case Product:
+		return "product " + x.Name
+	case Pricer:
+		return fmt.Sprintf("pricer for %d", x.PriceFor(1))
+	case fmt.Stringer:
+		return x.String()
+	default:
+		return fmt.Sprintf("%T", x)
+	}
+}
+
+func trackingURL(s Shipper, id string) string {
+	if t, ok := s.(Tracker); ok {
+		return t.TrackingURL(id)
+	}
+	return ""
+}
+
+func lookup(couriers map[string]*Courier, name string) Shipper {
+	c, ok := couriers[name]
+	if !ok {
+		return nil // not c: a nil *Courier in a Shipper is != nil
+	}
+	return c
+}
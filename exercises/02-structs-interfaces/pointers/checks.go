@@ -0,0 +1,186 @@
+//go:build kata
+
+package pointers
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+// recoverStub turns a panic, such as an unimplemented stub, into a test
+// failure so the remaining checks still run.
+func recoverStub(t *testing.T, call string) {
+	if r := recover(); r != nil {
+		t.Errorf("%s panicked: %v", call, r)
+	}
+}
+
+func catalog() []Product {
+	return []Product{{"KB-01", 3}, {"MS-02", 0}, {"CB-03", 40}}
+}
+
+func TestRestock(t *testing.T) {
+	tests := []struct {
+		stock, n, want int
+	}{
+		{3, 10, 13},
+		{0, 5, 5},
+		{7, 0, 7},
+	}
+	for _, tt := range tests {
+		func() {
+			defer recoverStub(t, fmt.Sprintf("restock(&Product{Stock: %d}, %d)", tt.stock, tt.n))
+			p := Product{SKU: "KB-01", Stock: tt.stock}
+			restock(&p, tt.n)
+			if p.Stock != tt.want {
+				t.Errorf("restock(&Product{Stock: %d}, %d) left Stock = %d; want %d", tt.stock, tt.n, p.Stock, tt.want)
+			}
+		}()
+	}
+	func() {
+		defer recoverStub(t, "restock(nil, 5)")
+		restock(nil, 5)
+	}()
+}
+
+func TestClearStock(t *testing.T) {
+	tests := [][]Product{catalog(), {{"KB-01", 1}}, {}}
+	for _, products := range tests {
+		func() {
+			before := slices.Clone(products)
+			defer recoverStub(t, fmt.Sprintf("clearStock(%v)", before))
+			clearStock(products)
+			for i, p := range products {
+				if p.Stock != 0 || p.SKU != before[i].SKU {
+					t.Errorf("clearStock(%v) left %v; want every Stock 0 and the SKUs unchanged", before, products)
+					return
+				}
+			}
+		}()
+	}
+}
+
+func TestIndexBySKU(t *testing.T) {
+	func() {
+		products := catalog()
+		defer recoverStub(t, fmt.Sprintf("indexBySKU(%v)", products))
+		m := indexBySKU(products)
+		if len(m) != len(products) {
+			t.Errorf("indexBySKU(%v) has %d entries; want %d", catalog(), len(m), len(products))
+			return
+		}
+		for i := range products {
+			p := m[products[i].SKU]
+			if p != &products[i] {
+				t.Errorf("indexBySKU(%v)[%q] does not point at products[%d]", catalog(), products[i].SKU, i)
+			}
+		}
+		if p := m["MS-02"]; p != nil {
+			p.Stock = 20
+			if products[1].Stock != 20 {
+				t.Errorf("setting indexBySKU(...)[\"MS-02\"].Stock = 20 left products[1].Stock = %d; want 20", products[1].Stock)
+			}
+		}
+	}()
+	func() {
+		defer recoverStub(t, "indexBySKU(nil)")
+		if m := indexBySKU(nil); m == nil || len(m) != 0 {
+			t.Errorf("indexBySKU(nil) = %v; want an empty, non-nil map", m)
+		}
+	}()
+}
+
+func TestSwap(t *testing.T) {
+	func() {
+		a, b := Product{"KB-01", 3}, Product{"MS-02", 9}
+		defer recoverStub(t, fmt.Sprintf("swap(&%v, &%v)", a, b))
+		pa, pb := &a, &b
+		swap(pa, pb)
+		if a != (Product{"MS-02", 9}) || b != (Product{"KB-01", 3}) {
+			t.Errorf("swap(&{KB-01 3}, &{MS-02 9}) left %v, %v; want {MS-02 9}, {KB-01 3}", a, b)
+		}
+		if pa != &a || pb != &b {
+			t.Errorf("swap must exchange the products, not the pointers")
+		}
+	}()
+	func() {
+		products := catalog()
+		defer recoverStub(t, "swap(&products[0], &products[2])")
+		swap(&products[0], &products[2])
+		want := []Product{{"CB-03", 40}, {"MS-02", 0}, {"KB-01", 3}}
+		if !slices.Equal(products, want) {
+			t.Errorf("swap(&products[0], &products[2]) left %v; want %v", products, want)
+		}
+	}()
+}
+
+// history builds a history from events, oldest first, and returns its
+// head, the newest event.
+func history(events ...string) *Event {
+	var head *Event
+	for _, text := range events {
+		head = &Event{Text: text, Prev: head}
+	}
+	return head
+}
+
+// texts lists a history newest first.
+func texts(head *Event) []string {
+	var out []string
+	for e := head; e != nil && len(out) <= 10; e = e.Prev {
+		out = append(out, e.Text)
+	}
+	return out
+}
+
+func TestHistoryLen(t *testing.T) {
+	tests := []struct {
+		events []string
+		want   int
+	}{
+		{nil, 0},
+		{[]string{"placed"}, 1},
+		{[]string{"placed", "paid", "shipped"}, 3},
+	}
+	for _, tt := range tests {
+		func() {
+			defer recoverStub(t, fmt.Sprintf("historyLen(%q)", tt.events))
+			if got := historyLen(history(tt.events...)); got != tt.want {
+				t.Errorf("historyLen of %q = %d; want %d", tt.events, got, tt.want)
+			}
+		}()
+	}
+}
+
+func TestReverse(t *testing.T) {
+	tests := [][]string{
+		nil,
+		{"placed"},
+		{"placed", "paid"},
+		{"placed", "paid", "shipped", "delivered"},
+	}
+	for _, events := range tests {
+		func() {
+			defer recoverStub(t, fmt.Sprintf("reverse(%q)", events))
+			head := history(events...)
+			var nodes []*Event
+			for e := head; e != nil; e = e.Prev {
+				nodes = append(nodes, e)
+			}
+
+			got := reverse(head)
+			// Reversed, the history reads oldest first from the head.
+			if !slices.Equal(texts(got), events) {
+				t.Errorf("reverse of a history %q (newest first) reads %q; want %q", texts(head), texts(got), events)
+				return
+			}
+			for e, i := got, len(nodes)-1; e != nil; e, i = e.Prev, i-1 {
+				if e != nodes[i] {
+					t.Errorf("reverse of %q allocated new events; rewire the existing ones", events)
+					return
+				}
+			}
+		}()
+	}
+}
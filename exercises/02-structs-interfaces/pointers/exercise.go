@@ -0,0 +1,54 @@
+// Package pointers is the exercise for examples/02-structs-interfaces/pointers.
+//
+// The types are given. Implement each function below, replacing the
+// panic, then check your work with:
+//
+//	go run ./cmd/demo exercise pointers
+package pointers
+
+// Product is something the shop sells, with the units in stock.
+type Product struct {
+	SKU   string
+	Stock int
+}
+
+// Event is one entry of an order history, linked to the entry before
+// it. A nil *Event is an empty history.
+type Event struct {
+	Text string
+	Prev *Event
+}
+
+// restock adds n units to the product p points to. A nil p is ignored.
+func restock(p *Product, n int) {
+	panic("not implemented")
+}
+
+// clearStock sets the stock of every product in products to zero, in
+// place.
+func clearStock(products []Product) {
+	panic("not implemented")
+}
+
+// indexBySKU returns a map from SKU to a pointer to that product in
+// products, so that changes made through the map show up in the slice.
+func indexBySKU(products []Product) map[string]*Product {
+	panic("not implemented")
+}
+
+// swap exchanges the products a and b point to.
+func swap(a, b *Product) {
+	panic("not implemented")
+}
+
+// historyLen returns the number of events in the history ending at
+// head, following Prev until nil; 0 for a nil head.
+func historyLen(head *Event) int {
+	panic("not implemented")
+}
+
+// reverse reverses the history in place, by rewiring the Prev pointers,
+// and returns the new head: the event that was oldest.
+func reverse(head *Event) *Event {
+	panic("not implemented")
+}
@@ -0,0 +1,50 @@
+//go:build ignore
+
+// Reference solutions, revealed one function at a time by
+// `go run ./cmd/demo hint --solution`. The ignore build tag keeps them
+// out of the build so they never satisfy the hidden tests by accident.
+
+package pointers
+
+func restock(p *Product, n int) {
+	if p == nil {
+		return
+	}
+	p.Stock += n
+}
+
+func clearStock(products []Product) {
+	for i := range products {
+		products[i].Stock = 0
+	}
+}
+
+func indexBySKU(products []Product) map[string]*Product {
+	m := make(map[string]*Product, len(products))
+	for i := range products {
+		m[products[i].SKU] = &products[i]
+	}
+	return m
+}
+
+func swap(a, b *Product) {
+	*a, *b = *b, *a
+}
+
+func historyLen(head *Event) int {
+	n := 0
+	for e := head; e != nil; e = e.Prev {
+		n++
+	}
+	return n
+}
+
+func reverse(head *Event) *Event {
+	var next *Event
+	for head != nil {
+		prev := head.Prev
+		head.Prev = next
+		next, head = head, prev
+	}
+	return next
+}
@@ -0,0 +1,153 @@
+//go:build kata
+
+package structs
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+// recoverStub turns a panic, such as an unimplemented stub, into a test
+// failure so the remaining checks still run.
+func recoverStub(t *testing.T, call string) {
+	if r := recover(); r != nil {
+		t.Errorf("%s panicked: %v", call, r)
+	}
+}
+
+var (
+	keyboard = Product{SKU: "KB-01", Name: "Keyboard", Price: 8999}
+	mouse    = Product{SKU: "MS-02", Name: "Mouse", Price: 2499}
+	cable    = Product{SKU: "CB-03", Name: "Cable", Price: 999}
+)
+
+func TestFormatMoney(t *testing.T) {
+	tests := []struct {
+		in   Money
+		want string
+	}{
+		{1999, "$19.99"},
+		{5, "$0.05"},
+		{0, "$0.00"},
+		{100000, "$1000.00"},
+		{-250, "-$2.50"},
+		{-5, "-$0.05"},
+	}
+	for _, tt := range tests {
+		func() {
+			defer recoverStub(t, fmt.Sprintf("formatMoney(%d)", tt.in))
+			if got := formatMoney(tt.in); got != tt.want {
+				t.Errorf("formatMoney(%d) = %q; want %q", tt.in, got, tt.want)
+			}
+		}()
+	}
+}
+
+func TestLineTotal(t *testing.T) {
+	tests := []struct {
+		in   LineItem
+		want Money
+	}{
+		{LineItem{keyboard, 1}, 8999},
+		{LineItem{mouse, 3}, 7497},
+		{LineItem{cable, 0}, 0},
+	}
+	for _, tt := range tests {
+		func() {
+			defer recoverStub(t, fmt.Sprintf("lineTotal(%v)", tt.in))
+			if got := lineTotal(tt.in); got != tt.want {
+				t.Errorf("lineTotal(%v) = %d; want %d", tt.in, got, tt.want)
+			}
+		}()
+	}
+}
+
+func TestAddLine(t *testing.T) {
+	type add struct {
+		p   Product
+		qty int
+	}
+	tests := []struct {
+		adds []add
+		want []LineItem
+	}{
+		{[]add{{keyboard, 1}}, []LineItem{{keyboard, 1}}},
+		{[]add{{keyboard, 1}, {mouse, 2}}, []LineItem{{keyboard, 1}, {mouse, 2}}},
+		{[]add{{mouse, 2}, {cable, 1}, {mouse, 3}}, []LineItem{{mouse, 5}, {cable, 1}}},
+	}
+	for _, tt := range tests {
+		func() {
+			defer recoverStub(t, fmt.Sprintf("addLine for %v", tt.adds))
+			var o Order // a zero Order, with nil Lines, must be usable
+			for _, a := range tt.adds {
+				addLine(&o, a.p, a.qty)
+			}
+			if !slices.Equal(o.Lines, tt.want) {
+				t.Errorf("adding %v to an empty order gives lines %v; want %v", tt.adds, o.Lines, tt.want)
+			}
+		}()
+	}
+}
+
+func TestOrderTotal(t *testing.T) {
+	tests := []struct {
+		in   Order
+		want Money
+	}{
+		{Order{}, 0},
+		{Order{Lines: []LineItem{{keyboard, 1}}}, 8999},
+		{Order{Lines: []LineItem{{keyboard, 1}, {mouse, 2}, {cable, 3}}}, 8999 + 4998 + 2997},
+	}
+	for _, tt := range tests {
+		func() {
+			defer recoverStub(t, fmt.Sprintf("orderTotal(%v)", tt.in.Lines))
+			if got := orderTotal(tt.in); got != tt.want {
+				t.Errorf("orderTotal with lines %v = %d; want %d", tt.in.Lines, got, tt.want)
+			}
+		}()
+	}
+}
+
+func TestCustomerName(t *testing.T) {
+	tests := []struct {
+		in   *Customer
+		want string
+	}{
+		{&Customer{Name: "Alice"}, "Alice"},
+		{&Customer{}, ""},
+		{nil, "guest"},
+	}
+	for _, tt := range tests {
+		func() {
+			defer recoverStub(t, fmt.Sprintf("customerName(%v)", tt.in))
+			if got := customerName(tt.in); got != tt.want {
+				t.Errorf("customerName(%v) = %q; want %q", tt.in, got, tt.want)
+			}
+		}()
+	}
+}
+
+func TestShippingLabel(t *testing.T) {
+	tests := []struct {
+		in   Customer
+		want string
+	}{
+		{
+			Customer{"Alice", Address{"12 Main St", "Springfield", "US"}},
+			"Alice\n12 Main St\nSpringfield, US",
+		},
+		{
+			Customer{"Bob", Address{"1 Rue de Rivoli", "Paris", "FR"}},
+			"Bob\n1 Rue de Rivoli\nParis, FR",
+		},
+	}
+	for _, tt := range tests {
+		func() {
+			defer recoverStub(t, fmt.Sprintf("shippingLabel(%v)", tt.in))
+			if got := shippingLabel(tt.in); got != tt.want {
+				t.Errorf("shippingLabel(%v) = %q; want %q", tt.in, got, tt.want)
+			}
+		}()
+	}
+}
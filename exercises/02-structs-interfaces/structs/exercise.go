@@ -0,0 +1,77 @@
+// Package structs is the exercise for examples/02-structs-interfaces/structs.
+//
+// The types are given. Implement each function below, replacing the
+// panic, then check your work with:
+//
+//	go run ./cmd/demo exercise structs
+package structs
+
+// Money is an amount in cents.
+type Money int64
+
+// Product is something the shop sells.
+type Product struct {
+	SKU   string
+	Name  string
+	Price Money
+}
+
+// Address is a postal address.
+type Address struct {
+	Street  string
+	City    string
+	Country string
+}
+
+// Customer embeds Address, so c.City means c.Address.City.
+type Customer struct {
+	Name string
+	Address
+}
+
+// LineItem embeds Product, so li.Price means li.Product.Price.
+type LineItem struct {
+	Product
+	Quantity int
+}
+
+// Order is a customer's order. Customer is nil for a guest checkout.
+type Order struct {
+	Customer *Customer
+	Lines    []LineItem
+}
+
+// formatMoney returns m as dollars and cents: 1999 is "$19.99", 5 is
+// "$0.05" and -250 is "-$2.50".
+func formatMoney(m Money) string {
+	panic("not implemented")
+}
+
+// lineTotal returns the price of a whole line: the unit price times the
+// quantity.
+func lineTotal(li LineItem) Money {
+	panic("not implemented")
+}
+
+// addLine adds qty units of p to the order o points to. If o already has
+// a line with p's SKU, that line's quantity grows; otherwise a new line
+// is appended.
+func addLine(o *Order, p Product, qty int) {
+	panic("not implemented")
+}
+
+// orderTotal returns the sum of the line totals of o; zero for no lines.
+func orderTotal(o Order) Money {
+	panic("not implemented")
+}
+
+// customerName returns c's name, or "guest" if c is nil.
+func customerName(c *Customer) string {
+	panic("not implemented")
+}
+
+// shippingLabel returns the name and address of c on three lines:
+// "Alice\n12 Main St\nSpringfield, US".
+func shippingLabel(c Customer) string {
+	panic("not implemented")
+}
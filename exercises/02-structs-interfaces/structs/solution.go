@@ -0,0 +1,50 @@
+//go:build ignore
+
+// Reference solutions, revealed one function at a time by
+// `go run ./cmd/demo hint --solution`. The ignore build tag keeps them
+// out of the build so they never satisfy the hidden tests by accident.
+
+package structs
+
+import "fmt"
+
+func formatMoney(m Money) string {
+	sign := ""
+	if m < 0 {
+		sign, m = "-", -m
+	}
+	return fmt.Sprintf("%s$%d.%02d", sign, m/100, m%100)
+}
+
+func lineTotal(li LineItem) Money {
+	return li.Price * Money(li.Quantity)
+}
+
+func addLine(o *Order, p Product, qty int) {
+	for i := range o.Lines {
+		if o.Lines[i].SKU == p.SKU {
+			o.Lines[i].Quantity += qty
+			return
+		}
+	}
+	o.Lines = append(o.Lines, LineItem{Product: p, Quantity: qty})
+}
+
+func orderTotal(o Order) Money {
+	var total Money
+	for _, li := range o.Lines {
+		total += lineTotal(li)
+	}
+	return total
+}
+
+func customerName(c *Customer) string {
+	if c == nil {
+		return "guest"
+	}
+	return c.Name
+}
+
+func shippingLabel(c Customer) string {
+	return fmt.Sprintf("%s\n%s\n%s, %s", c.Name, c.Street, c.City, c.Country)
+}
@@ -0,0 +1,99 @@
+// Package auth authenticates and authorizes the users of an HTTP API.
+//
+// Users log in with a name and a password, hashed with argon2id. A
+// program or script then gets a pair of HMAC-signed JWTs: a short-lived
+// access token, sent as "Authorization: Bearer", and a refresh token
+// that buys a new pair. A browser gets a session cookie instead, and
+// must echo the session's CSRF token in the X-CSRF-Token header of
+// every request that changes something.
+//
+// Each user has a role and a Policy grants roles permissions. The
+// Authenticate middleware works out who sent a request, and Require
+// lets it through only with a permission:
+//
+//	a := auth.New(users, tokens, sessions, auth.DefaultPolicy, logger)
+//	mux.Handle("DELETE /tasks/{id}", a.Require(auth.PermDeleteTasks)(h))
+//	mux.Handle("/auth/", a.Handler())
+//	srv.Handler = a.Authenticate(mux)
+//
+// Errors match errs.ErrUnauthenticated, when a request does not say who
+// sent it or says so wrongly, or errs.ErrPermissionDenied.
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"slices"
+
+	"golang-learning-project/pkg/errs"
+)
+
+// Role is what a user is, as far as permissions go.
+type Role string
+
+const (
+	RoleViewer Role = "viewer"
+	RoleEditor Role = "editor"
+	RoleAdmin  Role = "admin"
+)
+
+func (r Role) valid() bool {
+	return r == RoleViewer || r == RoleEditor || r == RoleAdmin
+}
+
+// Permission is something a request may need to be allowed to do.
+type Permission string
+
+const (
+	PermReadTasks   Permission = "tasks:read"
+	PermWriteTasks  Permission = "tasks:write"
+	PermDeleteTasks Permission = "tasks:delete"
+)
+
+// Policy lists the permissions each role has.
+type Policy map[Role][]Permission
+
+// DefaultPolicy lets viewers read tasks, editors also create and change
+// them, and admins also delete them.
+var DefaultPolicy = Policy{
+	RoleViewer: {PermReadTasks},
+	RoleEditor: {PermReadTasks, PermWriteTasks},
+	RoleAdmin:  {PermReadTasks, PermWriteTasks, PermDeleteTasks},
+}
+
+// Allows reports whether role has perm.
+func (p Policy) Allows(role Role, perm Permission) bool {
+	return slices.Contains(p[role], perm)
+}
+
+// User is someone who has logged in.
+type User struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Role Role   `json:"role"`
+}
+
+type userKey struct{}
+
+// WithUser returns a context carrying u, as Authenticate does.
+func WithUser(ctx context.Context, u *User) context.Context {
+	return context.WithValue(ctx, userKey{}, u)
+}
+
+// UserFrom returns the user a request's context carries, if any.
+func UserFrom(ctx context.Context) (*User, bool) {
+	u, ok := ctx.Value(userKey{}).(*User)
+	return u, ok
+}
+
+// randomToken returns n random bytes, URL-safe base64 encoded. Without
+// randomness there is no secret to hand out, so its error must stop the
+// login.
+func randomToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", errs.Wrap(err, "random token")
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
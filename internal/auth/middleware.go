@@ -0,0 +1,369 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+
+	"golang-learning-project/pkg/errs"
+)
+
+// Authenticator ties users, tokens, sessions and a policy together: it
+// works out who sent a request, decides what they may do, and serves
+// the endpoints that log users in and out.
+type Authenticator struct {
+	users    UserStore
+	tokens   *Tokens
+	sessions *Sessions
+	policy   Policy
+	logger   *log.Logger
+}
+
+// New returns an Authenticator. Errors that are not the client's fault,
+// such as a failing UserStore, are logged to logger.
+func New(users UserStore, tokens *Tokens, sessions *Sessions, policy Policy, logger *log.Logger) *Authenticator {
+	return &Authenticator{users: users, tokens: tokens, sessions: sessions, policy: policy, logger: logger}
+}
+
+type (
+	sessionKey   struct{}
+	rejectionKey struct{}
+)
+
+// rejection is why Authenticate turned down a request's credentials.
+type rejection struct {
+	status int
+	code   string // the RFC 6750 error code of a 401
+	detail string
+}
+
+// Authenticate returns middleware that puts the sender of a request in
+// its context, for UserFrom and Require. The sender is the owner of a
+// valid access token in the Authorization header or, failing that, of a
+// live session cookie.
+//
+// Authenticate rejects nothing itself: a request whose credentials fail
+// goes on without a user, so that it can still log in or refresh, and
+// Require explains what was wrong. A session authenticates a POST, PUT,
+// PATCH or DELETE only if the request carries the session's CSRF token.
+// Its user is looked up for every request, so that a new role applies
+// at once; a user who no longer exists loses the session.
+func (a *Authenticator) Authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if h := r.Header.Get("Authorization"); h != "" {
+			scheme, token, _ := strings.Cut(h, " ")
+			c, err := a.tokens.Verify(token, AccessToken)
+			switch {
+			case !strings.EqualFold(scheme, "Bearer"):
+				ctx = context.WithValue(ctx, rejectionKey{}, &rejection{http.StatusUnauthorized, "invalid_request", "Authorization must be a Bearer token"})
+			case err != nil:
+				ctx = context.WithValue(ctx, rejectionKey{}, &rejection{http.StatusUnauthorized, "invalid_token", err.Error()})
+			default:
+				ctx = WithUser(ctx, c.User())
+			}
+		} else if sess, ok := a.sessions.Lookup(r); ok {
+			u, err := a.users.Get(ctx, sess.UserID)
+			switch {
+			case errors.Is(err, errs.ErrNotFound):
+				a.sessions.remove(sess.ID)
+			case err != nil:
+				a.serverError(w, r, err)
+				return
+			case checkCSRF(r, sess):
+				ctx = context.WithValue(WithUser(ctx, u), sessionKey{}, sess)
+			default:
+				ctx = context.WithValue(ctx, rejectionKey{}, &rejection{http.StatusForbidden, "", "a session needs its CSRF token in " + CSRFHeader})
+			}
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// Require returns middleware that lets a request through only if its
+// sender has perm: 401 if there is no sender, or their token is bad;
+// 403 if they lack perm, or their session's CSRF token is missing.
+func (a *Authenticator) Require(perm Permission) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			u, ok := requireUser(w, r)
+			switch {
+			case !ok:
+			case !a.policy.Allows(u.Role, perm):
+				writeProblem(w, r, http.StatusForbidden, fmt.Sprintf("role %s does not have permission %s", u.Role, perm))
+			default:
+				next.ServeHTTP(w, r)
+			}
+		})
+	}
+}
+
+// requireUser returns the sender of a request or, if there is none,
+// writes the response saying why.
+func requireUser(w http.ResponseWriter, r *http.Request) (*User, bool) {
+	if u, ok := UserFrom(r.Context()); ok {
+		return u, true
+	}
+	rj, ok := r.Context().Value(rejectionKey{}).(*rejection)
+	switch {
+	case !ok:
+		unauthorized(w, r, "", "log in first")
+	case rj.status == http.StatusUnauthorized:
+		unauthorized(w, r, rj.code, rj.detail)
+	default:
+		writeProblem(w, r, rj.status, rj.detail)
+	}
+	return nil, false
+}
+
+// ========================================
+// ENDPOINTS
+// ========================================
+
+// Credentials are the body of a login.
+type Credentials struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// RefreshRequest is the body of a refresh, and optionally of a logout.
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// Me describes the sender of a request. CSRFToken is set when a session
+// cookie authenticated it.
+type Me struct {
+	User        User         `json:"user"`
+	Permissions []Permission `json:"permissions"`
+	CSRFToken   string       `json:"csrf_token,omitempty"`
+}
+
+// Handler serves the endpoints under /auth/:
+//
+//	POST /auth/token    Credentials → TokenPair
+//	POST /auth/refresh  RefreshRequest → TokenPair, spending the token
+//	POST /auth/login    Credentials → Me, starting a session
+//	POST /auth/logout   ends the session, and the refresh token's family if one is sent
+//	GET  /auth/me       → Me
+//
+// The handler must be behind Authenticate. Logging out and asking who
+// one is need a user; the rest take credentials in the body.
+func (a *Authenticator) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /auth/token", a.token)
+	mux.HandleFunc("POST /auth/refresh", a.refresh)
+	mux.HandleFunc("POST /auth/login", a.login)
+	mux.HandleFunc("POST /auth/logout", a.logout)
+	mux.HandleFunc("GET /auth/me", a.me)
+	mux.HandleFunc("/auth/", func(w http.ResponseWriter, r *http.Request) {
+		writeProblem(w, r, http.StatusNotFound, "no resource at "+r.URL.Path)
+	})
+	return mux
+}
+
+func (a *Authenticator) token(w http.ResponseWriter, r *http.Request) {
+	u, ok := a.checkCredentials(w, r)
+	if !ok {
+		return
+	}
+	pair, err := a.tokens.Issue(u)
+	if err != nil {
+		a.serverError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, pair)
+}
+
+func (a *Authenticator) refresh(w http.ResponseWriter, r *http.Request) {
+	var req RefreshRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	pair, err := a.tokens.Refresh(req.RefreshToken, func(id string) (*User, error) {
+		return a.users.Get(r.Context(), id)
+	})
+	switch {
+	case errors.Is(err, errs.ErrUnauthenticated), errors.Is(err, errs.ErrNotFound):
+		unauthorized(w, r, "invalid_token", err.Error())
+	case err != nil:
+		a.serverError(w, r, err)
+	default:
+		writeJSON(w, http.StatusOK, pair)
+	}
+}
+
+func (a *Authenticator) login(w http.ResponseWriter, r *http.Request) {
+	u, ok := a.checkCredentials(w, r)
+	if !ok {
+		return
+	}
+	a.sessions.End(w, r)
+	sess, err := a.sessions.Start(w, u)
+	if err != nil {
+		a.serverError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, a.describe(u, sess.CSRFToken))
+}
+
+func (a *Authenticator) logout(w http.ResponseWriter, r *http.Request) {
+	u, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	if r.ContentLength != 0 {
+		var req RefreshRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		err := a.tokens.Revoke(req.RefreshToken, u.ID)
+		switch {
+		case errors.Is(err, errs.ErrPermissionDenied):
+			writeProblem(w, r, http.StatusForbidden, err.Error())
+			return
+		case err != nil:
+			unauthorized(w, r, "invalid_token", err.Error())
+			return
+		}
+	}
+	if _, ok := r.Context().Value(sessionKey{}).(Session); ok {
+		a.sessions.End(w, r)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *Authenticator) me(w http.ResponseWriter, r *http.Request) {
+	u, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	sess, _ := r.Context().Value(sessionKey{}).(Session)
+	writeJSON(w, http.StatusOK, a.describe(u, sess.CSRFToken))
+}
+
+func (a *Authenticator) describe(u *User, csrf string) Me {
+	perms := a.policy[u.Role]
+	if perms == nil {
+		perms = []Permission{}
+	}
+	return Me{User: *u, Permissions: perms, CSRFToken: csrf}
+}
+
+// dummyHash is checked against when there is no such user, so that
+// the answer takes as long as for a wrong password and does not tell
+// which names exist.
+var dummyHash = sync.OnceValues(func() (string, error) {
+	return HashPassword("no such user", DefaultParams)
+})
+
+// checkCredentials decodes a login and returns the user, or writes the
+// response itself. Unknown names and wrong passwords get the same 401.
+func (a *Authenticator) checkCredentials(w http.ResponseWriter, r *http.Request) (*User, bool) {
+	var c Credentials
+	if !decode(w, r, &c) {
+		return nil, false
+	}
+	u, hash, err := a.users.Lookup(r.Context(), c.Username)
+	switch {
+	case errors.Is(err, errs.ErrNotFound):
+		hash, err := dummyHash()
+		if err != nil {
+			a.serverError(w, r, err)
+			return nil, false
+		}
+		CheckPassword(hash, c.Password)
+	case err != nil:
+		a.serverError(w, r, err)
+		return nil, false
+	default:
+		if err = CheckPassword(hash, c.Password); err == nil {
+			return u, true
+		}
+		if !errors.Is(err, errs.ErrUnauthenticated) {
+			a.serverError(w, r, err)
+			return nil, false
+		}
+	}
+	unauthorized(w, r, "", "wrong user name or password")
+	return nil, false
+}
+
+// maxBodyBytes limits the bodies of the endpoints, which are small.
+const maxBodyBytes = 4 << 10
+
+// decode decodes a JSON request body into v, or writes a 400 or 415
+// problem and returns false.
+func decode(w http.ResponseWriter, r *http.Request, v any) bool {
+	if ct := r.Header.Get("Content-Type"); !strings.HasPrefix(ct, "application/json") {
+		writeProblem(w, r, http.StatusUnsupportedMediaType, "Content-Type must be application/json")
+		return false
+	}
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		writeProblem(w, r, http.StatusBadRequest, "body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+// ========================================
+// RESPONSES
+// ========================================
+
+// problem is an RFC 7807 problem, as the task API sends them.
+type problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+}
+
+func writeProblem(w http.ResponseWriter, r *http.Request, status int, detail string) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   detail,
+		Instance: r.URL.Path,
+	})
+}
+
+// unauthorized writes a 401 with the WWW-Authenticate challenge of RFC
+// 6750. code is its error code, empty when no token was sent.
+func unauthorized(w http.ResponseWriter, r *http.Request, code, detail string) {
+	challenge := `Bearer realm="api"`
+	if code != "" {
+		challenge += fmt.Sprintf(`, error=%q`, code)
+	}
+	w.Header().Set("WWW-Authenticate", challenge)
+	writeProblem(w, r, http.StatusUnauthorized, detail)
+}
+
+func (a *Authenticator) serverError(w http.ResponseWriter, r *http.Request, err error) {
+	a.logger.Printf("auth: %s %s: %v", r.Method, r.URL.Path, err)
+	writeProblem(w, r, http.StatusInternalServerError, "")
+}
+
+// writeJSON writes v as indented JSON. Everything the endpoints return
+// holds a secret or depends on who asks, so nothing may be cached.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(v)
+}
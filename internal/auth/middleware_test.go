@@ -0,0 +1,354 @@
+package auth_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"golang-learning-project/internal/auth"
+	"golang-learning-project/pkg/errs"
+)
+
+// syncBuffer collects the server log, which handlers write to from
+// their own goroutines.
+type syncBuffer struct {
+	mu sync.Mutex
+	b  strings.Builder
+}
+
+func (s *syncBuffer) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.b.Write(p)
+}
+
+func (s *syncBuffer) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.b.String()
+}
+
+// store is a UserStore whose users can be removed.
+type store struct {
+	*auth.MemoryUsers
+	mu      sync.Mutex
+	removed map[string]bool
+}
+
+func (s *store) Get(ctx context.Context, id string) (*auth.User, error) {
+	s.mu.Lock()
+	gone := s.removed[id]
+	s.mu.Unlock()
+	if gone {
+		return nil, errs.New(errs.ErrNotFound, "user %s", id)
+	}
+	return s.MemoryUsers.Get(ctx, id)
+}
+
+func (s *store) remove(id string) {
+	s.mu.Lock()
+	s.removed[id] = true
+	s.mu.Unlock()
+}
+
+// server is a test server with one user of each role, alice the admin,
+// bob the editor and carol the viewer. Their passwords are their names
+// with "-secret". Every task route answers 200 with the user's name
+// once Require lets it through.
+type server struct {
+	*httptest.Server
+	users    *store
+	ids      map[string]string
+	sessions *auth.Sessions
+	clock    *clock
+}
+
+func newServer(t *testing.T) *server {
+	t.Helper()
+	c := newClock()
+	users := &store{MemoryUsers: auth.NewMemoryUsers(), removed: make(map[string]bool)}
+	ids := make(map[string]string)
+	for name, role := range map[string]auth.Role{"alice": auth.RoleAdmin, "bob": auth.RoleEditor, "carol": auth.RoleViewer} {
+		u, err := users.Add(name, name+"-secret", role)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids[name] = u.ID
+	}
+	tokens := newTokens(t, c)
+	sessions := auth.NewSessions(false)
+	sessions.Now = c.Now
+
+	logs := new(syncBuffer)
+	a := auth.New(users, tokens, sessions, auth.DefaultPolicy, log.New(logs, "", 0))
+	whoami := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		u, _ := auth.UserFrom(r.Context())
+		io.WriteString(w, u.Name)
+	})
+	mux := http.NewServeMux()
+	mux.Handle("GET /tasks", a.Require(auth.PermReadTasks)(whoami))
+	mux.Handle("POST /tasks", a.Require(auth.PermWriteTasks)(whoami))
+	mux.Handle("DELETE /tasks/{id}", a.Require(auth.PermDeleteTasks)(whoami))
+	mux.Handle("/auth/", a.Handler())
+
+	srv := httptest.NewServer(a.Authenticate(mux))
+	t.Cleanup(func() {
+		srv.Close()
+		if logs.String() != "" {
+			t.Errorf("server log:\n%s", logs)
+		}
+	})
+	return &server{Server: srv, users: users, ids: ids, sessions: sessions, clock: c}
+}
+
+// request is one request to a server and what its response must be.
+type request struct {
+	method, path string
+	header       http.Header
+	body         string
+	status       int
+	contains     string
+}
+
+// do sends req with hc, or the server's client if hc is nil, and
+// returns the response with its body read.
+func (s *server) do(t *testing.T, hc *http.Client, req request) (*http.Response, string) {
+	t.Helper()
+	if hc == nil {
+		hc = s.Client()
+	}
+	r, err := http.NewRequest(req.method, s.URL+req.path, strings.NewReader(req.body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, values := range req.header {
+		r.Header[name] = values
+	}
+	if req.body != "" {
+		r.Header.Set("Content-Type", "application/json")
+	}
+	res, err := hc.Do(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	body, _ := io.ReadAll(res.Body)
+	if res.StatusCode != req.status || !strings.Contains(string(body), req.contains) {
+		t.Errorf("%s %s: %d %s\nwant %d with %q", req.method, req.path, res.StatusCode, body, req.status, req.contains)
+	}
+	return res, string(body)
+}
+
+// login logs name in at /auth/token and returns the pair.
+func (s *server) login(t *testing.T, name string) auth.TokenPair {
+	t.Helper()
+	_, body := s.do(t, nil, request{"POST", "/auth/token", nil, credentials(name, name+"-secret"), 200, "access_token"})
+	var pair auth.TokenPair
+	if err := json.Unmarshal([]byte(body), &pair); err != nil {
+		t.Fatal(err)
+	}
+	return pair
+}
+
+func credentials(name, password string) string {
+	b, _ := json.Marshal(auth.Credentials{Username: name, Password: password})
+	return string(b)
+}
+
+func bearer(token string) http.Header {
+	return http.Header{"Authorization": {"Bearer " + token}}
+}
+
+func TestRequire(t *testing.T) {
+	s := newServer(t)
+	alice, bob, carol := s.login(t, "alice"), s.login(t, "bob"), s.login(t, "carol")
+
+	for _, req := range []request{
+		{"GET", "/tasks", nil, "", 401, "log in first"},
+		{"GET", "/tasks", http.Header{"Authorization": {"Basic YWxpY2U6eA=="}}, "", 401, "must be a Bearer token"},
+		{"GET", "/tasks", bearer("nonsense"), "", 401, "invalid token"},
+		{"GET", "/tasks", bearer(alice.RefreshToken), "", 401, "refresh token used as access token"},
+
+		{"GET", "/tasks", bearer(carol.AccessToken), "", 200, "carol"},
+		{"POST", "/tasks", bearer(carol.AccessToken), "", 403, "role viewer does not have permission tasks:write"},
+		{"POST", "/tasks", bearer(bob.AccessToken), "", 200, "bob"},
+		{"DELETE", "/tasks/1", bearer(bob.AccessToken), "", 403, "role editor does not have permission tasks:delete"},
+		{"DELETE", "/tasks/1", bearer(alice.AccessToken), "", 200, "alice"},
+	} {
+		res, _ := s.do(t, nil, req)
+		challenge := res.Header.Get("WWW-Authenticate")
+		if (res.StatusCode == 401) != strings.HasPrefix(challenge, `Bearer realm="api"`) {
+			t.Errorf("%s %s: %d with WWW-Authenticate %q", req.method, req.path, res.StatusCode, challenge)
+		}
+	}
+
+	// An access token keeps its role until it expires
+	s.users.SetRole(s.ids["carol"], auth.RoleEditor)
+	s.do(t, nil, request{"POST", "/tasks", bearer(carol.AccessToken), "", 403, "role viewer"})
+	s.clock.Advance(16 * time.Minute)
+	s.do(t, nil, request{"GET", "/tasks", bearer(carol.AccessToken), "", 401, "token expired"})
+}
+
+func TestTokenEndpoints(t *testing.T) {
+	s := newServer(t)
+	for _, req := range []request{
+		{"POST", "/auth/token", nil, credentials("alice", "bob-secret"), 401, "wrong user name or password"},
+		{"POST", "/auth/token", nil, credentials("mallory", "alice-secret"), 401, "wrong user name or password"},
+		{"POST", "/auth/token", nil, `{"username":"alice","password":"alice-secret","scope":"all"}`, 400, "unknown field"},
+		{"POST", "/auth/token", http.Header{"Content-Type": {"text/plain"}}, "", 415, "application/json"},
+		{"GET", "/auth/nothing", nil, "", 404, "no resource at /auth/nothing"},
+		{"GET", "/auth/me", nil, "", 401, "log in first"},
+	} {
+		s.do(t, nil, req)
+	}
+
+	res, _ := s.do(t, nil, request{"POST", "/auth/token", nil, credentials("bob", "bob-secret"), 200, `"token_type": "Bearer"`})
+	if res.Header.Get("Cache-Control") != "no-store" {
+		t.Errorf("Cache-Control %q, want no-store", res.Header.Get("Cache-Control"))
+	}
+	bob := s.login(t, "bob")
+	s.do(t, nil, request{"GET", "/auth/me", bearer(bob.AccessToken), "", 200, `"tasks:read",`})
+
+	refresh := func(token string, status int, contains string) auth.TokenPair {
+		t.Helper()
+		_, body := s.do(t, nil, request{"POST", "/auth/refresh", nil, `{"refresh_token":"` + token + `"}`, status, contains})
+		var pair auth.TokenPair
+		json.Unmarshal([]byte(body), &pair)
+		return pair
+	}
+	next := refresh(bob.RefreshToken, 200, "access_token")
+	refresh(bob.RefreshToken, 401, "used twice")
+	refresh(next.RefreshToken, 401, "revoked")
+	refresh(bob.AccessToken, 401, "access token used as refresh token")
+
+	// A refresh picks up a new role
+	carol := s.login(t, "carol")
+	s.users.SetRole(s.ids["carol"], auth.RoleEditor)
+	promoted := refresh(carol.RefreshToken, 200, "access_token")
+	s.do(t, nil, request{"POST", "/tasks", bearer(promoted.AccessToken), "", 200, "carol"})
+
+	// A removed user cannot refresh
+	s.users.remove(s.ids["carol"])
+	refresh(promoted.RefreshToken, 401, "user "+s.ids["carol"])
+
+	// Logging out with a refresh token ends its login, but only the
+	// user's own
+	alice := s.login(t, "alice")
+	s.do(t, nil, request{"POST", "/auth/logout", nil, `{"refresh_token":"` + alice.RefreshToken + `"}`, 401, "log in first"})
+	bob = s.login(t, "bob")
+	s.do(t, nil, request{"POST", "/auth/logout", bearer(bob.AccessToken), `{"refresh_token":"` + alice.RefreshToken + `"}`, 403, "refresh token of another user"})
+	refresh(alice.RefreshToken, 200, "access_token")
+	alice = s.login(t, "alice")
+	s.do(t, nil, request{"POST", "/auth/logout", bearer(alice.AccessToken), `{"refresh_token":"` + alice.RefreshToken + `"}`, 204, ""})
+	refresh(alice.RefreshToken, 401, "revoked")
+}
+
+// browser returns a client that keeps cookies, logged in as name, and
+// the session's CSRF token.
+func (s *server) browser(t *testing.T, name string) (*http.Client, string) {
+	t.Helper()
+	jar, _ := cookiejar.New(nil)
+	hc := &http.Client{Jar: jar}
+	res, body := s.do(t, hc, request{"POST", "/auth/login", nil, credentials(name, name+"-secret"), 200, `"csrf_token"`})
+	// Login first clears any cookie there was, then sets the new one
+	cookies := res.Header.Values("Set-Cookie")
+	cookie := cookies[len(cookies)-1]
+	for _, attr := range []string{"HttpOnly", "SameSite=Lax", "Path=/", "Max-Age=43200"} {
+		if !strings.Contains(cookie, attr) {
+			t.Errorf("Set-Cookie %q, want %s", cookie, attr)
+		}
+	}
+	var me auth.Me
+	if err := json.Unmarshal([]byte(body), &me); err != nil || me.CSRFToken == "" || me.User.Name != name {
+		t.Fatalf("login: %s (%v)", body, err)
+	}
+	return hc, me.CSRFToken
+}
+
+func TestSessionCSRF(t *testing.T) {
+	s := newServer(t)
+	hc, csrf := s.browser(t, "bob")
+	with := func(token string) http.Header { return http.Header{auth.CSRFHeader: {token}} }
+
+	for _, req := range []request{
+		{"GET", "/tasks", nil, "", 200, "bob"},
+		{"GET", "/auth/me", nil, "", 200, `"csrf_token": "` + csrf + `"`},
+		{"POST", "/tasks", nil, "", 403, "a session needs its CSRF token in X-CSRF-Token"},
+		{"POST", "/tasks", with(csrf[1:] + "x"), "", 403, "CSRF"},
+		{"POST", "/tasks", with(strings.ToUpper(csrf)), "", 403, "CSRF"},
+		{"POST", "/tasks", with(csrf), "", 200, "bob"},
+		{"DELETE", "/tasks/1", with(csrf), "", 403, "tasks:delete"},
+		{"POST", "/auth/logout", nil, "", 403, "CSRF"},
+		{"POST", "/auth/logout", with(csrf), "", 204, ""},
+		{"GET", "/tasks", nil, "", 401, "log in first"},
+	} {
+		s.do(t, hc, req)
+	}
+
+	// A bearer token takes precedence over the cookie
+	hc, _ = s.browser(t, "bob")
+	carol := s.login(t, "carol")
+	s.do(t, hc, request{"GET", "/tasks", bearer(carol.AccessToken), "", 200, "carol"})
+}
+
+// TestSessionUser checks that a session follows its user: a new role
+// applies to the next request, and a removed user is logged out.
+func TestSessionUser(t *testing.T) {
+	s := newServer(t)
+	hc, csrf := s.browser(t, "carol")
+	post := request{"POST", "/tasks", http.Header{auth.CSRFHeader: {csrf}}, "", 403, "role viewer"}
+	s.do(t, hc, post)
+
+	s.users.SetRole(s.ids["carol"], auth.RoleEditor)
+	post.status, post.contains = 200, "carol"
+	s.do(t, hc, post)
+	s.do(t, hc, request{"GET", "/auth/me", nil, "", 200, `"role": "editor"`})
+
+	s.users.remove(s.ids["carol"])
+	s.do(t, hc, request{"GET", "/tasks", nil, "", 401, "log in first"})
+	if n := s.sessions.Len(); n != 0 {
+		t.Errorf("%d sessions kept after the user was removed", n)
+	}
+}
+
+func TestSessionExpiry(t *testing.T) {
+	s := newServer(t)
+	hc, _ := s.browser(t, "bob")
+	list := func(status int) {
+		t.Helper()
+		s.do(t, hc, request{"GET", "/tasks", nil, "", status, ""})
+	}
+
+	// Each request restarts the idle timeout, up to the 12 hour TTL
+	for range 24 {
+		s.clock.Advance(29 * time.Minute)
+		list(200)
+	}
+	s.clock.Advance(29 * time.Minute)
+	list(401)
+
+	hc, _ = s.browser(t, "bob")
+	s.clock.Advance(30 * time.Minute)
+	list(401)
+
+	// A new login forgets the sessions that ended unseen
+	for range 3 {
+		s.browser(t, "carol")
+	}
+	if n := s.sessions.Len(); n != 3 {
+		t.Fatalf("%d sessions, want 3", n)
+	}
+	s.clock.Advance(time.Hour)
+	s.browser(t, "carol")
+	if n := s.sessions.Len(); n != 1 {
+		t.Errorf("%d sessions after the others expired, want 1", n)
+	}
+}
@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+
+	"golang-learning-project/pkg/errs"
+)
+
+// Params are the cost of an argon2id hash. Raising them makes every
+// guess at a stolen hash slower, and every login too.
+type Params struct {
+	Memory  uint32 // KiB
+	Time    uint32 // passes over the memory
+	Threads uint8
+	SaltLen uint32 // bytes
+	KeyLen  uint32 // bytes
+}
+
+// DefaultParams are OWASP's recommended minimum for argon2id: 19 MiB,
+// two passes, one thread.
+var DefaultParams = Params{Memory: 19 * 1024, Time: 2, Threads: 1, SaltLen: 16, KeyLen: 32}
+
+// HashPassword hashes password with a fresh random salt. The result is
+// in the PHC string format and carries its own parameters, so hashes
+// made with different Params can be checked side by side:
+//
+//	$argon2id$v=19$m=19456,t=2,p=1$<salt>$<key>
+func HashPassword(password string, p Params) (string, error) {
+	salt := make([]byte, p.SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", errs.Wrap(err, "password salt")
+	}
+	key := argon2.IDKey([]byte(password), salt, p.Time, p.Memory, p.Threads, p.KeyLen)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s", argon2.Version, p.Memory, p.Time, p.Threads,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// CheckPassword reports whether password is the one hashed. A wrong
+// password matches errs.ErrUnauthenticated, a malformed hash
+// errs.ErrInvalidArgument. The comparison takes the same time however
+// much of the key matches.
+func CheckPassword(hash, password string) error {
+	p, salt, key, err := decodeHash(hash)
+	if err != nil {
+		return err
+	}
+	got := argon2.IDKey([]byte(password), salt, p.Time, p.Memory, p.Threads, uint32(len(key)))
+	if subtle.ConstantTimeCompare(got, key) != 1 {
+		return errs.New(errs.ErrUnauthenticated, "wrong password")
+	}
+	return nil
+}
+
+func decodeHash(hash string) (p Params, salt, key []byte, err error) {
+	bad := func(what string) error {
+		return errs.New(errs.ErrInvalidArgument, "password hash: %s", what)
+	}
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[0] != "" || parts[1] != "argon2id" {
+		return p, nil, nil, bad("not an argon2id hash")
+	}
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return p, nil, nil, bad("unsupported version " + parts[2])
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.Memory, &p.Time, &p.Threads); err != nil ||
+		p.Memory == 0 || p.Time == 0 || p.Threads == 0 {
+		return p, nil, nil, bad("invalid parameters " + parts[3])
+	}
+	if salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil || len(salt) == 0 {
+		return p, nil, nil, bad("invalid salt")
+	}
+	if key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil || len(key) == 0 {
+		return p, nil, nil, bad("invalid key")
+	}
+	return p, salt, key, nil
+}
@@ -0,0 +1,70 @@
+package auth_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"golang-learning-project/internal/auth"
+	"golang-learning-project/pkg/errs"
+)
+
+// cheap keeps the tests fast; the format and the checks do not depend
+// on the cost.
+var cheap = auth.Params{Memory: 64, Time: 1, Threads: 1, SaltLen: 16, KeyLen: 32}
+
+func hash(t *testing.T, password string, p auth.Params) string {
+	t.Helper()
+	h, err := auth.HashPassword(password, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return h
+}
+
+func TestHashPassword(t *testing.T) {
+	h1, h2 := hash(t, "hunter2", auth.DefaultParams), hash(t, "hunter2", auth.DefaultParams)
+	if !strings.HasPrefix(h1, "$argon2id$v=19$m=19456,t=2,p=1$") {
+		t.Errorf("hash %s, want argon2id with the default parameters", h1)
+	}
+	if h1 == h2 {
+		t.Errorf("two hashes of one password are both %s; the salt is not random", h1)
+	}
+
+	tests := []struct {
+		hash, password string
+		want           error
+	}{
+		{h1, "hunter2", nil},
+		{h2, "hunter2", nil},
+		{h1, "Hunter2", errs.ErrUnauthenticated},
+		{h1, "", errs.ErrUnauthenticated},
+		// A hash carries its parameters, so a cheaper one still checks
+		{hash(t, "hunter2", cheap), "hunter2", nil},
+		{hash(t, "hunter2", cheap), "hunter3", errs.ErrUnauthenticated},
+	}
+	for _, tt := range tests {
+		if err := auth.CheckPassword(tt.hash, tt.password); !errors.Is(err, tt.want) || (tt.want == nil) != (err == nil) {
+			t.Errorf("CheckPassword(%s, %q) = %v, want %v", tt.hash, tt.password, err, tt.want)
+		}
+	}
+}
+
+func TestCheckPasswordMalformed(t *testing.T) {
+	for _, h := range []string{
+		"",
+		"hunter2",
+		"$2a$10$abcdefghijklmnopqrstuu",
+		"$argon2i$v=19$m=64,t=1,p=1$c2FsdA$a2V5",
+		"$argon2id$v=16$m=64,t=1,p=1$c2FsdA$a2V5",
+		"$argon2id$v=19$m=0,t=0,p=0$c2FsdA$a2V5",
+		"$argon2id$v=19$m=64,t=1,p=1$$a2V5",
+		"$argon2id$v=19$m=64,t=1,p=1$c2FsdA$",
+		"$argon2id$v=19$m=64,t=1,p=1$!!$a2V5",
+		"$argon2id$v=19$m=64,t=1,p=1$c2FsdA$a2V5$extra",
+	} {
+		if err := auth.CheckPassword(h, "x"); !errors.Is(err, errs.ErrInvalidArgument) {
+			t.Errorf("CheckPassword(%q) = %v, want an invalid hash", h, err)
+		}
+	}
+}
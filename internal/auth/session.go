@@ -0,0 +1,158 @@
+package auth
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// CSRFHeader is the request header that must carry a session's CSRF
+// token when the session cookie authenticates an unsafe request.
+const CSRFHeader = "X-CSRF-Token"
+
+// Session is one browser's login. It holds the user's ID only:
+// Authenticate looks the user up on every request, so a changed role
+// takes effect at once and a removed user is logged out.
+type Session struct {
+	ID        string
+	UserID    string
+	CSRFToken string // a secret the page holds and a forged request cannot know
+	Created   time.Time
+	LastSeen  time.Time
+}
+
+// Sessions keeps sessions in memory and ties them to a cookie. A
+// session ends when it has been idle for IdleTimeout, TTL after it
+// started, or when the user logs out. Ended sessions are forgotten when
+// they are next looked up, or when a new one starts.
+//
+// The cookie is HttpOnly, so scripts cannot read it, and SameSite=Lax,
+// so other sites' forms and scripts do not send it with unsafe
+// requests. The CSRF token covers older browsers and same-site
+// attackers, and must accompany every POST, PUT, PATCH and DELETE.
+type Sessions struct {
+	CookieName  string        // default "session"
+	TTL         time.Duration // default 12 hours
+	IdleTimeout time.Duration // default 30 minutes
+	Secure      bool          // send the cookie over HTTPS only
+	Now         func() time.Time
+
+	mu   sync.Mutex
+	byID map[string]*Session
+}
+
+// NewSessions returns an empty session store. secure should be false
+// only for a server on plain HTTP, such as one in development.
+func NewSessions(secure bool) *Sessions {
+	return &Sessions{
+		CookieName:  "session",
+		TTL:         12 * time.Hour,
+		IdleTimeout: 30 * time.Minute,
+		Secure:      secure,
+		Now:         time.Now,
+		byID:        make(map[string]*Session),
+	}
+}
+
+// Start starts a session for u and sets its cookie. Every login gets a
+// new session ID, so one planted in the browser beforehand is useless.
+func (s *Sessions) Start(w http.ResponseWriter, u *User) (Session, error) {
+	id, err := randomToken(32)
+	if err != nil {
+		return Session{}, err
+	}
+	csrf, err := randomToken(32)
+	if err != nil {
+		return Session{}, err
+	}
+	now := s.Now()
+	sess := &Session{ID: id, UserID: u.ID, CSRFToken: csrf, Created: now, LastSeen: now}
+
+	s.mu.Lock()
+	// Forget the sessions that ended without being looked up again
+	for id, old := range s.byID {
+		if s.expired(old, now) {
+			delete(s.byID, id)
+		}
+	}
+	s.byID[sess.ID] = sess
+	s.mu.Unlock()
+
+	http.SetCookie(w, s.cookie(sess.ID, int(s.TTL/time.Second)))
+	return *sess, nil
+}
+
+// expired reports whether sess has ended by now.
+func (s *Sessions) expired(sess *Session, now time.Time) bool {
+	return now.Sub(sess.LastSeen) >= s.IdleTimeout || now.Sub(sess.Created) >= s.TTL
+}
+
+// Len returns the number of sessions kept, ended ones included until
+// they are forgotten.
+func (s *Sessions) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.byID)
+}
+
+// Lookup returns the session of a request's cookie, if it has a live
+// one, and marks it as seen.
+func (s *Sessions) Lookup(r *http.Request) (Session, bool) {
+	c, err := r.Cookie(s.CookieName)
+	if err != nil {
+		return Session{}, false
+	}
+	now := s.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess := s.byID[c.Value]
+	if sess == nil {
+		return Session{}, false
+	}
+	if s.expired(sess, now) {
+		delete(s.byID, sess.ID)
+		return Session{}, false
+	}
+	sess.LastSeen = now
+	return *sess, true
+}
+
+// End ends the session of a request's cookie, if any, and clears the
+// cookie.
+func (s *Sessions) End(w http.ResponseWriter, r *http.Request) {
+	if c, err := r.Cookie(s.CookieName); err == nil {
+		s.remove(c.Value)
+	}
+	http.SetCookie(w, s.cookie("", -1))
+}
+
+func (s *Sessions) remove(id string) {
+	s.mu.Lock()
+	delete(s.byID, id)
+	s.mu.Unlock()
+}
+
+func (s *Sessions) cookie(value string, maxAge int) *http.Cookie {
+	return &http.Cookie{
+		Name:     s.CookieName,
+		Value:    value,
+		Path:     "/",
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   s.Secure,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+// checkCSRF reports whether r carries sess's CSRF token, or needs none
+// because its method is safe.
+func checkCSRF(r *http.Request, sess Session) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	got := r.Header.Get(CSRFHeader)
+	return got != "" && subtle.ConstantTimeCompare([]byte(got), []byte(sess.CSRFToken)) == 1
+}
@@ -0,0 +1,343 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"golang-learning-project/pkg/errs"
+)
+
+// TokenUse tells access tokens and refresh tokens apart, so that one
+// cannot stand in for the other.
+type TokenUse string
+
+const (
+	AccessToken  TokenUse = "access"
+	RefreshToken TokenUse = "refresh"
+)
+
+// MinKeyLen is the shortest signing key accepted: HS256 needs a key as
+// long as its hash.
+const MinKeyLen = 32
+
+// Claims are the payload of a token.
+type Claims struct {
+	Issuer    string   `json:"iss"`
+	Subject   string   `json:"sub"` // the user's ID
+	Name      string   `json:"name"`
+	Role      Role     `json:"role"`
+	Use       TokenUse `json:"use"`
+	ID        string   `json:"jti"`
+	Family    string   `json:"fam,omitempty"` // refresh tokens: the login they descend from
+	IssuedAt  int64    `json:"iat"`
+	ExpiresAt int64    `json:"exp"`
+}
+
+// User returns the user the claims describe.
+func (c *Claims) User() *User {
+	return &User{ID: c.Subject, Name: c.Name, Role: c.Role}
+}
+
+// TokenPair is what a login or a refresh returns, in the shape of an
+// OAuth 2.0 token response.
+type TokenPair struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"` // always Bearer
+	ExpiresIn    int    `json:"expires_in"` // seconds until the access token expires
+}
+
+// Tokens issues and checks JWTs signed with HMAC-SHA256, and keeps
+// track of refresh tokens.
+//
+// Refresh tokens rotate: each one can be used once, and the new pair it
+// buys carries a new refresh token of the same family. Using one a
+// second time means two parties hold it, one of them a thief, so the
+// whole family is revoked and both must log in again.
+//
+// Signing keys rotate too. RotateKey starts signing with a new key while
+// tokens signed with the old one still verify; RetireKey ends that once
+// they have expired. The key ID is in each token's "kid" header.
+type Tokens struct {
+	Issuer     string
+	AccessTTL  time.Duration // default 15 minutes
+	RefreshTTL time.Duration // default 7 days
+	Now        func() time.Time
+
+	mu       sync.Mutex
+	keys     map[string][]byte
+	current  string
+	families map[string]*family
+}
+
+// family is the state of one login's chain of refresh tokens.
+type family struct {
+	current string // the ID of the one refresh token that may be used
+	expires time.Time
+	revoked bool
+}
+
+// NewTokens returns Tokens that sign with key, named keyID.
+func NewTokens(issuer, keyID string, key []byte) (*Tokens, error) {
+	t := &Tokens{
+		Issuer:     issuer,
+		AccessTTL:  15 * time.Minute,
+		RefreshTTL: 7 * 24 * time.Hour,
+		Now:        time.Now,
+		keys:       make(map[string][]byte),
+		families:   make(map[string]*family),
+	}
+	if err := t.RotateKey(keyID, key); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// RotateKey signs new tokens with key from now on. Earlier keys still
+// verify until they are retired.
+func (t *Tokens) RotateKey(keyID string, key []byte) error {
+	switch {
+	case keyID == "":
+		return errs.New(errs.ErrInvalidArgument, "key ID must not be empty")
+	case len(key) < MinKeyLen:
+		return errs.New(errs.ErrInvalidArgument, "key %s is %d bytes; it needs at least %d", keyID, len(key), MinKeyLen)
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.keys[keyID]; ok {
+		return errs.New(errs.ErrAlreadyExists, "key %s", keyID)
+	}
+	t.keys[keyID] = key
+	t.current = keyID
+	return nil
+}
+
+// RetireKey stops accepting tokens signed with a key. The key that
+// signs new tokens cannot be retired.
+func (t *Tokens) RetireKey(keyID string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	switch {
+	case t.keys[keyID] == nil:
+		return errs.New(errs.ErrNotFound, "key %s", keyID)
+	case keyID == t.current:
+		return errs.New(errs.ErrConflict, "key %s signs new tokens; rotate it first", keyID)
+	}
+	delete(t.keys, keyID)
+	return nil
+}
+
+// Issue returns a new pair of tokens for u, starting a new family of
+// refresh tokens.
+func (t *Tokens) Issue(u *User) (*TokenPair, error) {
+	fam, err := randomToken(16)
+	if err != nil {
+		return nil, err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// Forget the families no token of which is still valid
+	now := t.Now()
+	for id, f := range t.families {
+		if now.After(f.expires) {
+			delete(t.families, id)
+		}
+	}
+	f := &family{}
+	t.families[fam] = f
+	return t.issue(u, fam, f)
+}
+
+// issue signs a pair for u in the family fam, whose state is f. t.mu
+// must be held.
+func (t *Tokens) issue(u *User, fam string, f *family) (*TokenPair, error) {
+	accessID, err := randomToken(16)
+	if err != nil {
+		return nil, err
+	}
+	refreshID, err := randomToken(16)
+	if err != nil {
+		return nil, err
+	}
+	now := t.Now()
+	access := Claims{
+		Issuer:    t.Issuer,
+		Subject:   u.ID,
+		Name:      u.Name,
+		Role:      u.Role,
+		Use:       AccessToken,
+		ID:        accessID,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(t.AccessTTL).Unix(),
+	}
+	refresh := access
+	refresh.Use = RefreshToken
+	refresh.ID = refreshID
+	refresh.Family = fam
+	refresh.ExpiresAt = now.Add(t.RefreshTTL).Unix()
+
+	f.current = refresh.ID
+	f.expires = time.Unix(refresh.ExpiresAt, 0)
+
+	return &TokenPair{
+		AccessToken:  t.sign(&access),
+		RefreshToken: t.sign(&refresh),
+		TokenType:    "Bearer",
+		ExpiresIn:    int(t.AccessTTL / time.Second),
+	}, nil
+}
+
+// header is a token's JOSE header.
+type header struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	Typ string `json:"typ"`
+}
+
+// sign encodes and signs c with the current key. t.mu must be held.
+func (t *Tokens) sign(c *Claims) string {
+	h, _ := json.Marshal(header{Alg: "HS256", Kid: t.current, Typ: "JWT"})
+	p, _ := json.Marshal(c)
+	msg := base64.RawURLEncoding.EncodeToString(h) + "." + base64.RawURLEncoding.EncodeToString(p)
+	return msg + "." + base64.RawURLEncoding.EncodeToString(mac(t.keys[t.current], msg))
+}
+
+func mac(key []byte, msg string) []byte {
+	m := hmac.New(sha256.New, key)
+	m.Write([]byte(msg))
+	return m.Sum(nil)
+}
+
+// Verify checks a token's signature, issuer, use and expiry, and
+// returns its claims. Only HS256 is accepted: a token cannot choose a
+// weaker algorithm, or none, by saying so in its header.
+func (t *Tokens) Verify(token string, use TokenUse) (*Claims, error) {
+	invalid := func(why string) error {
+		return errs.New(errs.ErrUnauthenticated, "invalid token: %s", why)
+	}
+
+	msg, sig64, ok := cutLast(token, ".")
+	h64, p64, ok2 := strings.Cut(msg, ".")
+	if !ok || !ok2 {
+		return nil, invalid("not a JWT")
+	}
+	var h header
+	if err := decodeSegment(h64, &h); err != nil {
+		return nil, invalid("header: " + err.Error())
+	}
+	if h.Alg != "HS256" {
+		return nil, invalid("algorithm " + h.Alg)
+	}
+	t.mu.Lock()
+	key := t.keys[h.Kid]
+	t.mu.Unlock()
+	if key == nil {
+		return nil, invalid("unknown key " + h.Kid)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(sig64)
+	if err != nil || !hmac.Equal(sig, mac(key, msg)) {
+		return nil, invalid("bad signature")
+	}
+
+	var c Claims
+	if err := decodeSegment(p64, &c); err != nil {
+		return nil, invalid("claims: " + err.Error())
+	}
+	switch {
+	case c.Issuer != t.Issuer:
+		return nil, invalid("issuer " + c.Issuer)
+	case c.Use != use:
+		return nil, invalid(string(c.Use) + " token used as " + string(use) + " token")
+	case t.Now().Unix() >= c.ExpiresAt:
+		return nil, errs.New(errs.ErrUnauthenticated, "token expired")
+	}
+	return &c, nil
+}
+
+func decodeSegment(s string, v any) error {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+func cutLast(s, sep string) (before, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
+
+// Refresh uses up a refresh token and returns a new pair in its family,
+// for the user get returns now: a changed role takes effect, and a
+// removed user cannot refresh. A token used before revokes its family.
+//
+// get may be slow, as a database is, so it is called without holding
+// t.mu; the family is checked again afterwards, in case the token was
+// spent or revoked meanwhile.
+func (t *Tokens) Refresh(token string, get func(id string) (*User, error)) (*TokenPair, error) {
+	c, err := t.Verify(token, RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+	t.mu.Lock()
+	_, err = t.spend(c)
+	t.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	u, getErr := get(c.Subject)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	f, err := t.spend(c)
+	switch {
+	case err != nil:
+		return nil, err
+	case getErr != nil:
+		f.revoked = true
+		return nil, errs.Wrap(getErr, "refresh")
+	}
+	return t.issue(u, c.Family, f)
+}
+
+// spend returns the family of the refresh token c if c may be used, and
+// revokes the family if c has been used already. t.mu must be held.
+func (t *Tokens) spend(c *Claims) (*family, error) {
+	f := t.families[c.Family]
+	switch {
+	case f == nil || f.revoked:
+		return nil, errs.New(errs.ErrUnauthenticated, "refresh token revoked")
+	case f.current != c.ID:
+		f.revoked = true
+		return nil, errs.New(errs.ErrUnauthenticated, "refresh token used twice; every token of its login is revoked")
+	}
+	return f, nil
+}
+
+// Revoke ends the family of a refresh token, as logging out does. Only
+// the user the token was issued to may revoke it; for anyone else it
+// returns errs.ErrPermissionDenied and leaves the token alone.
+func (t *Tokens) Revoke(token, userID string) error {
+	c, err := t.Verify(token, RefreshToken)
+	if err != nil {
+		return err
+	}
+	if c.Subject != userID {
+		return errs.New(errs.ErrPermissionDenied, "refresh token of another user")
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if f := t.families[c.Family]; f != nil {
+		f.revoked = true
+	}
+	return nil
+}
@@ -0,0 +1,330 @@
+package auth_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"golang-learning-project/internal/auth"
+	"golang-learning-project/pkg/errs"
+)
+
+// clock is a time that tests move forward by hand.
+type clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newClock() *clock {
+	return &clock{now: time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)}
+}
+
+func (c *clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func key(b byte) []byte {
+	return []byte(strings.Repeat(string(b), auth.MinKeyLen))
+}
+
+func newTokens(t *testing.T, c *clock) *auth.Tokens {
+	t.Helper()
+	tokens, err := auth.NewTokens("test", "k1", key('1'))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokens.Now = c.Now
+	return tokens
+}
+
+func issue(t *testing.T, tokens *auth.Tokens, u *auth.User) *auth.TokenPair {
+	t.Helper()
+	pair, err := tokens.Issue(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pair
+}
+
+var alice = &auth.User{ID: "u1", Name: "alice", Role: auth.RoleEditor}
+
+// forge returns token with its claims changed by edit, and its header
+// replaced by header unless that is empty. The signature is kept, or
+// dropped with a new header.
+func forge(token, header string, edit func(claims map[string]any)) string {
+	parts := strings.Split(token, ".")
+	payload, _ := base64.RawURLEncoding.DecodeString(parts[1])
+	var claims map[string]any
+	json.Unmarshal(payload, &claims)
+	edit(claims)
+	payload, _ = json.Marshal(claims)
+	parts[1] = base64.RawURLEncoding.EncodeToString(payload)
+	if header != "" {
+		parts[0] = base64.RawURLEncoding.EncodeToString([]byte(header))
+		parts[2] = ""
+	}
+	return strings.Join(parts, ".")
+}
+
+func TestVerify(t *testing.T) {
+	c := newClock()
+	tokens := newTokens(t, c)
+	pair := issue(t, tokens, alice)
+
+	claims, err := tokens.Verify(pair.AccessToken, auth.AccessToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *claims.User() != *alice || claims.Issuer != "test" || claims.ExpiresAt != c.Now().Add(15*time.Minute).Unix() {
+		t.Errorf("claims %+v", claims)
+	}
+	if pair.TokenType != "Bearer" || pair.ExpiresIn != 900 {
+		t.Errorf("pair %+v", pair)
+	}
+
+	other, err := auth.NewTokens("other", "k1", key('1'))
+	if err != nil {
+		t.Fatal(err)
+	}
+	foreign := issue(t, other, alice)
+
+	tests := []struct {
+		name   string
+		token  string
+		use    auth.TokenUse
+		detail string
+	}{
+		{"forged role", forge(pair.AccessToken, "", func(c map[string]any) { c["role"] = "admin" }), auth.AccessToken, "bad signature"},
+		{"forged expiry", forge(pair.AccessToken, "", func(c map[string]any) { c["exp"] = 1 << 40 }), auth.AccessToken, "bad signature"},
+		{"alg none", forge(pair.AccessToken, `{"alg":"none","kid":"k1","typ":"JWT"}`, func(map[string]any) {}), auth.AccessToken, "algorithm none"},
+		{"alg HS512", forge(pair.AccessToken, `{"alg":"HS512","kid":"k1","typ":"JWT"}`, func(map[string]any) {}), auth.AccessToken, "algorithm HS512"},
+		{"unknown key", forge(pair.AccessToken, `{"alg":"HS256","kid":"k9","typ":"JWT"}`, func(map[string]any) {}), auth.AccessToken, "unknown key k9"},
+		{"refresh as access", pair.RefreshToken, auth.AccessToken, "refresh token used as access token"},
+		{"access as refresh", pair.AccessToken, auth.RefreshToken, "access token used as refresh token"},
+		{"another issuer", foreign.AccessToken, auth.AccessToken, "issuer other"},
+		{"not a JWT", "hello", auth.AccessToken, "not a JWT"},
+		{"bad header", "!!.e30.", auth.AccessToken, "header"},
+		{"empty", "", auth.AccessToken, "not a JWT"},
+	}
+	for _, tt := range tests {
+		if _, err := tokens.Verify(tt.token, tt.use); !errors.Is(err, errs.ErrUnauthenticated) || !strings.Contains(err.Error(), tt.detail) {
+			t.Errorf("%s: Verify = %v, want unauthenticated with %q", tt.name, err, tt.detail)
+		}
+	}
+
+	c.Advance(15*time.Minute - time.Second)
+	if _, err := tokens.Verify(pair.AccessToken, auth.AccessToken); err != nil {
+		t.Errorf("Verify just before expiry: %v", err)
+	}
+	c.Advance(time.Second)
+	if _, err := tokens.Verify(pair.AccessToken, auth.AccessToken); err == nil || !strings.Contains(err.Error(), "token expired") {
+		t.Errorf("Verify at expiry: %v, want expired", err)
+	}
+	if _, err := tokens.Verify(pair.RefreshToken, auth.RefreshToken); err != nil {
+		t.Errorf("refresh token expired with the access token: %v", err)
+	}
+}
+
+func TestKeyRotation(t *testing.T) {
+	tokens := newTokens(t, newClock())
+	old := issue(t, tokens, alice)
+
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"empty ID", tokens.RotateKey("", key('2')), errs.ErrInvalidArgument},
+		{"short key", tokens.RotateKey("k2", key('2')[1:]), errs.ErrInvalidArgument},
+		{"reused ID", tokens.RotateKey("k1", key('2')), errs.ErrAlreadyExists},
+		{"retire the current key", tokens.RetireKey("k1"), errs.ErrConflict},
+		{"retire an unknown key", tokens.RetireKey("k9"), errs.ErrNotFound},
+	}
+	for _, tt := range tests {
+		if !errors.Is(tt.err, tt.want) {
+			t.Errorf("%s: %v, want %v", tt.name, tt.err, tt.want)
+		}
+	}
+
+	if err := tokens.RotateKey("k2", key('2')); err != nil {
+		t.Fatal(err)
+	}
+	fresh := issue(t, tokens, alice)
+	for name, token := range map[string]string{"old key": old.AccessToken, "new key": fresh.AccessToken} {
+		if _, err := tokens.Verify(token, auth.AccessToken); err != nil {
+			t.Errorf("Verify of a token signed with the %s: %v", name, err)
+		}
+	}
+	if err := tokens.RetireKey("k1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tokens.Verify(old.AccessToken, auth.AccessToken); err == nil || !strings.Contains(err.Error(), "unknown key k1") {
+		t.Errorf("Verify after the key retired: %v, want unknown key k1", err)
+	}
+	if _, err := tokens.Verify(fresh.AccessToken, auth.AccessToken); err != nil {
+		t.Errorf("Verify with the current key: %v", err)
+	}
+}
+
+// users is a get function for Refresh over a fixed set of users.
+func users(list ...*auth.User) func(id string) (*auth.User, error) {
+	return func(id string) (*auth.User, error) {
+		for _, u := range list {
+			if u.ID == id {
+				found := *u
+				return &found, nil
+			}
+		}
+		return nil, errs.New(errs.ErrNotFound, "user %s", id)
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	tokens := newTokens(t, newClock())
+	get := users(alice)
+	refresh := func(token string) (*auth.TokenPair, error) {
+		return tokens.Refresh(token, get)
+	}
+	first := issue(t, tokens, alice)
+
+	second, err := refresh(first.RefreshToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second.RefreshToken == first.RefreshToken || second.AccessToken == first.AccessToken {
+		t.Error("Refresh returned the same tokens")
+	}
+	third, err := refresh(second.RefreshToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The first token is replayed: the whole login is revoked, the
+	// newest token included
+	if _, err := refresh(first.RefreshToken); !errors.Is(err, errs.ErrUnauthenticated) || !strings.Contains(err.Error(), "used twice") {
+		t.Errorf("Refresh with a spent token: %v, want used twice", err)
+	}
+	if _, err := refresh(third.RefreshToken); err == nil || !strings.Contains(err.Error(), "revoked") {
+		t.Errorf("Refresh after a replay: %v, want revoked", err)
+	}
+
+	// Other logins are not affected
+	other := issue(t, tokens, alice)
+	if _, err := refresh(other.RefreshToken); err != nil {
+		t.Errorf("Refresh of another login: %v", err)
+	}
+
+	revoked := issue(t, tokens, alice)
+	if err := tokens.Revoke(revoked.RefreshToken, "u2"); !errors.Is(err, errs.ErrPermissionDenied) {
+		t.Errorf("Revoke by another user: %v, want permission denied", err)
+	}
+	if _, err := refresh(revoked.RefreshToken); err != nil {
+		t.Errorf("Refresh after another user's Revoke was refused: %v", err)
+	}
+	revoked = issue(t, tokens, alice)
+	if err := tokens.Revoke(revoked.RefreshToken, alice.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := refresh(revoked.RefreshToken); err == nil || !strings.Contains(err.Error(), "revoked") {
+		t.Errorf("Refresh after Revoke: %v, want revoked", err)
+	}
+	if err := tokens.Revoke(first.AccessToken, alice.ID); !errors.Is(err, errs.ErrUnauthenticated) {
+		t.Errorf("Revoke of an access token: %v, want unauthenticated", err)
+	}
+}
+
+func TestRefreshAsksForTheUser(t *testing.T) {
+	tokens := newTokens(t, newClock())
+	pair := issue(t, tokens, alice)
+
+	promoted := *alice
+	promoted.Role = auth.RoleAdmin
+	next, err := tokens.Refresh(pair.RefreshToken, users(&promoted))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c, _ := tokens.Verify(next.AccessToken, auth.AccessToken); c == nil || c.Role != auth.RoleAdmin {
+		t.Errorf("claims after a change of role: %+v, want admin", c)
+	}
+
+	// A removed user's login ends for good
+	if _, err := tokens.Refresh(next.RefreshToken, users()); !errors.Is(err, errs.ErrNotFound) {
+		t.Errorf("Refresh of a removed user: %v, want not found", err)
+	}
+	if _, err := tokens.Refresh(next.RefreshToken, users(alice)); err == nil || !strings.Contains(err.Error(), "revoked") {
+		t.Errorf("Refresh after the user was not found: %v, want revoked", err)
+	}
+}
+
+// TestRefreshUnlocked checks that get is called without the lock: it
+// may use the same Tokens, and what it does is seen.
+func TestRefreshUnlocked(t *testing.T) {
+	tokens := newTokens(t, newClock())
+	pair := issue(t, tokens, alice)
+	done := make(chan error, 1)
+	go func() {
+		_, err := tokens.Refresh(pair.RefreshToken, func(id string) (*auth.User, error) {
+			// The user logs out while the refresh waits for the store
+			if err := tokens.Revoke(pair.RefreshToken, alice.ID); err != nil {
+				return nil, err
+			}
+			return users(alice)(id)
+		})
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err == nil || !strings.Contains(err.Error(), "revoked") {
+			t.Errorf("Refresh revoked meanwhile: %v, want revoked", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Refresh holds its lock while it asks for the user")
+	}
+}
+
+// TestRefreshConcurrent spends one refresh token from many goroutines
+// at once. One may win; the rest are replays, which revoke the login,
+// the winner's new token included.
+func TestRefreshConcurrent(t *testing.T) {
+	tokens := newTokens(t, newClock())
+	pair := issue(t, tokens, alice)
+	pairs := make([]*auth.TokenPair, 8)
+	results := make([]error, len(pairs))
+	var wg sync.WaitGroup
+	for i := range pairs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			pairs[i], results[i] = tokens.Refresh(pair.RefreshToken, users(alice))
+		}()
+	}
+	wg.Wait()
+
+	won := 0
+	for i, err := range results {
+		switch {
+		case err == nil:
+			won++
+			if _, err := tokens.Refresh(pairs[i].RefreshToken, users(alice)); err == nil {
+				t.Error("the winner's new token still works after the replays")
+			}
+		case !errors.Is(err, errs.ErrUnauthenticated):
+			t.Errorf("Refresh: %v, want unauthenticated", err)
+		}
+	}
+	if won > 1 {
+		t.Errorf("%d refreshes with one token succeeded", won)
+	}
+}
@@ -0,0 +1,104 @@
+package auth
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"golang-learning-project/pkg/errs"
+)
+
+// UserStore finds the users who may log in.
+type UserStore interface {
+	// Lookup returns the user with the given name and their password
+	// hash, as made by HashPassword.
+	Lookup(ctx context.Context, name string) (*User, string, error)
+	// Get returns the user with the given ID. A refresh, and every
+	// request in a session, asks again, so that a new role or a removed
+	// user takes effect.
+	Get(ctx context.Context, id string) (*User, error)
+}
+
+// MemoryUsers is a UserStore in memory, safe for concurrent use.
+type MemoryUsers struct {
+	mu     sync.RWMutex
+	byName map[string]*account
+	byID   map[string]*account
+	lastID int
+}
+
+type account struct {
+	user User
+	hash string
+}
+
+var _ UserStore = (*MemoryUsers)(nil)
+
+func NewMemoryUsers() *MemoryUsers {
+	return &MemoryUsers{byName: make(map[string]*account), byID: make(map[string]*account)}
+}
+
+// Add adds a user, hashing the password with DefaultParams.
+func (m *MemoryUsers) Add(name, password string, role Role) (*User, error) {
+	switch {
+	case name == "" || password == "":
+		return nil, errs.New(errs.ErrInvalidArgument, "user name and password must not be empty")
+	case !role.valid():
+		return nil, errs.New(errs.ErrInvalidArgument, "role %q", role)
+	}
+	hash, err := HashPassword(password, DefaultParams)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.byName[name] != nil {
+		return nil, errs.New(errs.ErrAlreadyExists, "user %s", name)
+	}
+	m.lastID++
+	a := &account{user: User{ID: "u" + strconv.Itoa(m.lastID), Name: name, Role: role}, hash: hash}
+	m.byName[name] = a
+	m.byID[a.user.ID] = a
+	u := a.user
+	return &u, nil
+}
+
+// SetRole changes a user's role. Their sessions have it at once; their
+// access tokens keep the old role until they expire, and the next
+// refresh picks up the new one.
+func (m *MemoryUsers) SetRole(id string, role Role) error {
+	if !role.valid() {
+		return errs.New(errs.ErrInvalidArgument, "role %q", role)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	a := m.byID[id]
+	if a == nil {
+		return errs.New(errs.ErrNotFound, "user %s", id)
+	}
+	a.user.Role = role
+	return nil
+}
+
+func (m *MemoryUsers) Lookup(ctx context.Context, name string) (*User, string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	a := m.byName[name]
+	if a == nil {
+		return nil, "", errs.New(errs.ErrNotFound, "user %s", name)
+	}
+	u := a.user
+	return &u, a.hash, nil
+}
+
+func (m *MemoryUsers) Get(ctx context.Context, id string) (*User, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	a := m.byID[id]
+	if a == nil {
+		return nil, errs.New(errs.ErrNotFound, "user %s", id)
+	}
+	u := a.user
+	return &u, nil
+}
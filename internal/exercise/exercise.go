@@ -0,0 +1,321 @@
+// Package exercise runs the practice exercises ("katas") that accompany
+// the lessons.
+//
+// Each lesson with an exercise has a directory below exercises/ that
+// mirrors its path below examples/ and holds two files:
+//
+//	exercise.go  stub functions, with the signatures used in the lesson,
+//	             that the learner implements
+//	checks.go    hidden table-driven tests, excluded from the normal build
+//	             by the "kata" build tag
+//
+// The stubs are copied once into workspace/ (its own Go module, ignored by
+// git) where the learner edits them. Checking copies the learner's file
+// and the hidden tests into a temporary module and runs go test there, so
+// the tests never appear in the workspace.
+package exercise
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang-learning-project/internal/lessons"
+)
+
+const (
+	// Dir holds one exercise directory per lesson.
+	Dir = "exercises"
+
+	// WorkspaceDir is where stubs are copied for the learner to edit.
+	WorkspaceDir = "workspace"
+
+	// StubFile and ChecksFile are the file names inside an exercise dir.
+	StubFile   = "exercise.go"
+	ChecksFile = "checks.go"
+
+	// BuildTag keeps ChecksFile out of the repository's own build.
+	BuildTag = "kata"
+)
+
+// ErrNoExercise is returned by Load for lessons without an exercise.
+var ErrNoExercise = errors.New("lesson has no exercise")
+
+// Exercise is the exercise of one lesson.
+type Exercise struct {
+	Lesson    lessons.Lesson
+	Dir       string   // slash-separated and relative to the root
+	Workspace string   // learner's copy of StubFile, relative to the root
+	Functions []string // stub functions, in source order
+}
+
+// Load returns the exercise for l.
+func Load(root string, l lessons.Lesson) (Exercise, error) {
+	e := Exercise{
+		Lesson:    l,
+		Dir:       path.Join(Dir, l.ID),
+		Workspace: path.Join(WorkspaceDir, l.ID, StubFile),
+	}
+	stub := filepath.Join(root, filepath.FromSlash(e.Dir), StubFile)
+	if _, err := os.Stat(stub); os.IsNotExist(err) {
+		return Exercise{}, fmt.Errorf("%s: %w", l.ID, ErrNoExercise)
+	}
+
+	f, err := parser.ParseFile(token.NewFileSet(), stub, nil, parser.SkipObjectResolution)
+	if err != nil {
+		return Exercise{}, err
+	}
+	for _, decl := range f.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil {
+			e.Functions = append(e.Functions, fn.Name.Name)
+		}
+	}
+	return e, nil
+}
+
+// Prepare copies the stubs into the workspace unless the learner already
+// has a copy there, in which case it is kept; reset overwrites it. It
+// reports whether a fresh copy was written.
+func (e Exercise) Prepare(root string, reset bool) (bool, error) {
+	dst := filepath.Join(root, filepath.FromSlash(e.Workspace))
+	if _, err := os.Stat(dst); err == nil && !reset {
+		return false, nil
+	}
+
+	// The workspace is its own module, so the learner's half-finished
+	// code never breaks `go build ./...` of the project.
+	ws := filepath.Join(root, WorkspaceDir)
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return false, err
+	}
+	if _, err := os.Stat(filepath.Join(ws, "go.mod")); os.IsNotExist(err) {
+		if err := os.WriteFile(filepath.Join(ws, "go.mod"), []byte(goMod("kata")), 0o644); err != nil {
+			return false, err
+		}
+	}
+
+	stub, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(e.Dir), StubFile))
+	if err != nil {
+		return false, err
+	}
+	return true, os.WriteFile(dst, stub, 0o644)
+}
+
+// Result is the outcome of the hidden tests for one stub function.
+type Result struct {
+	Function string   `json:"function"`
+	Passed   bool     `json:"passed"`
+	Started  bool     `json:"started"`            // the learner changed it from the stub
+	Failures []string `json:"failures,omitempty"` // failing inputs, as reported by the tests
+}
+
+// Report is the outcome of checking the learner's workspace copy.
+type Report struct {
+	Exercise    string   `json:"exercise"`
+	Results     []Result `json:"results"`
+	BuildErrors string   `json:"build_errors,omitempty"` // set if the code did not compile
+}
+
+// Passed returns the number of functions that passed.
+func (r Report) Passed() int {
+	n := 0
+	for _, res := range r.Results {
+		if res.Passed {
+			n++
+		}
+	}
+	return n
+}
+
+// Check runs the hidden tests against the learner's workspace copy and
+// reports pass or fail per stub function. A compile error is reported in
+// BuildErrors with every function marked as failed.
+func (e Exercise) Check(root string) (Report, error) {
+	report := Report{Exercise: e.Lesson.ID}
+
+	tmp, err := os.MkdirTemp("", "go-learning-kata-")
+	if err != nil {
+		return report, err
+	}
+	defer os.RemoveAll(tmp)
+
+	files := map[string]string{
+		filepath.Join(root, filepath.FromSlash(e.Workspace)):       StubFile,
+		filepath.Join(root, filepath.FromSlash(e.Dir), ChecksFile): "checks_test.go",
+	}
+	for src, name := range files {
+		data, err := os.ReadFile(src)
+		if err != nil {
+			return report, err
+		}
+		if err := os.WriteFile(filepath.Join(tmp, name), data, 0o644); err != nil {
+			return report, err
+		}
+	}
+	if err := os.WriteFile(filepath.Join(tmp, "go.mod"), []byte(goMod("kata/"+e.Lesson.Name)), 0o644); err != nil {
+		return report, err
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("go", "test", "-json", "-count=1", "-tags", BuildTag, ".")
+	cmd.Dir = tmp
+	cmd.Env = append(os.Environ(), "GOWORK=off", "GOFLAGS=-mod=mod")
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	runErr := cmd.Run()
+
+	tests, buildOutput := parseTestJSON(stdout.Bytes())
+	buildOutput += stderr.String()
+	if len(tests) == 0 && runErr != nil {
+		report.BuildErrors = strings.TrimSpace(strings.ReplaceAll(buildOutput, tmp+string(filepath.Separator), ""))
+		if report.BuildErrors == "" {
+			report.BuildErrors = runErr.Error()
+		}
+	}
+
+	started, err := e.started(root)
+	if err != nil {
+		return report, err
+	}
+	for _, fn := range e.Functions {
+		res := Result{Function: fn, Started: started[fn]}
+		if t, ok := tests[testName(fn)]; ok {
+			res.Passed = t.passed
+			res.Failures = t.failures
+		} else if report.BuildErrors == "" {
+			res.Failures = []string{"no result; an earlier check may have crashed the test binary"}
+		}
+		report.Results = append(report.Results, res)
+	}
+	return report, nil
+}
+
+// started reports which functions of the workspace copy differ from
+// their stubs. A copy that does not parse counts as unchanged: a syntax
+// error says nothing about which function the learner worked on.
+func (e Exercise) started(root string) (map[string]bool, error) {
+	stub, err := funcBodies(filepath.Join(root, filepath.FromSlash(e.Dir), StubFile))
+	if err != nil {
+		return nil, err
+	}
+	ws, err := funcBodies(filepath.Join(root, filepath.FromSlash(e.Workspace)))
+	if err != nil {
+		return map[string]bool{}, nil
+	}
+	started := make(map[string]bool, len(stub))
+	for fn, body := range stub {
+		started[fn] = ws[fn] != body
+	}
+	return started, nil
+}
+
+// funcBodies returns the gofmt-formatted body of every top-level function
+// in a file, so that changes to layout or comments alone do not count.
+func funcBodies(file string) (map[string]string, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, file, nil, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+	bodies := map[string]string{}
+	for _, decl := range f.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil || fn.Body == nil {
+			continue
+		}
+		var sb strings.Builder
+		if err := format.Node(&sb, fset, fn.Body); err != nil {
+			return nil, err
+		}
+		bodies[fn.Name.Name] = sb.String()
+	}
+	return bodies, nil
+}
+
+// testName maps a stub function to the hidden test that checks it:
+// divideWithRemainder is checked by TestDivideWithRemainder.
+func testName(fn string) string {
+	r, size := utf8.DecodeRuneInString(fn)
+	return "Test" + string(unicode.ToUpper(r)) + fn[size:]
+}
+
+func goMod(module string) string {
+	return "module " + module + "\n\ngo 1.24\n"
+}
+
+// testOutcome is the result of one top-level test.
+type testOutcome struct {
+	passed   bool
+	failures []string
+}
+
+// testEvent is one line of `go test -json` output.
+type testEvent struct {
+	Action string
+	Test   string
+	Output string
+}
+
+// parseTestJSON collects the outcome of every top-level test from
+// `go test -json` output, along with any compiler output.
+func parseTestJSON(data []byte) (map[string]*testOutcome, string) {
+	tests := map[string]*testOutcome{}
+	var build strings.Builder
+
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		var ev testEvent
+		if err := json.Unmarshal(sc.Bytes(), &ev); err != nil {
+			build.WriteString(sc.Text() + "\n")
+			continue
+		}
+		if ev.Action == "build-output" {
+			build.WriteString(ev.Output)
+			continue
+		}
+		if ev.Test == "" {
+			continue
+		}
+		name, _, _ := strings.Cut(ev.Test, "/")
+		t := tests[name]
+		if t == nil {
+			t = &testOutcome{}
+			tests[name] = t
+		}
+		switch ev.Action {
+		case "pass":
+			if ev.Test == name {
+				t.passed = true
+			}
+		case "output":
+			if msg, ok := failureMessage(ev.Output); ok {
+				t.failures = append(t.failures, msg)
+			}
+		}
+	}
+	return tests, build.String()
+}
+
+// failureMessage extracts the message from a t.Errorf output line such
+// as "    checks_test.go:42: divide(10, 0) = 0, <nil>; want an error".
+func failureMessage(line string) (string, bool) {
+	line = strings.TrimSpace(line)
+	file, msg, ok := strings.Cut(line, ": ")
+	if !ok || !strings.Contains(file, "_test.go:") {
+		return "", false
+	}
+	return msg, true
+}
@@ -0,0 +1,92 @@
+package exercise
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang-learning-project/internal/lessons"
+)
+
+const stubSource = `package kata
+
+func double(n int) int {
+	panic("not implemented")
+}
+
+func half(n int) int {
+	panic("not implemented")
+}
+`
+
+func TestStarted(t *testing.T) {
+	tests := []struct {
+		name      string
+		workspace string
+		want      map[string]bool
+	}{
+		{"untouched", stubSource, map[string]bool{"double": false, "half": false}},
+		{
+			"reformatted",
+			"package kata\n\n// double doubles.\nfunc double(n int) int {\n\tpanic( \"not implemented\" )\n}\n\nfunc half(n int) int { panic(\"not implemented\") }\n",
+			map[string]bool{"double": false, "half": false},
+		},
+		{
+			"one done",
+			"package kata\n\nfunc double(n int) int {\n\treturn 2 * n\n}\n\nfunc half(n int) int {\n\tpanic(\"not implemented\")\n}\n",
+			map[string]bool{"double": true, "half": false},
+		},
+		{"removed", "package kata\n\nfunc half(n int) int { return n / 2 }\n", map[string]bool{"double": true, "half": true}},
+		{"syntax error", "package kata\n\nfunc double(n int) int {\n", map[string]bool{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			l := lessons.Lesson{ID: "01-basics/kata", Name: "kata"}
+			write(t, filepath.Join(root, Dir, "01-basics", "kata", StubFile), stubSource)
+			ex, err := Load(root, l)
+			if err != nil {
+				t.Fatal(err)
+			}
+			write(t, filepath.Join(root, filepath.FromSlash(ex.Workspace)), tt.workspace)
+
+			got, err := ex.started(root)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, fn := range ex.Functions {
+				if got[fn] != tt.want[fn] {
+					t.Errorf("started[%s] = %v; want %v", fn, got[fn], tt.want[fn])
+				}
+			}
+		})
+	}
+}
+
+func TestFailureMessage(t *testing.T) {
+	tests := []struct {
+		line string
+		want string
+		ok   bool
+	}{
+		{"    checks_test.go:42: divide(10, 0) = 0, <nil>; want an error\n", "divide(10, 0) = 0, <nil>; want an error", true},
+		{"=== RUN   TestDivide\n", "", false},
+		{"    main.go:3: not a test file\n", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := failureMessage(tt.line)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("failureMessage(%q) = %q, %v; want %q, %v", tt.line, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func write(t *testing.T, path, data string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
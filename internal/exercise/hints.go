@@ -0,0 +1,88 @@
+package exercise
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+const (
+	// HintsFile holds the tiered hints of an exercise, as JSON.
+	HintsFile = "hints.json"
+
+	// SolutionFile holds the reference solutions. Its ignore build tag
+	// keeps it out of every build.
+	SolutionFile = "solution.go"
+
+	// defaultSolutionAfter applies when HintsFile does not set
+	// solution_after.
+	defaultSolutionAfter = 3
+)
+
+// Hints is the content of an exercise's HintsFile.
+type Hints struct {
+	// SolutionAfter is the number of failed attempts at a function
+	// after which its reference solution may be revealed.
+	SolutionAfter int `json:"solution_after"`
+
+	// Functions maps each stub function to its hints, from the gentlest
+	// nudge to the most explicit.
+	Functions map[string][]string `json:"functions"`
+}
+
+// Hints loads the exercise's hints. An exercise without a HintsFile has
+// no hints, which is not an error.
+func (e Exercise) Hints(root string) (Hints, error) {
+	h := Hints{SolutionAfter: defaultSolutionAfter}
+	data, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(e.Dir), HintsFile))
+	if os.IsNotExist(err) {
+		return h, nil
+	}
+	if err != nil {
+		return h, err
+	}
+	if err := json.Unmarshal(data, &h); err != nil {
+		return h, fmt.Errorf("%s/%s: %w", e.Dir, HintsFile, err)
+	}
+	if h.SolutionAfter <= 0 {
+		h.SolutionAfter = defaultSolutionAfter
+	}
+	return h, nil
+}
+
+// HasFunction reports whether fn is one of the exercise's stubs.
+func (e Exercise) HasFunction(fn string) bool {
+	return slices.Contains(e.Functions, fn)
+}
+
+// Solution returns the source of the reference solution for fn,
+// including its comments.
+func (e Exercise) Solution(root, fn string) (string, error) {
+	file := filepath.Join(root, filepath.FromSlash(e.Dir), SolutionFile)
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, file, nil, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		return "", err
+	}
+	for _, decl := range f.Decls {
+		d, ok := decl.(*ast.FuncDecl)
+		if !ok || d.Recv != nil || d.Name.Name != fn {
+			continue
+		}
+		var buf bytes.Buffer
+		node := &printer.CommentedNode{Node: d, Comments: f.Comments}
+		if err := format.Node(&buf, fset, node); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	}
+	return "", fmt.Errorf("%s/%s has no solution for %s", e.Dir, SolutionFile, fn)
+}
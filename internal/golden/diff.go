@@ -0,0 +1,151 @@
+package golden
+
+import (
+	"fmt"
+	"strings"
+)
+
+// context is the number of unchanged lines shown around each change.
+const context = 3
+
+// op is one line of an edit script.
+type op struct {
+	kind byte // ' ', '-' or '+'
+	line string
+}
+
+// Unified returns a unified diff turning want into got, labelled with
+// the two names, or "" if they are equal. The example outputs are a few
+// hundred lines at most, so a quadratic LCS table is fine here.
+func Unified(wantName, gotName, want, got string) string {
+	if want == got {
+		return ""
+	}
+	a := splitLines(want)
+	b := splitLines(got)
+	ops := editScript(a, b)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", wantName, gotName)
+
+	// Walk the script, cutting it into hunks of changes that are less
+	// than 2*context unchanged lines apart.
+	for i := 0; i < len(ops); {
+		if ops[i].kind == ' ' {
+			i++
+			continue
+		}
+		start := max(i-context, 0)
+		end := i
+		for end < len(ops) {
+			if ops[end].kind != ' ' {
+				end++
+				continue
+			}
+			run := end
+			for run < len(ops) && ops[run].kind == ' ' {
+				run++
+			}
+			if run == len(ops) || run-end > 2*context {
+				end = min(end+context, len(ops))
+				break
+			}
+			end = run
+		}
+		writeHunk(&sb, ops, start, end)
+		i = end
+	}
+	return sb.String()
+}
+
+// writeHunk writes ops[start:end] with its @@ header.
+func writeHunk(sb *strings.Builder, ops []op, start, end int) {
+	// Line numbers of the hunk start in a and b are the number of
+	// lines from each consumed before start, plus one.
+	aLine, bLine := 1, 1
+	for _, o := range ops[:start] {
+		if o.kind != '+' {
+			aLine++
+		}
+		if o.kind != '-' {
+			bLine++
+		}
+	}
+	aCount, bCount := 0, 0
+	for _, o := range ops[start:end] {
+		if o.kind != '+' {
+			aCount++
+		}
+		if o.kind != '-' {
+			bCount++
+		}
+	}
+	fmt.Fprintf(sb, "@@ -%s +%s @@\n", hunkRange(aLine, aCount), hunkRange(bLine, bCount))
+	for _, o := range ops[start:end] {
+		sb.WriteByte(o.kind)
+		sb.WriteString(o.line)
+		sb.WriteByte('\n')
+	}
+}
+
+func hunkRange(line, count int) string {
+	if count == 0 {
+		line-- // an empty range names the line before it
+	}
+	if count == 1 {
+		return fmt.Sprint(line)
+	}
+	return fmt.Sprintf("%d,%d", line, count)
+}
+
+// editScript returns the shortest sequence of keeps, deletions and
+// insertions turning a into b, using a longest-common-subsequence table.
+func editScript(a, b []string) []op {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var ops []op
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, op{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, op{'-', a[i]})
+			i++
+		default:
+			ops = append(ops, op{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		ops = append(ops, op{'-', a[i]})
+	}
+	for ; j < len(b); j++ {
+		ops = append(ops, op{'+', b[j]})
+	}
+	return ops
+}
+
+// splitLines splits s into lines, dropping the empty element after a
+// trailing newline.
+func splitLines(s string) []string {
+	lines := strings.Split(s, "\n")
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
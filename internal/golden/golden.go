@@ -0,0 +1,160 @@
+// Package golden checks the output of the example programs against
+// checked-in golden files.
+//
+// Each lesson keeps its expected stdout in testdata/output.golden next to
+// its main.go. Output that depends on map iteration order is made
+// deterministic by listing the line that introduces each such block in
+// testdata/unordered; the indented lines following a listed header are
+// sorted before comparing:
+//
+//	Iterating over map:
+//	Word frequency:
+package golden
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"golang-learning-project/internal/lessons"
+)
+
+const (
+	// GoldenFile holds the expected stdout, relative to the lesson dir.
+	GoldenFile = "testdata/output.golden"
+
+	// UnorderedFile lists the headers of blocks whose lines are sorted
+	// before comparing, relative to the lesson dir.
+	UnorderedFile = "testdata/unordered"
+)
+
+// Status is the outcome of checking one lesson.
+type Status string
+
+const (
+	StatusOK      Status = "ok"
+	StatusFail    Status = "FAIL"
+	StatusSkip    Status = "SKIP"
+	StatusUpdated Status = "updated"
+)
+
+// Result is the outcome of checking one lesson. Diff is set when Status
+// is StatusFail because the output differed.
+type Result struct {
+	Lesson lessons.Lesson
+	Status Status
+	Diff   string
+	Err    error
+}
+
+// Has reports whether the lesson has a golden file.
+func Has(root string, l lessons.Lesson) bool {
+	_, err := os.Stat(path(root, l, GoldenFile))
+	return err == nil
+}
+
+// Check runs the lesson and compares its normalized stdout with the
+// golden file. Lessons without a golden file are skipped unless update is
+// set. With update, the golden file is (re)written from the normalized
+// output instead of compared.
+func Check(root string, l lessons.Lesson, update bool) Result {
+	res := Result{Lesson: l}
+	if !update && !Has(root, l) {
+		res.Status = StatusSkip
+		return res
+	}
+
+	headers, err := readUnordered(root, l)
+	if err != nil {
+		res.Status, res.Err = StatusFail, err
+		return res
+	}
+
+	var stdout, stderr bytes.Buffer
+	run, err := l.Run(root, &stdout, &stderr)
+	if err == nil && run.ExitCode != 0 {
+		err = fmt.Errorf("exit status %d: %s", run.ExitCode, strings.TrimSpace(stderr.String()))
+	}
+	if err != nil {
+		res.Status, res.Err = StatusFail, err
+		return res
+	}
+	got := Normalize(stdout.String(), headers)
+
+	file := path(root, l, GoldenFile)
+	if update {
+		if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+			res.Status, res.Err = StatusFail, err
+			return res
+		}
+		if err := os.WriteFile(file, []byte(got), 0o644); err != nil {
+			res.Status, res.Err = StatusFail, err
+			return res
+		}
+		res.Status = StatusUpdated
+		return res
+	}
+
+	want, err := os.ReadFile(file)
+	if err != nil {
+		res.Status, res.Err = StatusFail, err
+		return res
+	}
+	if string(want) == got {
+		res.Status = StatusOK
+		return res
+	}
+	res.Status = StatusFail
+	res.Diff = Unified(l.Dir+"/"+GoldenFile, "actual output", string(want), got)
+	return res
+}
+
+// Normalize makes program output comparable: it converts CRLF line
+// endings and, for every line equal to one of headers, sorts the block of
+// indented lines that follows it.
+func Normalize(output string, headers []string) string {
+	lines := strings.Split(strings.ReplaceAll(output, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		if !slices.Contains(headers, strings.TrimSpace(lines[i])) {
+			continue
+		}
+		start := i + 1
+		end := start
+		for end < len(lines) && isIndented(lines[end]) {
+			end++
+		}
+		slices.Sort(lines[start:end])
+		i = end - 1
+	}
+	return strings.Join(lines, "\n")
+}
+
+func isIndented(line string) bool {
+	return strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")
+}
+
+// readUnordered returns the block headers listed in the lesson's
+// unordered file, or nil if it has none.
+func readUnordered(root string, l lessons.Lesson) ([]string, error) {
+	data, err := os.ReadFile(path(root, l, UnorderedFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var headers []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+			headers = append(headers, line)
+		}
+	}
+	return headers, nil
+}
+
+func path(root string, l lessons.Lesson, name string) string {
+	return filepath.Join(root, filepath.FromSlash(l.Dir), filepath.FromSlash(name))
+}
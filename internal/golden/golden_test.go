@@ -0,0 +1,80 @@
+package golden_test
+
+import (
+	"flag"
+	"testing"
+
+	"golang-learning-project/internal/golden"
+	"golang-learning-project/internal/lessons"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files from the current output")
+
+// root is the repository root, seen from this package's directory.
+const root = "../.."
+
+// TestExamples runs every lesson that has a golden file and compares its
+// output, like demo verify. As there, -update only rewrites existing
+// golden files:
+//
+//	go test ./internal/golden -update
+func TestExamples(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds and runs every example")
+	}
+	catalog, err := lessons.Discover(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checked := 0
+	for _, l := range catalog {
+		if !golden.Has(root, l) {
+			continue
+		}
+		checked++
+		t.Run(l.ID, func(t *testing.T) {
+			t.Parallel()
+			res := golden.Check(root, l, *update)
+			switch {
+			case res.Err != nil:
+				t.Fatal(res.Err)
+			case res.Status == golden.StatusFail:
+				t.Errorf("output differs from %s:\n%s", golden.GoldenFile, res.Diff)
+			}
+		})
+	}
+	if checked == 0 {
+		t.Fatal("no lesson has a golden file")
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		headers []string
+		want    string
+	}{
+		{"no headers", "b\na\n", nil, "b\na\n"},
+		{"sorts block", "Map:\n  b\n  a\nafter\n", []string{"Map:"}, "Map:\n  a\n  b\nafter\n"},
+		{"only indented", "Map:\n  z\n  y\nx\nw\n", []string{"Map:"}, "Map:\n  y\n  z\nx\nw\n"},
+		{"every block", "Map:\n 2\n 1\nMap:\n 4\n 3\n", []string{"Map:"}, "Map:\n 1\n 2\nMap:\n 3\n 4\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := golden.Normalize(tt.in, tt.headers); got != tt.want {
+				t.Errorf("Normalize(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnified(t *testing.T) {
+	if d := golden.Unified("want", "got", "a\nb\n", "a\nb\n"); d != "" {
+		t.Errorf("equal inputs: diff %q, want none", d)
+	}
+	want := "--- want\n+++ got\n@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n"
+	if d := golden.Unified("want", "got", "a\nb\nc\n", "a\nB\nc\n"); d != want {
+		t.Errorf("diff:\n%s\nwant:\n%s", d, want)
+	}
+}
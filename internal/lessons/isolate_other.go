@@ -0,0 +1,9 @@
+//go:build !unix
+
+package lessons
+
+import "os/exec"
+
+// isolate does nothing: outside Unix, Ctrl+C reaches every process on
+// the console, and os.Interrupt cannot be sent to another process.
+func isolate(cmd *exec.Cmd) {}
@@ -0,0 +1,14 @@
+//go:build unix
+
+package lessons
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// isolate starts cmd in a process group of its own, so that a Ctrl+C at
+// the terminal reaches only this program, which forwards it once.
+func isolate(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
@@ -0,0 +1,180 @@
+// Package lessons discovers the runnable examples under examples/ and
+// parses the header comment every lesson starts with:
+//
+//	/*
+//	=============================================================================
+//	TOPIC: Functions in Go
+//	=============================================================================
+//
+//	Functions are the building blocks of Go programs.
+//
+//	Key Concepts:
+//	- Function declaration
+//	- Closures
+//	=============================================================================
+//	*/
+//
+// A lesson that runs until it is interrupted, such as an HTTP server,
+// says so with a SERVER: line under its TOPIC: line, giving the URL it
+// listens on.
+//
+// The catalog is rebuilt from disk every time, so menus, numbering and run
+// commands always match the examples that actually exist.
+package lessons
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ExamplesDir is the directory, relative to the repository root, that
+// holds one sub-directory per level.
+const ExamplesDir = "examples"
+
+// Lesson is one runnable example program.
+type Lesson struct {
+	Number   int      `json:"number"`   // 1-based position in the catalog
+	ID       string   `json:"id"`       // path below examples/, e.g. "01-basics/functions"
+	Level    string   `json:"level"`    // top-level directory, e.g. "01-basics"
+	Name     string   `json:"name"`     // last path element, e.g. "functions"
+	Dir      string   `json:"dir"`      // slash-separated and relative to the root
+	File     string   `json:"file"`     // Dir + "/main.go"
+	Topic    string   `json:"topic"`    // the TOPIC: line, or Name if missing
+	Summary  string   `json:"summary"`  // prose between the TOPIC rule and Key Concepts
+	Server   string   `json:"server"`   // the SERVER: line of a lesson that runs until interrupted
+	Concepts []string `json:"concepts"` // the Key Concepts bullet list
+}
+
+// Discover walks root/examples and returns every directory that holds a
+// main.go, ordered by path and numbered from 1. Directories named
+// testdata, or starting with "." or "_", are skipped like the go tool does.
+func Discover(root string) ([]Lesson, error) {
+	base := filepath.Join(root, ExamplesDir)
+	var lessons []Lesson
+	err := filepath.WalkDir(base, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			name := d.Name()
+			if p != base && (name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Name() != "main.go" {
+			return nil
+		}
+
+		rel, err := filepath.Rel(base, filepath.Dir(p))
+		if err != nil {
+			return err
+		}
+		id := filepath.ToSlash(rel)
+		l := Lesson{
+			ID:    id,
+			Level: strings.SplitN(id, "/", 2)[0],
+			Name:  path.Base(id),
+			Dir:   path.Join(ExamplesDir, id),
+		}
+		l.File = path.Join(l.Dir, "main.go")
+		if err := parseHeader(p, &l); err != nil {
+			return err
+		}
+		if l.Topic == "" {
+			l.Topic = l.Name
+		}
+		lessons = append(lessons, l)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range lessons {
+		lessons[i].Number = i + 1
+	}
+	return lessons, nil
+}
+
+// parseHeader fills Topic, Server, Summary and Concepts from the first
+// comment in file that contains a "TOPIC:" line. A file without one is
+// not an error; the fields are simply left empty.
+func parseHeader(file string, l *Lesson) error {
+	f, err := parser.ParseFile(token.NewFileSet(), file, nil, parser.ParseComments)
+	if err != nil {
+		return err
+	}
+
+	for _, group := range f.Comments {
+		text := group.Text()
+		if !strings.Contains(text, "TOPIC:") {
+			continue
+		}
+
+		var summary []string
+		inConcepts := false
+		for _, line := range strings.Split(text, "\n") {
+			line = strings.TrimSpace(line)
+			switch {
+			case strings.HasPrefix(line, "==="):
+				inConcepts = false
+			case strings.HasPrefix(line, "TOPIC:"):
+				l.Topic = strings.TrimSpace(strings.TrimPrefix(line, "TOPIC:"))
+			case strings.HasPrefix(line, "SERVER:"):
+				l.Server = strings.TrimSpace(strings.TrimPrefix(line, "SERVER:"))
+			case line == "Key Concepts:":
+				inConcepts = true
+			case inConcepts && strings.HasPrefix(line, "- "):
+				l.Concepts = append(l.Concepts, strings.TrimPrefix(line, "- "))
+			case inConcepts:
+				inConcepts = line == ""
+			case l.Topic != "" && len(l.Concepts) == 0:
+				summary = append(summary, line)
+			}
+		}
+		l.Summary = strings.TrimSpace(strings.Join(summary, "\n"))
+		return nil
+	}
+	return nil
+}
+
+// Find looks a lesson up by catalog number ("3"), by ID
+// ("01-basics/functions") or by name ("functions"). A name that matches
+// lessons in several levels is reported as ambiguous.
+func Find(lessons []Lesson, query string) (Lesson, error) {
+	query = strings.Trim(strings.TrimSpace(query), "/")
+	if n, err := strconv.Atoi(query); err == nil {
+		if n < 1 || n > len(lessons) {
+			return Lesson{}, fmt.Errorf("lesson %d out of range (1-%d)", n, len(lessons))
+		}
+		return lessons[n-1], nil
+	}
+
+	var matches []Lesson
+	for _, l := range lessons {
+		if l.ID == query || l.Dir == query {
+			return l, nil
+		}
+		if l.Name == query {
+			matches = append(matches, l)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return Lesson{}, fmt.Errorf("no lesson named %q", query)
+	case 1:
+		return matches[0], nil
+	}
+	ids := make([]string, len(matches))
+	for i, m := range matches {
+		ids[i] = m.ID
+	}
+	return Lesson{}, fmt.Errorf("lesson name %q is ambiguous: %s", query, strings.Join(ids, ", "))
+}
@@ -0,0 +1,184 @@
+package lessons_test
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"golang-learning-project/internal/lessons"
+)
+
+// tree writes files, keyed by slash-separated path, under a new root.
+func tree(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	for name, data := range files {
+		file := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+const header = `package main
+
+// A comment before the header is not the header.
+
+/*
+=============================================================================
+TOPIC: Functions in Go
+=============================================================================
+
+Functions are the building blocks of Go programs.
+They are values too.
+
+Key Concepts:
+- Function declaration
+- Closures
+
+- After a blank line
+=============================================================================
+*/
+
+// TOPIC: a later comment is ignored
+
+func main() {}
+`
+
+const serverHeader = `package main
+
+/*
+TOPIC: HTTP Servers in Go
+SERVER: http://localhost:8080/
+=====
+
+Runs until Ctrl+C.
+
+Key Concepts:
+- ServeMux
+*/
+func main() {}
+`
+
+func TestDiscover(t *testing.T) {
+	root := tree(t, map[string]string{
+		"examples/01-basics/functions/main.go":      header,
+		"examples/01-basics/bare/main.go":           "package main\n\nfunc main() {}\n",
+		"examples/01-basics/bare/helper.go":         "package main\n",
+		"examples/02-web/server/main.go":            serverHeader,
+		"examples/03-api/main.go":                   "package main\n\n/* TOPIC: At the level */\nfunc main() {}\n",
+		"examples/01-basics/testdata/x/main.go":     "package main\n",
+		"examples/01-basics/_draft/main.go":         "package main\n",
+		"examples/01-basics/.hidden/main.go":        "package main\n",
+		"examples/01-basics/notes.md":               "not a lesson\n",
+		"examples/02-web/server/static/app.js":      "",
+		"examples/02-web/server/templates/main.txt": "",
+	})
+	catalog, err := lessons.Discover(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []string
+	for _, l := range catalog {
+		ids = append(ids, l.ID)
+	}
+	if want := []string{"01-basics/bare", "01-basics/functions", "02-web/server", "03-api"}; !slices.Equal(ids, want) {
+		t.Fatalf("Discover = %q, want %q", ids, want)
+	}
+
+	fns := catalog[1]
+	if fns.Number != 2 || fns.Level != "01-basics" || fns.Name != "functions" ||
+		fns.Dir != "examples/01-basics/functions" || fns.File != "examples/01-basics/functions/main.go" {
+		t.Errorf("functions = %+v", fns)
+	}
+	if fns.Topic != "Functions in Go" || fns.Server != "" ||
+		fns.Summary != "Functions are the building blocks of Go programs.\nThey are values too." {
+		t.Errorf("functions header: topic %q, server %q, summary %q", fns.Topic, fns.Server, fns.Summary)
+	}
+	if want := []string{"Function declaration", "Closures", "After a blank line"}; !slices.Equal(fns.Concepts, want) {
+		t.Errorf("functions concepts %q, want %q", fns.Concepts, want)
+	}
+
+	if bare := catalog[0]; bare.Topic != "bare" || bare.Summary != "" || bare.Concepts != nil {
+		t.Errorf("a lesson without a header: %+v, want its name as the topic", bare)
+	}
+	if srv := catalog[2]; srv.Server != "http://localhost:8080/" || srv.Topic != "HTTP Servers in Go" ||
+		srv.Summary != "Runs until Ctrl+C." || !slices.Equal(srv.Concepts, []string{"ServeMux"}) {
+		t.Errorf("server lesson %+v", srv)
+	}
+	if api := catalog[3]; api.Level != "03-api" || api.Name != "03-api" || api.Topic != "At the level" {
+		t.Errorf("lesson at the top of a level: %+v", api)
+	}
+}
+
+func TestDiscoverErrors(t *testing.T) {
+	if _, err := lessons.Discover(t.TempDir()); err == nil {
+		t.Error("Discover without an examples directory succeeded")
+	}
+	root := tree(t, map[string]string{"examples/01/broken/main.go": "package main\n\nfunc main() {"})
+	if _, err := lessons.Discover(root); err == nil || !strings.Contains(err.Error(), "main.go") {
+		t.Errorf("Discover with a main.go that does not parse = %v", err)
+	}
+}
+
+// TestServers checks that the examples that run until interrupted are
+// the ones marked as servers, so the menu can hand them Ctrl+C.
+func TestServers(t *testing.T) {
+	catalog, err := lessons.Discover("../..")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var servers []string
+	for _, l := range catalog {
+		if l.Server != "" {
+			servers = append(servers, l.ID)
+		}
+	}
+	if want := []string{"03-http-server/basic-server", "04-rest-api"}; !slices.Equal(servers, want) {
+		t.Errorf("server lessons %q, want %q", servers, want)
+	}
+}
+
+func TestFind(t *testing.T) {
+	catalog := []lessons.Lesson{
+		{Number: 1, ID: "01-basics/functions", Name: "functions", Dir: "examples/01-basics/functions"},
+		{Number: 2, ID: "01-basics/structs", Name: "structs", Dir: "examples/01-basics/structs"},
+		{Number: 3, ID: "02-advanced/structs", Name: "structs", Dir: "examples/02-advanced/structs"},
+		{Number: 4, ID: "04-rest-api", Name: "04-rest-api", Dir: "examples/04-rest-api"},
+	}
+	tests := []struct {
+		query string
+		want  string // the ID found, or part of the error
+		ok    bool
+	}{
+		{"1", "01-basics/functions", true},
+		{" 4 ", "04-rest-api", true},
+		{"functions", "01-basics/functions", true},
+		{"01-basics/structs", "01-basics/structs", true},
+		{"/02-advanced/structs/", "02-advanced/structs", true},
+		{"examples/02-advanced/structs", "02-advanced/structs", true},
+		{"04-rest-api", "04-rest-api", true},
+		{"structs", `lesson name "structs" is ambiguous: 01-basics/structs, 02-advanced/structs`, false},
+		{"0", "lesson 0 out of range (1-4)", false},
+		{"5", "lesson 5 out of range (1-4)", false},
+		{"-1", "out of range", false},
+		{"Functions", `no lesson named "Functions"`, false},
+		{"", `no lesson named ""`, false},
+	}
+	for _, tt := range tests {
+		l, err := lessons.Find(catalog, tt.query)
+		switch {
+		case tt.ok && (err != nil || l.ID != tt.want):
+			t.Errorf("Find(%q) = %q, %v; want %q", tt.query, l.ID, err, tt.want)
+		case !tt.ok && (err == nil || !strings.Contains(err.Error(), tt.want)):
+			t.Errorf("Find(%q) = %q, %v; want error %q", tt.query, l.ID, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package lessons
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"time"
+)
+
+// RunResult describes one finished run of an example.
+type RunResult struct {
+	ExitCode int
+	Duration time.Duration
+}
+
+// Run builds the lesson's example into a temporary directory and runs
+// the binary as a child process, writing its output to stdout and
+// stderr. The example runs from its own directory so any relative paths
+// it uses resolve the same as with `go run main.go`.
+//
+// A server lesson runs until it is interrupted. While it runs, Ctrl+C
+// is caught and passed on to the example alone, which shuts down and
+// returns control to the caller instead of ending the whole program.
+//
+// A non-zero exit of the example is reported through the result, not as
+// an error; err is only set when the example could not be built or
+// started.
+func (l Lesson) Run(root string, stdout, stderr io.Writer) (RunResult, error) {
+	tmp, err := os.MkdirTemp("", "go-learning-demo-")
+	if err != nil {
+		return RunResult{}, err
+	}
+	defer os.RemoveAll(tmp)
+
+	bin := filepath.Join(tmp, l.Name)
+	build := exec.Command("go", "build", "-o", bin, "./"+l.Dir)
+	build.Dir = root
+	build.Stdout = stderr
+	build.Stderr = stderr
+	if err := build.Run(); err != nil {
+		return RunResult{}, fmt.Errorf("build failed: %w", err)
+	}
+
+	run := exec.Command(bin)
+	run.Dir = filepath.Join(root, filepath.FromSlash(l.Dir))
+	run.Stdout = stdout
+	run.Stderr = stderr
+	var interrupts chan os.Signal
+	if l.Server != "" {
+		isolate(run)
+		interrupts = make(chan os.Signal, 1)
+		signal.Notify(interrupts, os.Interrupt)
+		defer signal.Stop(interrupts)
+	}
+	start := time.Now()
+	err = run.Start()
+	if err == nil {
+		if interrupts != nil {
+			done := make(chan struct{})
+			defer close(done)
+			go forward(interrupts, run.Process, done)
+		}
+		err = run.Wait()
+	}
+	res := RunResult{Duration: time.Since(start)}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		res.ExitCode = exitErr.ExitCode()
+		return res, nil
+	}
+	return res, err
+}
+
+// forward sends every signal received on signals to p, until done is
+// closed.
+func forward(signals <-chan os.Signal, p *os.Process, done <-chan struct{}) {
+	for {
+		select {
+		case sig := <-signals:
+			p.Signal(sig)
+		case <-done:
+			return
+		}
+	}
+}
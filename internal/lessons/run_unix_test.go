@@ -0,0 +1,73 @@
+//go:build unix
+
+package lessons_test
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+
+	"golang-learning-project/internal/lessons"
+)
+
+const server = `package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	fmt.Println("ready")
+	<-ctx.Done()
+	fmt.Println("stopped")
+}
+`
+
+// signalOnReady is the stdout of the server: once the server says it
+// is ready, it interrupts this process, as Ctrl+C at the terminal does.
+type signalOnReady struct {
+	mu   sync.Mutex
+	buf  bytes.Buffer
+	sent bool
+}
+
+func (w *signalOnReady) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.buf.Write(p)
+	if !w.sent && strings.Contains(w.buf.String(), "ready\n") {
+		w.sent = true
+		syscall.Kill(os.Getpid(), syscall.SIGINT)
+	}
+	return len(p), nil
+}
+
+func TestRunServer(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds an example")
+	}
+	root := tree(t, map[string]string{
+		"go.mod":               "module lessontest\n\ngo 1.24\n",
+		"examples/srv/main.go": server,
+	})
+	l := lessons.Lesson{Name: "srv", Dir: "examples/srv", Server: "http://localhost:8080/"}
+
+	var stdout signalOnReady
+	var stderr bytes.Buffer
+	res, err := l.Run(root, &stdout, &stderr)
+	if err != nil {
+		t.Fatalf("Run: %v\n%s", err, stderr.String())
+	}
+	// Reaching here at all means the interrupt did not end the test
+	if got := stdout.buf.String(); res.ExitCode != 0 || got != "ready\nstopped\n" {
+		t.Errorf("Run = exit %d, output %q; want the server to stop cleanly", res.ExitCode, got)
+	}
+}
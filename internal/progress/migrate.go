@@ -0,0 +1,91 @@
+package progress
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// migrations holds the schema history. migrations[i] upgrades the
+// database from version i to version i+1. Entries are append-only: once
+// released, a migration must never be edited, only followed by another.
+var migrations = []string{
+	// 1: lesson runs and exercise attempts.
+	`CREATE TABLE lesson_runs (
+		id        INTEGER PRIMARY KEY,
+		lesson    TEXT    NOT NULL,
+		exit_code INTEGER NOT NULL,
+		ran_at    TEXT    NOT NULL
+	);
+	CREATE INDEX lesson_runs_lesson ON lesson_runs (lesson);
+
+	CREATE TABLE exercise_attempts (
+		id           INTEGER PRIMARY KEY,
+		lesson       TEXT    NOT NULL,
+		function     TEXT    NOT NULL,
+		passed       BOOLEAN NOT NULL,
+		attempted_at TEXT    NOT NULL
+	);
+	CREATE INDEX exercise_attempts_lesson ON exercise_attempts (lesson, function);`,
+
+	// 2: hints and solutions revealed for exercise functions.
+	`CREATE TABLE reveals (
+		id          INTEGER PRIMARY KEY,
+		lesson      TEXT    NOT NULL,
+		function    TEXT    NOT NULL,
+		kind        TEXT    NOT NULL CHECK (kind IN ('hint', 'solution')),
+		hint        INTEGER NOT NULL, -- 1-based hint number; 0 for the solution
+		revealed_at TEXT    NOT NULL
+	);
+	CREATE INDEX reveals_lesson ON reveals (lesson, function);`,
+
+	// 3: quiz scores.
+	`CREATE TABLE quiz_results (
+		id       INTEGER PRIMARY KEY,
+		lesson   TEXT    NOT NULL,
+		score    INTEGER NOT NULL,
+		total    INTEGER NOT NULL,
+		taken_at TEXT    NOT NULL
+	);
+	CREATE INDEX quiz_results_lesson ON quiz_results (lesson);`,
+}
+
+// migrate brings db up to len(migrations), applying each pending
+// migration in its own transaction and recording it in
+// schema_migrations.
+func migrate(db *sql.DB) error {
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    INTEGER PRIMARY KEY,
+		applied_at TEXT NOT NULL
+	)`); err != nil {
+		return err
+	}
+
+	var current int
+	if err := db.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&current); err != nil {
+		return err
+	}
+	if current > len(migrations) {
+		return fmt.Errorf("database schema version %d is newer than this program (%d)", current, len(migrations))
+	}
+
+	for v := current + 1; v <= len(migrations); v++ {
+		tx, err := db.Begin()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(migrations[v-1]); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("version %d: %w", v, err)
+		}
+		if _, err := tx.Exec(`INSERT INTO schema_migrations (version, applied_at) VALUES (?, ?)`,
+			v, time.Now().UTC().Format(timeLayout)); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("version %d: %w", v, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("version %d: %w", v, err)
+		}
+	}
+	return nil
+}
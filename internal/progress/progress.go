@@ -0,0 +1,254 @@
+// Package progress records a learner's progress in a local SQLite
+// database: which lessons were run, every exercise attempt with its
+// result, which hints and solutions were revealed, and quiz scores. It
+// uses the pure-Go modernc.org/sqlite driver, so no cgo or system SQLite
+// is needed.
+package progress
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// EnvPath overrides the database location, e.g. for a throwaway profile.
+const EnvPath = "GOLEARN_PROGRESS_DB"
+
+// Store is an open progress database.
+type Store struct {
+	db  *sql.DB
+	now func() time.Time // time.Now; tests fix it
+}
+
+// DefaultPath returns $GOLEARN_PROGRESS_DB if set, otherwise
+// progress.db in a golang-learning directory under the user's config dir
+// (~/.config on Linux).
+func DefaultPath() (string, error) {
+	if p := os.Getenv(EnvPath); p != "" {
+		return p, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "golang-learning", "progress.db"), nil
+}
+
+// Open opens or creates the database at path and migrates it to the
+// latest schema version.
+func Open(path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+	// SQLite allows one writer at a time; a single connection avoids
+	// "database is locked" errors between our own goroutines.
+	db.SetMaxOpenConns(1)
+
+	if err := migrate(db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("migrate %s: %w", path, err)
+	}
+	return &Store{db: db, now: time.Now}, nil
+}
+
+// Close closes the database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// RecordRun records that a lesson's example was run.
+func (s *Store) RecordRun(lesson string, exitCode int) error {
+	_, err := s.db.Exec(
+		`INSERT INTO lesson_runs (lesson, exit_code, ran_at) VALUES (?, ?, ?)`,
+		lesson, exitCode, s.timestamp())
+	return err
+}
+
+// RecordAttempt records one check of an exercise function.
+func (s *Store) RecordAttempt(lesson, function string, passed bool) error {
+	_, err := s.db.Exec(
+		`INSERT INTO exercise_attempts (lesson, function, passed, attempted_at) VALUES (?, ?, ?, ?)`,
+		lesson, function, passed, s.timestamp())
+	return err
+}
+
+// RecordHint records that hint number n (1-based) of an exercise
+// function was revealed.
+func (s *Store) RecordHint(lesson, function string, n int) error {
+	_, err := s.db.Exec(
+		`INSERT INTO reveals (lesson, function, kind, hint, revealed_at) VALUES (?, ?, 'hint', ?, ?)`,
+		lesson, function, n, s.timestamp())
+	return err
+}
+
+// RecordSolution records that the reference solution of an exercise
+// function was revealed.
+func (s *Store) RecordSolution(lesson, function string) error {
+	_, err := s.db.Exec(
+		`INSERT INTO reveals (lesson, function, kind, hint, revealed_at) VALUES (?, ?, 'solution', 0, ?)`,
+		lesson, function, s.timestamp())
+	return err
+}
+
+// RecordQuiz records a completed quiz with its score out of total.
+func (s *Store) RecordQuiz(lesson string, score, total int) error {
+	_, err := s.db.Exec(
+		`INSERT INTO quiz_results (lesson, score, total, taken_at) VALUES (?, ?, ?, ?)`,
+		lesson, score, total, s.timestamp())
+	return err
+}
+
+// Lesson is the recorded progress of one lesson.
+type Lesson struct {
+	Runs      int                 // successful runs of the example
+	LastRun   time.Time           // time of the latest successful run
+	Functions map[string]Function // by exercise function name
+
+	Quizzes   int // completed quizzes
+	QuizBest  int // best score so far
+	QuizTotal int // number of questions in the best-scoring quiz
+}
+
+// Function is the recorded progress of one exercise function.
+type Function struct {
+	Attempts         int
+	Failures         int  // attempts that did not pass
+	Passed           bool // passed in at least one attempt
+	LastAttempt      time.Time
+	HintsRevealed    int // highest hint number revealed so far
+	SolutionRevealed bool
+}
+
+// Ran reports whether the lesson's example was run successfully at
+// least once.
+func (l Lesson) Ran() bool {
+	return l.Runs > 0
+}
+
+// Lessons returns the progress of every lesson with recorded activity,
+// keyed by lesson ID.
+func (s *Store) Lessons() (map[string]*Lesson, error) {
+	out := map[string]*Lesson{}
+	get := func(id string) *Lesson {
+		l := out[id]
+		if l == nil {
+			l = &Lesson{Functions: map[string]Function{}}
+			out[id] = l
+		}
+		return l
+	}
+
+	rows, err := s.db.Query(
+		`SELECT lesson, COUNT(*), MAX(ran_at) FROM lesson_runs WHERE exit_code = 0 GROUP BY lesson`)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var id, last string
+		var runs int
+		if err := rows.Scan(&id, &runs, &last); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		l := get(id)
+		l.Runs = runs
+		l.LastRun = parseTimestamp(last)
+	}
+	if err := closeRows(rows); err != nil {
+		return nil, err
+	}
+
+	rows, err = s.db.Query(
+		`SELECT lesson, function, COUNT(*), SUM(NOT passed), MAX(passed), MAX(attempted_at)
+		 FROM exercise_attempts GROUP BY lesson, function`)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var id, fn, last string
+		var f Function
+		if err := rows.Scan(&id, &fn, &f.Attempts, &f.Failures, &f.Passed, &last); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		f.LastAttempt = parseTimestamp(last)
+		get(id).Functions[fn] = f
+	}
+	if err := closeRows(rows); err != nil {
+		return nil, err
+	}
+
+	// SQLite returns the bare columns of the row holding MAX(score), so
+	// total belongs to the best attempt.
+	rows, err = s.db.Query(
+		`SELECT lesson, COUNT(*), MAX(score), total FROM quiz_results GROUP BY lesson`)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var id string
+		var quizzes, best, total int
+		if err := rows.Scan(&id, &quizzes, &best, &total); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		l := get(id)
+		l.Quizzes, l.QuizBest, l.QuizTotal = quizzes, best, total
+	}
+	if err := closeRows(rows); err != nil {
+		return nil, err
+	}
+
+	rows, err = s.db.Query(
+		`SELECT lesson, function, MAX(hint), MAX(kind = 'solution')
+		 FROM reveals GROUP BY lesson, function`)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var id, fn string
+		var hints int
+		var solution bool
+		if err := rows.Scan(&id, &fn, &hints, &solution); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		l := get(id)
+		f := l.Functions[fn]
+		f.HintsRevealed, f.SolutionRevealed = hints, solution
+		l.Functions[fn] = f
+	}
+	if err := closeRows(rows); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func closeRows(rows *sql.Rows) error {
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return err
+	}
+	return rows.Close()
+}
+
+// timeLayout is RFC 3339 in UTC with fixed-width nanoseconds, so stored
+// timestamps sort correctly as strings and MAX() returns the latest.
+const timeLayout = "2006-01-02T15:04:05.000000000Z"
+
+func (s *Store) timestamp() string {
+	return s.now().UTC().Format(timeLayout)
+}
+
+func parseTimestamp(v string) time.Time {
+	t, _ := time.Parse(timeLayout, v)
+	return t
+}
@@ -0,0 +1,103 @@
+package progress
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// openTest opens a fresh store whose clock advances one minute per
+// record, starting at start.
+func openTest(t *testing.T, start time.Time) *Store {
+	t.Helper()
+	s, err := Open(filepath.Join(t.TempDir(), "progress.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.Close() })
+	now := start
+	s.now = func() time.Time {
+		now = now.Add(time.Minute)
+		return now
+	}
+	return s
+}
+
+func TestLessons(t *testing.T) {
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	s := openTest(t, start)
+
+	record := []func() error{
+		func() error { return s.RecordRun("01-basics/functions", 0) },
+		func() error { return s.RecordRun("01-basics/functions", 1) }, // failed runs do not count
+		func() error { return s.RecordRun("01-basics/functions", 0) },
+		func() error { return s.RecordAttempt("01-basics/functions", "divide", false) },
+		func() error { return s.RecordAttempt("01-basics/functions", "divide", false) },
+		func() error { return s.RecordAttempt("01-basics/functions", "divide", true) },
+		func() error { return s.RecordAttempt("01-basics/functions", "sum", false) },
+		func() error { return s.RecordHint("01-basics/functions", "sum", 1) },
+		func() error { return s.RecordHint("01-basics/functions", "sum", 2) },
+		func() error { return s.RecordSolution("01-basics/functions", "sum") },
+		func() error { return s.RecordQuiz("01-basics/functions", 2, 5) },
+		func() error { return s.RecordQuiz("01-basics/functions", 4, 6) },
+		func() error { return s.RecordQuiz("01-basics/functions", 3, 5) },
+	}
+	for i, f := range record {
+		if err := f(); err != nil {
+			t.Fatalf("record %d: %v", i, err)
+		}
+	}
+
+	all, err := s.Lessons()
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := all["01-basics/functions"]
+	if l == nil || len(all) != 1 {
+		t.Fatalf("Lessons() = %v; want only 01-basics/functions", all)
+	}
+	if !l.Ran() || l.Runs != 2 {
+		t.Errorf("Runs = %d; want 2", l.Runs)
+	}
+	if want := start.Add(3 * time.Minute); !l.LastRun.Equal(want) {
+		t.Errorf("LastRun = %v; want %v", l.LastRun, want)
+	}
+	if l.Quizzes != 3 || l.QuizBest != 4 || l.QuizTotal != 6 {
+		t.Errorf("quizzes = %d, best %d/%d; want 3, best 4/6", l.Quizzes, l.QuizBest, l.QuizTotal)
+	}
+
+	divide := Function{Attempts: 3, Failures: 2, Passed: true, LastAttempt: start.Add(6 * time.Minute)}
+	if got := l.Functions["divide"]; got != divide {
+		t.Errorf("divide = %+v; want %+v", got, divide)
+	}
+	sum := Function{Attempts: 1, Failures: 1, LastAttempt: start.Add(7 * time.Minute), HintsRevealed: 2, SolutionRevealed: true}
+	if got := l.Functions["sum"]; got != sum {
+		t.Errorf("sum = %+v; want %+v", got, sum)
+	}
+}
+
+func TestReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "progress.db")
+	s, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.RecordRun("01-basics/functions", 0); err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+
+	// Opening again must find the schema current and keep the data.
+	s, err = Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	all, err := s.Lessons()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l := all["01-basics/functions"]; l == nil || l.Runs != 1 {
+		t.Errorf("after reopening: %+v; want one run", l)
+	}
+}
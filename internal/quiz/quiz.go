@@ -0,0 +1,183 @@
+// Package quiz loads and scores the quizzes that check a lesson's Key
+// Concepts.
+//
+// A lesson's quiz lives in quiz.json next to its main.go:
+//
+//	{
+//	  "questions": [
+//	    {
+//	      "type": "choice",
+//	      "concept": "Zero values",
+//	      "question": "What is the zero value of a string?",
+//	      "options": ["nil", "\"\"", "\" \""],
+//	      "answer": "2"
+//	    },
+//	    {
+//	      "type": "output",
+//	      "concept": "Recursion",
+//	      "question": "What does this line print?",
+//	      "code": "fmt.Printf(\"Factorial of 5: %d\\n\", factorial(5))",
+//	      "answer_from": "^Factorial of 5: (.*)$"
+//	    }
+//	  ]
+//	}
+//
+// "choice" questions are multiple choice; answer is the 1-based number of
+// the correct option. "output" questions ask the learner to predict
+// output. Their answer is either given literally or, with answer_from,
+// taken from the example's real output: the first line matching the
+// regular expression, or its first capture group if it has one.
+package quiz
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang-learning-project/internal/lessons"
+)
+
+// File is the name of a lesson's quiz, next to its main.go.
+const File = "quiz.json"
+
+// Question types.
+const (
+	TypeChoice = "choice"
+	TypeOutput = "output"
+)
+
+// ErrNoQuiz is returned by Load for lessons without a quiz.
+var ErrNoQuiz = errors.New("lesson has no quiz")
+
+// Quiz is the quiz of one lesson.
+type Quiz struct {
+	Lesson    lessons.Lesson `json:"-"`
+	Questions []Question     `json:"questions"`
+}
+
+// Question is one quiz question.
+type Question struct {
+	Type        string   `json:"type"`
+	Concept     string   `json:"concept"`
+	Question    string   `json:"question"`
+	Code        string   `json:"code,omitempty"`
+	Options     []string `json:"options,omitempty"`
+	Answer      string   `json:"answer,omitempty"`
+	AnswerFrom  string   `json:"answer_from,omitempty"`
+	Explanation string   `json:"explanation,omitempty"`
+}
+
+// Load reads and validates the quiz of l.
+func Load(root string, l lessons.Lesson) (Quiz, error) {
+	q := Quiz{Lesson: l}
+	file := filepath.Join(root, filepath.FromSlash(l.Dir), File)
+	data, err := os.ReadFile(file)
+	if os.IsNotExist(err) {
+		return q, fmt.Errorf("%s: %w", l.ID, ErrNoQuiz)
+	}
+	if err != nil {
+		return q, err
+	}
+	if err := json.Unmarshal(data, &q); err != nil {
+		return q, fmt.Errorf("%s/%s: %w", l.Dir, File, err)
+	}
+	for i, question := range q.Questions {
+		if err := question.validate(); err != nil {
+			return q, fmt.Errorf("%s/%s: question %d: %w", l.Dir, File, i+1, err)
+		}
+	}
+	return q, nil
+}
+
+func (q Question) validate() error {
+	switch q.Type {
+	case TypeChoice:
+		n, err := strconv.Atoi(q.Answer)
+		if err != nil || n < 1 || n > len(q.Options) {
+			return fmt.Errorf("answer %q is not an option number (1-%d)", q.Answer, len(q.Options))
+		}
+	case TypeOutput:
+		if (q.Answer == "") == (q.AnswerFrom == "") {
+			return errors.New("exactly one of answer and answer_from must be set")
+		}
+		if q.AnswerFrom != "" {
+			if _, err := regexp.Compile(q.AnswerFrom); err != nil {
+				return fmt.Errorf("answer_from: %w", err)
+			}
+		}
+	default:
+		return fmt.Errorf("unknown type %q", q.Type)
+	}
+	return nil
+}
+
+// NeedsOutput reports whether any question takes its answer from the
+// example's output, so the caller knows to run the example first.
+func (q Quiz) NeedsOutput() bool {
+	for _, question := range q.Questions {
+		if question.AnswerFrom != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveAnswers fills in the answer of every answer_from question from
+// output, the example's stdout.
+func (q *Quiz) ResolveAnswers(output string) error {
+	lines := strings.Split(strings.ReplaceAll(output, "\r\n", "\n"), "\n")
+	for i := range q.Questions {
+		question := &q.Questions[i]
+		if question.AnswerFrom == "" {
+			continue
+		}
+		re := regexp.MustCompile(question.AnswerFrom) // validated by Load
+		found := false
+		for _, line := range lines {
+			m := re.FindStringSubmatch(line)
+			if m == nil {
+				continue
+			}
+			question.Answer = m[0]
+			if len(m) > 1 {
+				question.Answer = m[1]
+			}
+			found = true
+			break
+		}
+		if !found {
+			return fmt.Errorf("question %d: no output line matches %q", i+1, question.AnswerFrom)
+		}
+	}
+	return nil
+}
+
+// Correct reports whether response answers the question. Choice
+// questions accept the option number or the option text; output
+// questions compare with surrounding and repeated white space ignored.
+func (q Question) Correct(response string) bool {
+	response = normalize(response)
+	if q.Type == TypeChoice {
+		n, _ := strconv.Atoi(q.Answer)
+		return response == q.Answer || strings.EqualFold(response, normalize(q.Options[n-1]))
+	}
+	return response == normalize(q.Answer)
+}
+
+// CorrectAnswer returns the answer as shown to the learner.
+func (q Question) CorrectAnswer() string {
+	if q.Type == TypeChoice {
+		n, _ := strconv.Atoi(q.Answer)
+		return fmt.Sprintf("%d. %s", n, q.Options[n-1])
+	}
+	return q.Answer
+}
+
+func normalize(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
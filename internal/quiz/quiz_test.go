@@ -0,0 +1,131 @@
+package quiz_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang-learning-project/internal/lessons"
+	"golang-learning-project/internal/quiz"
+)
+
+var lesson = lessons.Lesson{ID: "01-basics/functions", Dir: "examples/01-basics/functions"}
+
+// load writes data as the quiz of lesson under a new root and loads it.
+func load(t *testing.T, data string) (quiz.Quiz, error) {
+	t.Helper()
+	root := t.TempDir()
+	dir := filepath.Join(root, filepath.FromSlash(lesson.Dir))
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, quiz.File), []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return quiz.Load(root, lesson)
+}
+
+func TestLoad(t *testing.T) {
+	q, err := load(t, `{"questions": [
+		{"type": "choice", "concept": "Zero values", "question": "Zero string?", "options": ["nil", "\"\""], "answer": "2"},
+		{"type": "output", "concept": "Recursion", "question": "Prints?", "answer_from": "^Factorial of 5: (.*)$"}
+	]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q.Lesson.ID != lesson.ID || len(q.Questions) != 2 || q.Questions[1].AnswerFrom == "" || !q.NeedsOutput() {
+		t.Errorf("Load = %+v", q)
+	}
+
+	tests := []struct {
+		name, data, err string
+	}{
+		{"missing answer", `{"questions": [{"type": "choice", "options": ["a", "b"]}]}`, `question 1: answer "" is not an option number (1-2)`},
+		{"answer above the options", `{"questions": [{"type": "choice", "options": ["a", "b"], "answer": "3"}]}`, `answer "3" is not an option number (1-2)`},
+		{"answer 0", `{"questions": [{"type": "choice", "options": ["a"], "answer": "0"}]}`, `answer "0" is not an option number (1-1)`},
+		{"answer as text", `{"questions": [{"type": "choice", "options": ["a"], "answer": "a"}]}`, `answer "a" is not an option number`},
+		{"no options", `{"questions": [{"type": "choice", "answer": "1"}]}`, `(1-0)`},
+		{"output without an answer", `{"questions": [{"type": "output"}]}`, "exactly one of answer and answer_from"},
+		{"output with both", `{"questions": [{"type": "output", "answer": "1", "answer_from": "x"}]}`, "exactly one of answer and answer_from"},
+		{"bad answer_from", `{"questions": [{"type": "output", "answer_from": "("}]}`, "answer_from: error parsing regexp"},
+		{"unknown type", `{"questions": [{"type": "essay"}]}`, `unknown type "essay"`},
+		{"second question", `{"questions": [{"type": "output", "answer": "1"}, {"type": "choice"}]}`, "question 2:"},
+		{"bad JSON", `{"questions": [`, "examples/01-basics/functions/quiz.json: unexpected end of JSON input"},
+	}
+	for _, tt := range tests {
+		if _, err := load(t, tt.data); err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("%s: Load = %v, want %q", tt.name, err, tt.err)
+		}
+	}
+
+	if _, err := quiz.Load(t.TempDir(), lesson); !errors.Is(err, quiz.ErrNoQuiz) {
+		t.Errorf("Load without quiz.json = %v, want ErrNoQuiz", err)
+	}
+}
+
+func TestResolveAnswers(t *testing.T) {
+	output := "Factorial of 5: 120\r\nSum: 6\nSum: 7\n"
+	tests := []struct {
+		name, from, want string
+	}{
+		{"capture group", `^Factorial of 5: (.*)$`, "120"},
+		{"whole match", `Sum: \d`, "Sum: 6"},
+		{"first line that matches", `^Sum: (\d)$`, "6"},
+		{"first group only", `^(\w+) of (\d)`, "Factorial"},
+	}
+	for _, tt := range tests {
+		q := quiz.Quiz{Questions: []quiz.Question{
+			{Type: quiz.TypeOutput, Answer: "kept"},
+			{Type: quiz.TypeOutput, AnswerFrom: tt.from},
+		}}
+		if err := q.ResolveAnswers(output); err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if got := q.Questions[1].Answer; got != tt.want || q.Questions[0].Answer != "kept" {
+			t.Errorf("%s: answers %q and %q, want kept and %q", tt.name, q.Questions[0].Answer, got, tt.want)
+		}
+	}
+
+	q := quiz.Quiz{Questions: []quiz.Question{
+		{Type: quiz.TypeOutput, AnswerFrom: "^Sum"},
+		{Type: quiz.TypeOutput, AnswerFrom: "^Product: (.*)$"},
+	}}
+	if err := q.ResolveAnswers(output); err == nil || !strings.Contains(err.Error(), `question 2: no output line matches "^Product: (.*)$"`) {
+		t.Errorf("ResolveAnswers with no match = %v", err)
+	}
+}
+
+func TestCorrect(t *testing.T) {
+	choice := quiz.Question{Type: quiz.TypeChoice, Options: []string{"nil", `""`, "Zero  Value"}, Answer: "3"}
+	output := quiz.Question{Type: quiz.TypeOutput, Answer: "Sum:  6 "}
+	tests := []struct {
+		q        quiz.Question
+		response string
+		want     bool
+	}{
+		{choice, "3", true},
+		{choice, " 3\n", true},
+		{choice, "03", false},
+		{choice, "2", false},
+		{choice, "Zero Value", true},
+		{choice, "  zero   VALUE ", true},
+		{choice, "nil", false},
+		{choice, "", false},
+		{output, "Sum: 6", true},
+		{output, "\tSum:\n6", true},
+		{output, "sum: 6", false},
+		{output, "Sum: 7", false},
+	}
+	for _, tt := range tests {
+		if got := tt.q.Correct(tt.response); got != tt.want {
+			t.Errorf("%s question, Correct(%q) = %v, want %v", tt.q.Type, tt.response, got, tt.want)
+		}
+	}
+
+	if got := choice.CorrectAnswer(); got != "3. Zero  Value" {
+		t.Errorf("CorrectAnswer = %q", got)
+	}
+}
@@ -0,0 +1,82 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// command returns the command that runs bin under lim. The rlimits are
+// set by a shell that then execs bin, so they apply from the program's
+// first instruction. RLIMIT_DATA is used for memory rather than
+// RLIMIT_AS because the Go runtime reserves far more address space at
+// start-up than it ever touches.
+func command(ctx context.Context, bin, dir string, lim Limits) *exec.Cmd {
+	script := []string{"ulimit -c 0"}
+	if lim.CPUTime > 0 {
+		script = append(script, fmt.Sprintf("ulimit -t %d", cpuSeconds(lim)))
+	}
+	if lim.Memory > 0 {
+		script = append(script, fmt.Sprintf("ulimit -d %d", lim.Memory>>10)) // KiB
+	}
+	script = append(script, `exec "$0"`)
+
+	cmd := exec.CommandContext(ctx, "/bin/sh", "-c", strings.Join(script, "; "), bin)
+	cmd.Env = []string{
+		"PATH=/usr/bin:/bin",
+		"HOME=" + dir,
+		"TMPDIR=" + dir,
+		"LANG=C.UTF-8",
+	}
+	// Its own process group, so that killing it also kills anything it
+	// started.
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+	return cmd
+}
+
+// limitReason tells from how the program died whether an rlimit stopped
+// it. The kernel sends SIGXCPU at the soft CPU limit and SIGKILL at the
+// hard one, but a SIGKILL may also come from elsewhere, such as the OOM
+// killer, so it only counts as the CPU limit if the program used about
+// that much CPU. A failed heap allocation makes the Go runtime abort.
+func limitReason(exitErr *exec.ExitError, stderr string, lim Limits) Reason {
+	if ws, ok := exitErr.Sys().(syscall.WaitStatus); ok && ws.Signaled() {
+		switch ws.Signal() {
+		case syscall.SIGXCPU:
+			return ReasonCPUTime
+		case syscall.SIGKILL:
+			// The rusage times are sampled, so they can fall a few
+			// milliseconds short of the limit the kernel enforced.
+			limit := time.Duration(cpuSeconds(lim)) * time.Second
+			if lim.CPUTime > 0 && cpuTime(exitErr.ProcessState) >= limit*9/10 {
+				return ReasonCPUTime
+			}
+		}
+	}
+	if outOfMemory(stderr) {
+		return ReasonMemory
+	}
+	return ReasonNone
+}
+
+// cpuSeconds is the CPU limit in the whole seconds of RLIMIT_CPU,
+// rounded up.
+func cpuSeconds(lim Limits) int64 {
+	return int64((lim.CPUTime + time.Second - 1) / time.Second)
+}
+
+// cpuTime returns the user plus system CPU time a finished process used.
+func cpuTime(ps *os.ProcessState) time.Duration {
+	ru, ok := ps.SysUsage().(*syscall.Rusage)
+	if !ok {
+		return 0
+	}
+	return time.Duration(ru.Utime.Nano() + ru.Stime.Nano())
+}
@@ -0,0 +1,23 @@
+//go:build !linux
+
+package runner
+
+import (
+	"context"
+	"os/exec"
+)
+
+// command returns the command that runs bin. Outside Linux only the
+// wall-clock and output limits apply, and the environment is inherited.
+func command(ctx context.Context, bin, dir string, lim Limits) *exec.Cmd {
+	return exec.CommandContext(ctx, bin)
+}
+
+// limitReason reports ReasonMemory if the program ran out of memory;
+// no other limits are enforced by the system here.
+func limitReason(exitErr *exec.ExitError, stderr string, lim Limits) Reason {
+	if outOfMemory(stderr) {
+		return ReasonMemory
+	}
+	return ReasonNone
+}
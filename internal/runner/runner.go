@@ -0,0 +1,233 @@
+// Package runner builds and runs untrusted Go snippets, such as code
+// typed into the playground, under resource limits.
+//
+// Each snippet is written as the main package of a fresh module in a
+// temporary directory, built with the local toolchain and run as a child
+// process with:
+//
+//   - a wall-clock deadline, after which the whole process group is killed;
+//   - a cap on the bytes kept from stdout and stderr, after which it is
+//     killed as well;
+//   - on Linux, CPU-time and data-segment (heap) rlimits, no core dumps,
+//     and an environment scrubbed down to a few harmless variables.
+//
+// This limits runaway programs; it is not a security boundary. The child
+// runs as the current user with full file-system and network access, so
+// only run code from people you would let use your terminal.
+package runner
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Limits bounds one run. A zero field means no limit of that kind.
+type Limits struct {
+	BuildTimeout time.Duration // for go build, which is trusted
+	WallTime     time.Duration
+	CPUTime      time.Duration // Linux only; whole seconds
+	Memory       int64         // bytes of heap; Linux only
+	Output       int           // bytes kept from each of stdout and stderr
+}
+
+// DefaultLimits suit short teaching examples.
+var DefaultLimits = Limits{
+	BuildTimeout: 30 * time.Second,
+	WallTime:     5 * time.Second,
+	CPUTime:      3 * time.Second,
+	Memory:       256 << 20,
+	Output:       64 << 10,
+}
+
+// Reason says why a run was killed.
+type Reason string
+
+// Reasons a run is killed.
+const (
+	ReasonNone     Reason = ""
+	ReasonWallTime Reason = "wall-clock limit exceeded"
+	ReasonCPUTime  Reason = "CPU-time limit exceeded"
+	ReasonMemory   Reason = "memory limit exceeded"
+	ReasonOutput   Reason = "output limit exceeded"
+	ReasonCanceled Reason = "canceled"
+)
+
+// Result describes one finished run.
+type Result struct {
+	// BuildFailed is set when the snippet did not compile. Stderr then
+	// holds the compiler output and ExitCode is 2, as with go run.
+	BuildFailed bool
+
+	ExitCode  int // -1 if the program was killed by a signal
+	Stdout    string
+	Stderr    string
+	Duration  time.Duration // of the run, excluding the build
+	Truncated bool          // output beyond Limits.Output was dropped
+	Killed    bool
+	Reason    Reason
+}
+
+// Run builds src as package main and runs it under lim. Problems with
+// the snippet itself — compile errors, crashes, exceeded limits — are
+// reported in the Result; err is only set when the runner could not do
+// its job, for example because the temporary directory could not be
+// created or the go command is missing.
+func Run(ctx context.Context, src string, lim Limits) (Result, error) {
+	var res Result
+	tmp, err := os.MkdirTemp("", "go-learning-runner-")
+	if err != nil {
+		return res, err
+	}
+	defer os.RemoveAll(tmp)
+
+	if err := build(ctx, tmp, src, lim, &res); err != nil || res.BuildFailed {
+		return res, err
+	}
+	return res, execute(ctx, tmp, lim, &res)
+}
+
+const binName = "prog"
+
+// build compiles src in a new module under dir.
+func build(ctx context.Context, dir, src string, lim Limits, res *Result) error {
+	files := map[string]string{
+		"go.mod":  "module snippet\n\ngo 1.24\n",
+		"main.go": src,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			return err
+		}
+	}
+
+	if lim.BuildTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, lim.BuildTimeout)
+		defer cancel()
+	}
+	out := &limitedBuffer{limit: lim.Output}
+	cmd := exec.CommandContext(ctx, "go", "build", "-o", binName, ".")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOWORK=off", "GOFLAGS=-mod=mod", "GOTOOLCHAIN=local")
+	cmd.Stdout = out
+	cmd.Stderr = out
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	switch {
+	case errors.As(err, &exitErr) || (err != nil && ctx.Err() != nil):
+		res.BuildFailed = true
+		res.ExitCode = 2
+		res.Stderr = strings.ReplaceAll(out.String(), dir+string(filepath.Separator), "")
+		res.Truncated = out.truncated
+		if ctx.Err() != nil {
+			res.Killed, res.Reason = true, ReasonWallTime
+			res.Stderr += "go build: timed out\n"
+		}
+		return nil
+	case err != nil:
+		return err
+	}
+	return nil
+}
+
+// execute runs the built binary in dir.
+func execute(ctx context.Context, dir string, lim Limits, res *Result) error {
+	if lim.WallTime > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, lim.WallTime)
+		defer cancel()
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// The first limit to trip records why the program is being killed.
+	var once sync.Once
+	kill := func(r Reason) {
+		once.Do(func() { res.Reason = r })
+		cancel()
+	}
+	stdout := &limitedBuffer{limit: lim.Output, full: func() { kill(ReasonOutput) }}
+	stderr := &limitedBuffer{limit: lim.Output, full: func() { kill(ReasonOutput) }}
+
+	cmd := command(ctx, filepath.Join(dir, binName), dir, lim)
+	cmd.Dir = dir
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	cmd.WaitDelay = time.Second
+
+	start := time.Now()
+	err := cmd.Run()
+	res.Duration = time.Since(start)
+	res.Stdout, res.Stderr = stdout.String(), stderr.String()
+	res.Truncated = stdout.truncated || stderr.truncated
+
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) && ctx.Err() == nil {
+		return err
+	}
+	if exitErr != nil {
+		res.ExitCode = exitErr.ExitCode()
+	}
+
+	switch {
+	case res.Reason != ReasonNone:
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		res.Reason = ReasonWallTime
+	case ctx.Err() != nil:
+		res.Reason = ReasonCanceled
+	case exitErr != nil:
+		res.Reason = limitReason(exitErr, res.Stderr, lim)
+	}
+	res.Killed = res.Reason != ReasonNone
+	return nil
+}
+
+// outOfMemory reports whether stderr ends with the Go runtime's fatal
+// error for a failed heap allocation.
+func outOfMemory(stderr string) bool {
+	return strings.Contains(stderr, "fatal error: runtime: cannot allocate memory") ||
+		strings.Contains(stderr, "fatal error: runtime: out of memory") ||
+		strings.Contains(stderr, "fatal error: out of memory")
+}
+
+// limitedBuffer keeps the first limit bytes written to it (all of them if
+// limit is 0) and calls full once when more arrive. Writes always
+// succeed, so the program is not stopped by a broken pipe before it is
+// killed.
+type limitedBuffer struct {
+	mu        sync.Mutex
+	buf       bytes.Buffer
+	limit     int
+	truncated bool
+	full      func()
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	room := b.limit - b.buf.Len()
+	if b.limit == 0 || len(p) <= room {
+		return b.buf.Write(p)
+	}
+	if room > 0 {
+		b.buf.Write(p[:room])
+	}
+	if !b.truncated && b.full != nil {
+		b.full()
+	}
+	b.truncated = true
+	return len(p), nil
+}
+
+func (b *limitedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
@@ -0,0 +1,211 @@
+package runner
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// run builds and runs src under lim, failing the test if the runner
+// itself fails.
+func run(t *testing.T, src string, lim Limits) Result {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("builds and runs a snippet")
+	}
+	res, err := Run(context.Background(), src, lim)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res
+}
+
+func linuxOnly(t *testing.T) {
+	t.Helper()
+	if runtime.GOOS != "linux" {
+		t.Skip("rlimits are only set on Linux")
+	}
+}
+
+const hello = `package main
+
+import (
+	"fmt"
+	"os"
+)
+
+func main() {
+	fmt.Println("hello")
+	fmt.Fprintln(os.Stderr, "to stderr")
+	os.Exit(3)
+}
+`
+
+func TestRun(t *testing.T) {
+	res := run(t, hello, DefaultLimits)
+	if res.Stdout != "hello\n" || res.Stderr != "to stderr\n" || res.ExitCode != 3 {
+		t.Errorf("got stdout %q, stderr %q, exit %d; want hello, to stderr, 3", res.Stdout, res.Stderr, res.ExitCode)
+	}
+	if res.Killed || res.Reason != ReasonNone || res.BuildFailed {
+		t.Errorf("got %+v; want a normal exit", res)
+	}
+}
+
+func TestRunEnvironment(t *testing.T) {
+	linuxOnly(t)
+	t.Setenv("RUNNER_SECRET", "leaked")
+	res := run(t, `package main
+
+import (
+	"fmt"
+	"os"
+)
+
+func main() { fmt.Print(os.Getenv("RUNNER_SECRET")) }
+`, DefaultLimits)
+	if res.Stdout != "" {
+		t.Errorf("the snippet saw RUNNER_SECRET=%q; want a scrubbed environment", res.Stdout)
+	}
+}
+
+func TestRunBuildFailed(t *testing.T) {
+	res := run(t, "package main\n\nfunc main() { undefined() }\n", DefaultLimits)
+	if !res.BuildFailed || res.ExitCode != 2 || !strings.Contains(res.Stderr, "undefined") {
+		t.Errorf("got %+v; want a build failure naming undefined", res)
+	}
+	if strings.Contains(res.Stderr, "go-learning-runner-") {
+		t.Errorf("stderr %q shows the temporary directory", res.Stderr)
+	}
+}
+
+func TestRunLimits(t *testing.T) {
+	tests := []struct {
+		name      string
+		linux     bool
+		src       string
+		lim       Limits
+		reason    Reason
+		maxTime   time.Duration
+		truncated bool
+	}{
+		{
+			name: "wall time",
+			src: `package main
+
+import "time"
+
+func main() { time.Sleep(time.Hour) }
+`,
+			lim:     Limits{WallTime: 300 * time.Millisecond},
+			reason:  ReasonWallTime,
+			maxTime: 3 * time.Second,
+		},
+		{
+			name: "output",
+			src: `package main
+
+import "fmt"
+
+func main() {
+	for {
+		fmt.Println("spam")
+	}
+}
+`,
+			lim:       Limits{WallTime: 10 * time.Second, Output: 1 << 10},
+			reason:    ReasonOutput,
+			maxTime:   5 * time.Second,
+			truncated: true,
+		},
+		{
+			name:  "CPU time",
+			linux: true,
+			src: `package main
+
+func main() {
+	for n := 0; ; n++ {
+	}
+}
+`,
+			lim:     Limits{WallTime: 20 * time.Second, CPUTime: time.Second},
+			reason:  ReasonCPUTime,
+			maxTime: 10 * time.Second,
+		},
+		{
+			name:  "memory",
+			linux: true,
+			src: `package main
+
+import "fmt"
+
+func main() {
+	var keep [][]byte
+	for {
+		b := make([]byte, 16<<20)
+		for i := range b {
+			b[i] = 1
+		}
+		keep = append(keep, b)
+		fmt.Println(len(keep))
+	}
+}
+`,
+			lim:     Limits{WallTime: 20 * time.Second, Memory: 64 << 20},
+			reason:  ReasonMemory,
+			maxTime: 10 * time.Second,
+		},
+		{
+			// A SIGKILL that is not the CPU limit's must not be
+			// reported as one.
+			name:  "other SIGKILL",
+			linux: true,
+			src: `package main
+
+import (
+	"os"
+	"syscall"
+)
+
+func main() { syscall.Kill(os.Getpid(), syscall.SIGKILL) }
+`,
+			lim:     Limits{WallTime: 10 * time.Second, CPUTime: time.Second},
+			reason:  ReasonNone,
+			maxTime: 5 * time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.linux {
+				linuxOnly(t)
+			}
+			res := run(t, tt.src, tt.lim)
+			if res.Reason != tt.reason || res.Killed != (tt.reason != ReasonNone) {
+				t.Errorf("Reason = %q, Killed = %v; want %q (exit %d, stderr %q)", res.Reason, res.Killed, tt.reason, res.ExitCode, res.Stderr)
+			}
+			if res.Duration > tt.maxTime {
+				t.Errorf("ran for %v; want at most %v", res.Duration, tt.maxTime)
+			}
+			if res.Truncated != tt.truncated {
+				t.Errorf("Truncated = %v; want %v", res.Truncated, tt.truncated)
+			}
+			if tt.truncated && len(res.Stdout) > tt.lim.Output {
+				t.Errorf("kept %d bytes of stdout; want at most %d", len(res.Stdout), tt.lim.Output)
+			}
+		})
+	}
+}
+
+func TestLimitedBuffer(t *testing.T) {
+	calls := 0
+	b := &limitedBuffer{limit: 5, full: func() { calls++ }}
+	for _, s := range []string{"abc", "def", "ghi"} {
+		if n, err := b.Write([]byte(s)); n != len(s) || err != nil {
+			t.Fatalf("Write(%q) = %d, %v; want %d, nil", s, n, err, len(s))
+		}
+	}
+	if b.String() != "abcde" || !b.truncated || calls != 1 {
+		t.Errorf("got %q, truncated %v, full called %d times; want abcde, true, 1", b.String(), b.truncated, calls)
+	}
+}
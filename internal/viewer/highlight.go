@@ -0,0 +1,81 @@
+package viewer
+
+import (
+	"go/scanner"
+	"go/token"
+	"html"
+	"html/template"
+	"strings"
+)
+
+// predeclared identifiers get their own colour, like in most editors.
+var predeclared = map[string]bool{
+	"bool": true, "byte": true, "complex64": true, "complex128": true,
+	"error": true, "float32": true, "float64": true, "int": true,
+	"int8": true, "int16": true, "int32": true, "int64": true, "rune": true,
+	"string": true, "uint": true, "uint8": true, "uint16": true,
+	"uint32": true, "uint64": true, "uintptr": true, "any": true,
+	"true": true, "false": true, "iota": true, "nil": true,
+	"append": true, "cap": true, "clear": true, "close": true, "complex": true,
+	"copy": true, "delete": true, "imag": true, "len": true, "make": true,
+	"max": true, "min": true, "new": true, "panic": true, "print": true,
+	"println": true, "real": true, "recover": true,
+}
+
+// Highlight returns src as HTML with Go tokens wrapped in spans of class
+// kw (keywords), id (predeclared identifiers), str, num and com
+// (comments). Everything between tokens, including white space, is
+// copied unchanged, so the layout of the source is preserved. Source that
+// does not scan cleanly is still rendered; the scanner just recovers.
+func Highlight(src []byte) template.HTML {
+	var sb strings.Builder
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(src))
+	var s scanner.Scanner
+	s.Init(file, src, func(token.Position, string) {}, scanner.ScanComments)
+
+	last := 0
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+		start := file.Offset(pos)
+		// Automatically inserted semicolons have no text in the source.
+		if tok == token.SEMICOLON && lit == "\n" {
+			continue
+		}
+		end := start + len(lit)
+		if lit == "" {
+			end = start + len(tok.String())
+		}
+		if start < last || end > len(src) {
+			continue
+		}
+
+		class := ""
+		switch {
+		case tok.IsKeyword():
+			class = "kw"
+		case tok == token.IDENT && predeclared[lit]:
+			class = "id"
+		case tok == token.STRING || tok == token.CHAR:
+			class = "str"
+		case tok == token.INT || tok == token.FLOAT || tok == token.IMAG:
+			class = "num"
+		case tok == token.COMMENT:
+			class = "com"
+		}
+		if class == "" {
+			continue
+		}
+
+		sb.WriteString(html.EscapeString(string(src[last:start])))
+		sb.WriteString(`<span class="` + class + `">`)
+		sb.WriteString(html.EscapeString(string(src[start:end])))
+		sb.WriteString(`</span>`)
+		last = end
+	}
+	sb.WriteString(html.EscapeString(string(src[last:])))
+	return template.HTML(sb.String())
+}
@@ -0,0 +1,46 @@
+package viewer
+
+import (
+	"context"
+
+	"golang-learning-project/internal/runner"
+)
+
+// Playground limits. Snippets come from a browser, so every run is
+// bounded in size and time; see runner.DefaultLimits for the rest.
+const (
+	maxSnippetBytes = 64 << 10
+	maxConcurrent   = 2
+)
+
+var limits = runner.DefaultLimits
+
+// runResult is the JSON reply of POST /run.
+type runResult struct {
+	Stdout     string `json:"stdout"`
+	Stderr     string `json:"stderr"`
+	ExitCode   int    `json:"exit_code"`
+	DurationMS int64  `json:"duration_ms"`
+	TimedOut   bool   `json:"timed_out"`
+	Truncated  bool   `json:"truncated"`
+	Killed     bool   `json:"killed"`
+	Reason     string `json:"reason,omitempty"`
+}
+
+// runSnippet compiles and runs code with the runner under limits.
+func runSnippet(ctx context.Context, code string) (runResult, error) {
+	res, err := runner.Run(ctx, code, limits)
+	if err != nil {
+		return runResult{}, err
+	}
+	return runResult{
+		Stdout:     res.Stdout,
+		Stderr:     res.Stderr,
+		ExitCode:   res.ExitCode,
+		DurationMS: res.Duration.Milliseconds(),
+		TimedOut:   res.Reason == runner.ReasonWallTime,
+		Truncated:  res.Truncated,
+		Killed:     res.Killed,
+		Reason:     string(res.Reason),
+	}, nil
+}
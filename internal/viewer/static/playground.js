@@ -0,0 +1,44 @@
+// Posts the playground form to /run and shows the program's output. The
+// server only runs code sent with the token embedded in the page.
+(function () {
+  const form = document.getElementById("playground");
+  const status = document.getElementById("status");
+  const stdout = document.getElementById("stdout");
+  const stderr = document.getElementById("stderr");
+  const button = form.querySelector("button[type=submit]");
+
+  function show(el, text) {
+    el.textContent = text;
+    el.hidden = text === "";
+  }
+
+  form.addEventListener("submit", async (event) => {
+    event.preventDefault();
+    button.disabled = true;
+    status.textContent = "Running…";
+    show(stdout, "");
+    show(stderr, "");
+    try {
+      const resp = await fetch("/run", {
+        method: "POST",
+        headers: { "X-Playground-Token": form.dataset.token },
+        body: new URLSearchParams(new FormData(form)),
+      });
+      if (!resp.ok) {
+        status.textContent = (await resp.text()).trim();
+        return;
+      }
+      const res = await resp.json();
+      show(stdout, res.stdout);
+      show(stderr, res.stderr);
+      let msg = `exit status ${res.exit_code} in ${res.duration_ms} ms`;
+      if (res.killed) msg += ` · killed: ${res.reason}`;
+      else if (res.truncated) msg += " · output truncated";
+      status.textContent = msg;
+    } catch (err) {
+      status.textContent = `request failed: ${err}`;
+    } finally {
+      button.disabled = false;
+    }
+  });
+})();
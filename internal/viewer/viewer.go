@@ -0,0 +1,186 @@
+// Package viewer serves the lessons to a browser: an index of the
+// catalog, a page per lesson with its parsed header and highlighted
+// source, and a playground that compiles and runs edited code locally.
+//
+// The playground executes whatever it is sent, so the server is meant to
+// listen on a loopback address only, and POST /run refuses requests that
+// another site could have made: see checkRun.
+package viewer
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"embed"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"golang-learning-project/internal/lessons"
+)
+
+//go:embed templates static
+var content embed.FS
+
+var templates = template.Must(template.ParseFS(content, "templates/*.html"))
+
+// Server is an http.Handler for the lesson viewer.
+type Server struct {
+	root  string
+	mux   *http.ServeMux
+	slots chan struct{} // limits concurrent playground runs
+	token string        // proves a run was sent by one of our pages
+}
+
+// New returns a Server for the lessons below root. The catalog is
+// discovered on every request, so edits on disk show up on reload.
+func New(root string) *Server {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err) // crypto/rand never fails on supported platforms
+	}
+	s := &Server{
+		root:  root,
+		mux:   http.NewServeMux(),
+		slots: make(chan struct{}, maxConcurrent),
+		token: hex.EncodeToString(b),
+	}
+	static, _ := fs.Sub(content, "static")
+	s.mux.Handle("GET /static/", http.StripPrefix("/static/", http.FileServerFS(static)))
+	s.mux.HandleFunc("GET /{$}", s.handleIndex)
+	s.mux.HandleFunc("GET /lessons/{id...}", s.handleLesson)
+	s.mux.HandleFunc("POST /run", s.handleRun)
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) handleIndex(w http.ResponseWriter, r *http.Request) {
+	catalog, err := lessons.Discover(s.root)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	s.render(w, "index.html", map[string]any{"Lessons": catalog})
+}
+
+func (s *Server) handleLesson(w http.ResponseWriter, r *http.Request) {
+	catalog, err := lessons.Discover(s.root)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	l, err := lessons.Find(catalog, r.PathValue("id"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	src, err := os.ReadFile(filepath.Join(s.root, filepath.FromSlash(l.File)))
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	s.render(w, "lesson.html", map[string]any{
+		"Lesson":      l,
+		"Source":      string(src),
+		"Highlighted": Highlight(src),
+		"Limits":      limits,
+		"OutputLimit": fmt.Sprintf("%d KiB", limits.Output>>10),
+		"MemoryLimit": fmt.Sprintf("%d MiB", limits.Memory>>20),
+		"Token":       s.token,
+	})
+}
+
+// TokenHeader carries the server's playground token. A page of another
+// site cannot read the token, and cannot set a custom header on a
+// cross-origin request without a CORS preflight, which is never granted.
+const TokenHeader = "X-Playground-Token"
+
+// checkRun returns why a run request must be refused, or "" if it may
+// go ahead. Besides the token, it refuses:
+//
+//   - a Host that is not loopback, as after DNS rebinding, where a page
+//     of another site resolves its own name to 127.0.0.1 and so counts
+//     as same-origin with us;
+//   - an Origin other than our own, or a Sec-Fetch-Site other than
+//     same-origin, which browsers send on every cross-site POST.
+func (s *Server) checkRun(r *http.Request) string {
+	host := r.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+		return "the playground only runs for loopback hosts, not " + r.Host
+	}
+	if o := r.Header.Get("Origin"); o != "" {
+		if u, err := url.Parse(o); err != nil || u.Host != r.Host {
+			return "cross-origin request from " + o
+		}
+	}
+	if site := r.Header.Get("Sec-Fetch-Site"); site != "" && site != "same-origin" {
+		return site + " request"
+	}
+	if subtle.ConstantTimeCompare([]byte(r.Header.Get(TokenHeader)), []byte(s.token)) != 1 {
+		return "missing or wrong " + TokenHeader + "; reload the page"
+	}
+	return ""
+}
+
+// handleRun compiles and runs the "code" form value and replies with a
+// runResult as JSON.
+func (s *Server) handleRun(w http.ResponseWriter, r *http.Request) {
+	if why := s.checkRun(r); why != "" {
+		http.Error(w, why, http.StatusForbidden)
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, 3*maxSnippetBytes) // room for URL encoding
+	err := r.ParseForm()
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) || len(r.PostForm.Get("code")) > maxSnippetBytes {
+		http.Error(w, "snippet too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	code := r.PostForm.Get("code")
+	if err != nil || code == "" {
+		http.Error(w, "missing code", http.StatusBadRequest)
+		return
+	}
+
+	select {
+	case s.slots <- struct{}{}:
+		defer func() { <-s.slots }()
+	default:
+		http.Error(w, "too many runs in progress, try again", http.StatusTooManyRequests)
+		return
+	}
+
+	res, err := runSnippet(r.Context(), code)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) render(w http.ResponseWriter, name string, data any) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := templates.ExecuteTemplate(w, name, data); err != nil {
+		log.Printf("render %s: %v", name, err)
+	}
+}
+
+func (s *Server) serverError(w http.ResponseWriter, err error) {
+	log.Print(err)
+	http.Error(w, "internal error", http.StatusInternalServerError)
+}
@@ -0,0 +1,95 @@
+package viewer
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// root is the repository root, seen from this package's directory.
+const root = "../.."
+
+func TestPages(t *testing.T) {
+	s := New(root)
+	tests := []struct {
+		path string
+		want int
+		body string
+	}{
+		{"/", http.StatusOK, "functions"},
+		{"/lessons/01-basics/functions", http.StatusOK, `data-token="` + s.token + `"`},
+		{"/lessons/no-such-lesson", http.StatusNotFound, ""},
+		{"/static/playground.js", http.StatusOK, "X-Playground-Token"},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, httptest.NewRequest("GET", tt.path, nil))
+		if rec.Code != tt.want || !strings.Contains(rec.Body.String(), tt.body) {
+			t.Errorf("GET %s = %d; want %d with %q", tt.path, rec.Code, tt.want, tt.body)
+		}
+	}
+}
+
+// TestRunRefusesOtherSites checks that only a request from one of our own
+// pages gets past checkRun. Runs that get past it have no code, so they
+// fail with 400 before anything is built.
+func TestRunRefusesOtherSites(t *testing.T) {
+	s := New(root)
+	page := httptest.NewRecorder()
+	s.ServeHTTP(page, httptest.NewRequest("GET", "/lessons/01-basics/functions", nil))
+	m := regexp.MustCompile(`data-token="([0-9a-f]+)"`).FindStringSubmatch(page.Body.String())
+	if m == nil {
+		t.Fatal("lesson page has no token")
+	}
+	token := m[1]
+
+	tests := []struct {
+		name   string
+		host   string
+		header map[string]string
+		want   int
+	}{
+		{"own page", "localhost:8080", map[string]string{TokenHeader: token, "Origin": "http://localhost:8080", "Sec-Fetch-Site": "same-origin"}, http.StatusBadRequest},
+		{"loopback IP", "127.0.0.1:8080", map[string]string{TokenHeader: token}, http.StatusBadRequest},
+		{"IPv6 loopback", "[::1]:8080", map[string]string{TokenHeader: token}, http.StatusBadRequest},
+		{"no token", "localhost:8080", nil, http.StatusForbidden},
+		{"wrong token", "localhost:8080", map[string]string{TokenHeader: "0123"}, http.StatusForbidden},
+		{"form from another site", "localhost:8080", map[string]string{"Origin": "https://evil.example", "Sec-Fetch-Site": "cross-site"}, http.StatusForbidden},
+		{"other origin with token", "localhost:8080", map[string]string{TokenHeader: token, "Origin": "https://evil.example"}, http.StatusForbidden},
+		{"other site with token", "localhost:8080", map[string]string{TokenHeader: token, "Sec-Fetch-Site": "same-site"}, http.StatusForbidden},
+		{"DNS rebinding", "evil.example:8080", map[string]string{TokenHeader: token, "Origin": "http://evil.example:8080", "Sec-Fetch-Site": "same-origin"}, http.StatusForbidden},
+		{"LAN address", "192.168.1.2:8080", map[string]string{TokenHeader: token}, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/run", strings.NewReader(url.Values{"code": {""}}.Encode()))
+			req.Host = tt.host
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			for k, v := range tt.header {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			s.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("POST /run = %d %q; want %d", rec.Code, strings.TrimSpace(rec.Body.String()), tt.want)
+			}
+		})
+	}
+}
+
+func TestRunTooLarge(t *testing.T) {
+	s := New(root)
+	body := url.Values{"code": {strings.Repeat("x", maxSnippetBytes+1)}}.Encode()
+	req := httptest.NewRequest("POST", "/run", strings.NewReader(body))
+	req.Host = "localhost"
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set(TokenHeader, s.token)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("POST /run with %d bytes = %d; want 413", maxSnippetBytes+1, rec.Code)
+	}
+}
@@ -0,0 +1,118 @@
+// Package checked does integer arithmetic that notices when a result
+// does not fit its type.
+//
+// Go's integer operators wrap around silently: an int8 holding 127 plus 1
+// is -128. For every integer type this package offers three behaviours:
+//
+//   - Add, Sub, Mul, Div and Convert return ErrOverflow, ErrDivideByZero,
+//     ErrTruncated or ErrSignLoss instead of a wrong result. The errors are
+//     the bare sentinels, so a failed check costs no allocation;
+//   - the Sat variants clamp to the nearest value the type can hold;
+//   - the Wrap variants wrap around, exactly like the built-in operators,
+//     for code that wants to say so explicitly.
+package checked
+
+import (
+	"errors"
+	"unsafe"
+)
+
+// Integer is any integer type.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+var (
+	// ErrOverflow is returned when the exact result of an operation is
+	// outside the range of its type.
+	ErrOverflow = errors.New("checked: integer overflow")
+
+	// ErrDivideByZero is returned by Div for a zero divisor.
+	ErrDivideByZero = errors.New("checked: integer divide by zero")
+
+	// ErrTruncated is returned by Convert when the value is too large in
+	// magnitude for the target type.
+	ErrTruncated = errors.New("checked: value out of range of target type")
+
+	// ErrSignLoss is returned by Convert for a negative value and an
+	// unsigned target type.
+	ErrSignLoss = errors.New("checked: negative value converted to unsigned type")
+)
+
+// Add returns a + b, or ErrOverflow.
+func Add[T Integer](a, b T) (T, error) {
+	c := a + b
+	if (c < a) != (b < 0) {
+		return 0, ErrOverflow
+	}
+	return c, nil
+}
+
+// Sub returns a - b, or ErrOverflow.
+func Sub[T Integer](a, b T) (T, error) {
+	c := a - b
+	if (c > a) != (b < 0) {
+		return 0, ErrOverflow
+	}
+	return c, nil
+}
+
+// Mul returns a * b, or ErrOverflow.
+func Mul[T Integer](a, b T) (T, error) {
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+	c := a * b
+	// Division undoes an exact product. MinInt * -1 is the one case it
+	// misses, because MinInt / -1 wraps back to MinInt.
+	if c/b != a || (signed[T]() && b == ^T(0) && a == minOf[T]()) {
+		return 0, ErrOverflow
+	}
+	return c, nil
+}
+
+// Div returns a / b truncated toward zero, like the / operator. It
+// returns ErrDivideByZero for b == 0 and ErrOverflow for the minimum
+// signed value divided by -1.
+func Div[T Integer](a, b T) (T, error) {
+	if b == 0 {
+		return 0, ErrDivideByZero
+	}
+	if signed[T]() && a == minOf[T]() && b == ^T(0) {
+		return 0, ErrOverflow
+	}
+	return a / b, nil
+}
+
+// Convert returns v as a To. It returns ErrSignLoss for a negative v and
+// an unsigned To, and ErrTruncated if v is outside the range of To.
+func Convert[To, From Integer](v From) (To, error) {
+	t := To(v)
+	switch {
+	case v < 0 && !signed[To]():
+		return 0, ErrSignLoss
+	case From(t) != v || (t < 0) != (v < 0):
+		return 0, ErrTruncated
+	}
+	return t, nil
+}
+
+// signed reports whether T is a signed integer type.
+func signed[T Integer]() bool {
+	return ^T(0) < 0
+}
+
+// minOf returns the smallest value of T.
+func minOf[T Integer]() T {
+	if !signed[T]() {
+		return 0
+	}
+	var zero T
+	return T(1) << (unsafe.Sizeof(zero)*8 - 1)
+}
+
+// maxOf returns the largest value of T.
+func maxOf[T Integer]() T {
+	return ^minOf[T]()
+}
@@ -0,0 +1,340 @@
+package checked_test
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+	"unsafe"
+
+	"golang-learning-project/pkg/checked"
+)
+
+// The fuzz tests use math/big as the oracle: every result is computed
+// exactly and compared with the checked, saturating and wrapping
+// answers. Each takes int64 operands and checks them converted to every
+// integer type, so one input exercises all widths and signs at once.
+// Without -fuzz, go test runs the seed corpus: every pair of boundary
+// values of every type. To search further:
+//
+//	go test -fuzz FuzzMul ./pkg/checked
+
+// edges are the boundary values of every integer type, as int64 bit
+// patterns: converting one to a narrower type keeps its low bits.
+var edges = func() []int64 {
+	vs := []int64{0, 1, 2, -1, -2}
+	for _, bits := range []uint{8, 16, 32, 64} {
+		smax := int64(uint64(1)<<(bits-1) - 1)
+		smin := -smax - 1
+		umax := int64(uint64(1)<<bits - 1) // -1 for 64 bits
+		vs = append(vs, smax, smax-1, smin, smin+1, smax/2, smin/2, umax, umax-1)
+	}
+	return vs
+}()
+
+func seedPairs(f *testing.F) {
+	for _, a := range edges {
+		for _, b := range edges {
+			f.Add(a, b)
+		}
+	}
+}
+
+// intRange describes T for the oracle.
+type intRange struct {
+	signed   bool
+	bits     uint
+	min, max *big.Int
+}
+
+func rangeOf[T checked.Integer]() intRange {
+	var zero T
+	ir := intRange{signed: ^T(0) < 0, bits: uint(unsafe.Sizeof(zero) * 8)}
+	one := big.NewInt(1)
+	if ir.signed {
+		ir.max = new(big.Int).Sub(new(big.Int).Lsh(one, ir.bits-1), one)
+		ir.min = new(big.Int).Neg(new(big.Int).Lsh(one, ir.bits-1))
+	} else {
+		ir.max = new(big.Int).Sub(new(big.Int).Lsh(one, ir.bits), one)
+		ir.min = new(big.Int)
+	}
+	return ir
+}
+
+func (ir intRange) contains(x *big.Int) bool {
+	return x.Cmp(ir.min) >= 0 && x.Cmp(ir.max) <= 0
+}
+
+func (ir intRange) clamp(x *big.Int) *big.Int {
+	switch {
+	case x.Cmp(ir.min) < 0:
+		return ir.min
+	case x.Cmp(ir.max) > 0:
+		return ir.max
+	}
+	return x
+}
+
+// wrap reduces x modulo 2^bits into the range, as the hardware does.
+func (ir intRange) wrap(x *big.Int) *big.Int {
+	mod := new(big.Int).Lsh(big.NewInt(1), ir.bits)
+	w := new(big.Int).Mod(x, mod)
+	if ir.signed && w.Cmp(ir.max) > 0 {
+		w.Sub(w, mod)
+	}
+	return w
+}
+
+func toBig[T checked.Integer](v T) *big.Int {
+	if ^T(0) < 0 {
+		return big.NewInt(int64(v))
+	}
+	return new(big.Int).SetUint64(uint64(v))
+}
+
+// arithOp is one operation in its three behaviours, with its exact
+// counterpart.
+type arithOp[T checked.Integer] struct {
+	name    string
+	exact   func(z, a, b *big.Int) *big.Int
+	checked func(a, b T) (T, error)
+	sat     func(a, b T) T
+	wrap    func(a, b T) T
+}
+
+func checkArith[T checked.Integer](t *testing.T, op arithOp[T], a, b T) {
+	t.Helper()
+	ir := rangeOf[T]()
+	got, err := op.checked(a, b)
+	if op.name == "Div" && b == 0 {
+		if !errors.Is(err, checked.ErrDivideByZero) {
+			t.Errorf("Div[%T](%d, 0) error = %v; want ErrDivideByZero", a, a, err)
+		}
+		return
+	}
+	exact := op.exact(new(big.Int), toBig(a), toBig(b))
+	switch {
+	case ir.contains(exact) && (err != nil || toBig(got).Cmp(exact) != 0):
+		t.Errorf("%s[%T](%d, %d) = %d, %v; want %v", op.name, a, a, b, got, err, exact)
+	case !ir.contains(exact) && !errors.Is(err, checked.ErrOverflow):
+		t.Errorf("%s[%T](%d, %d) error = %v; want ErrOverflow", op.name, a, a, b, err)
+	}
+	if sat := op.sat(a, b); toBig(sat).Cmp(ir.clamp(exact)) != 0 {
+		t.Errorf("%sSat[%T](%d, %d) = %d; want %v", op.name, a, a, b, sat, ir.clamp(exact))
+	}
+	if w := op.wrap(a, b); toBig(w).Cmp(ir.wrap(exact)) != 0 {
+		t.Errorf("%sWrap[%T](%d, %d) = %d; want %v", op.name, a, a, b, w, ir.wrap(exact))
+	}
+}
+
+// ops returns the four operations for T.
+func ops[T checked.Integer]() map[string]arithOp[T] {
+	return map[string]arithOp[T]{
+		"Add": {"Add", (*big.Int).Add, checked.Add[T], checked.AddSat[T], checked.AddWrap[T]},
+		"Sub": {"Sub", (*big.Int).Sub, checked.Sub[T], checked.SubSat[T], checked.SubWrap[T]},
+		"Mul": {"Mul", (*big.Int).Mul, checked.Mul[T], checked.MulSat[T], checked.MulWrap[T]},
+		"Div": {"Div", (*big.Int).Quo, checked.Div[T], checked.DivSat[T], checked.DivWrap[T]},
+	}
+}
+
+func arith[T checked.Integer](t *testing.T, name string, a, b int64) {
+	t.Helper()
+	checkArith(t, ops[T]()[name], T(a), T(b))
+}
+
+// fuzzArith checks op on a and b in every integer type.
+func fuzzArith(f *testing.F, op string) {
+	seedPairs(f)
+	f.Fuzz(func(t *testing.T, a, b int64) {
+		arith[int8](t, op, a, b)
+		arith[int16](t, op, a, b)
+		arith[int32](t, op, a, b)
+		arith[int64](t, op, a, b)
+		arith[int](t, op, a, b)
+		arith[uint8](t, op, a, b)
+		arith[uint16](t, op, a, b)
+		arith[uint32](t, op, a, b)
+		arith[uint64](t, op, a, b)
+		arith[uint](t, op, a, b)
+	})
+}
+
+func FuzzAdd(f *testing.F) { fuzzArith(f, "Add") }
+func FuzzSub(f *testing.F) { fuzzArith(f, "Sub") }
+func FuzzMul(f *testing.F) { fuzzArith(f, "Mul") }
+func FuzzDiv(f *testing.F) { fuzzArith(f, "Div") }
+
+func checkConvert[To, From checked.Integer](t *testing.T, v From) {
+	t.Helper()
+	ir := rangeOf[To]()
+	exact := toBig(v)
+	got, err := checked.Convert[To](v)
+	switch {
+	case ir.contains(exact):
+		if err != nil || toBig(got).Cmp(exact) != 0 {
+			t.Errorf("Convert[%T](%T(%d)) = %d, %v; want %v", got, v, v, got, err, exact)
+		}
+	case exact.Sign() < 0 && !ir.signed:
+		if !errors.Is(err, checked.ErrSignLoss) {
+			t.Errorf("Convert[%T](%T(%d)) error = %v; want ErrSignLoss", got, v, v, err)
+		}
+	default:
+		if !errors.Is(err, checked.ErrTruncated) {
+			t.Errorf("Convert[%T](%T(%d)) error = %v; want ErrTruncated", got, v, v, err)
+		}
+	}
+	if sat := checked.ConvertSat[To](v); toBig(sat).Cmp(ir.clamp(exact)) != 0 {
+		t.Errorf("ConvertSat[%T](%T(%d)) = %d; want %v", sat, v, v, sat, ir.clamp(exact))
+	}
+	if w := checked.ConvertWrap[To](v); toBig(w).Cmp(ir.wrap(exact)) != 0 {
+		t.Errorf("ConvertWrap[%T](%T(%d)) = %d; want %v", w, v, v, w, ir.wrap(exact))
+	}
+}
+
+// convertFrom checks converting v to every integer type.
+func convertFrom[From checked.Integer](t *testing.T, v From) {
+	t.Helper()
+	checkConvert[int8](t, v)
+	checkConvert[int16](t, v)
+	checkConvert[int32](t, v)
+	checkConvert[int64](t, v)
+	checkConvert[int](t, v)
+	checkConvert[uint8](t, v)
+	checkConvert[uint16](t, v)
+	checkConvert[uint32](t, v)
+	checkConvert[uint64](t, v)
+	checkConvert[uint](t, v)
+}
+
+// FuzzConvertSigned and FuzzConvertUnsigned check every conversion from a
+// signed and from an unsigned type, with v converted to that type first.
+func FuzzConvertSigned(f *testing.F) {
+	for _, v := range edges {
+		f.Add(v)
+	}
+	f.Fuzz(func(t *testing.T, v int64) {
+		convertFrom(t, int8(v))
+		convertFrom(t, int16(v))
+		convertFrom(t, int32(v))
+		convertFrom(t, v)
+		convertFrom(t, int(v))
+	})
+}
+
+func FuzzConvertUnsigned(f *testing.F) {
+	for _, v := range edges {
+		f.Add(uint64(v))
+	}
+	f.Fuzz(func(t *testing.T, v uint64) {
+		convertFrom(t, uint8(v))
+		convertFrom(t, uint16(v))
+		convertFrom(t, uint32(v))
+		convertFrom(t, v)
+		convertFrom(t, uint(v))
+	})
+}
+
+// TestErrorsDoNotAllocate checks the promise of the package doc that a
+// failed check costs no allocation.
+func TestErrorsDoNotAllocate(t *testing.T) {
+	var err error
+	allocs := testing.AllocsPerRun(100, func() {
+		_, err = checked.Add[int64](math.MaxInt64, 1)
+		_, err = checked.Div[int8](-128, -1)
+		_, err = checked.Convert[uint8](-1)
+	})
+	if allocs != 0 || err == nil {
+		t.Errorf("failed checks allocated %v times; want 0", allocs)
+	}
+}
+
+// input holds operands that sometimes overflow int32.
+var input = func() []int32 {
+	s := make([]int32, 1000)
+	x := uint32(1)
+	for i := range s {
+		x = x*1664525 + 1013904223
+		s[i] = int32(x>>12) - 1<<19
+	}
+	return s
+}()
+
+// sink keeps benchmark results alive so the compiler cannot drop the
+// work that produced them.
+var sink any
+
+func BenchmarkAdd(b *testing.B) {
+	b.Run("checked", func(b *testing.B) {
+		for range b.N {
+			var sum int32
+			for _, v := range input {
+				sum, _ = checked.Add(sum, v)
+			}
+			sink = sum
+		}
+	})
+	b.Run("sat", func(b *testing.B) {
+		for range b.N {
+			var sum int32
+			for _, v := range input {
+				sum = checked.AddSat(sum, v)
+			}
+			sink = sum
+		}
+	})
+	b.Run("loop", func(b *testing.B) {
+		for range b.N {
+			var sum int32
+			for _, v := range input {
+				sum += v
+			}
+			sink = sum
+		}
+	})
+}
+
+func BenchmarkMul(b *testing.B) {
+	b.Run("checked", func(b *testing.B) {
+		for range b.N {
+			var n int32
+			for _, v := range input {
+				if p, err := checked.Mul(v, v); err == nil {
+					n ^= p
+				}
+			}
+			sink = n
+		}
+	})
+	b.Run("loop", func(b *testing.B) {
+		for range b.N {
+			var n int32
+			for _, v := range input {
+				n ^= v * v
+			}
+			sink = n
+		}
+	})
+}
+
+func BenchmarkConvert(b *testing.B) {
+	b.Run("checked", func(b *testing.B) {
+		for range b.N {
+			var n int8
+			for _, v := range input {
+				if c, err := checked.Convert[int8](v); err == nil {
+					n ^= c
+				}
+			}
+			sink = n
+		}
+	})
+	b.Run("loop", func(b *testing.B) {
+		for range b.N {
+			var n int8
+			for _, v := range input {
+				n ^= int8(v)
+			}
+			sink = n
+		}
+	})
+}
@@ -0,0 +1,73 @@
+package checked
+
+// AddSat returns a + b, clamped to the range of T.
+func AddSat[T Integer](a, b T) T {
+	if c, err := Add(a, b); err == nil {
+		return c
+	}
+	if b < 0 {
+		return minOf[T]()
+	}
+	return maxOf[T]()
+}
+
+// SubSat returns a - b, clamped to the range of T.
+func SubSat[T Integer](a, b T) T {
+	if c, err := Sub(a, b); err == nil {
+		return c
+	}
+	if b < 0 {
+		return maxOf[T]()
+	}
+	return minOf[T]()
+}
+
+// MulSat returns a * b, clamped to the range of T.
+func MulSat[T Integer](a, b T) T {
+	if c, err := Mul(a, b); err == nil {
+		return c
+	}
+	if (a < 0) != (b < 0) {
+		return minOf[T]()
+	}
+	return maxOf[T]()
+}
+
+// DivSat returns a / b, clamped to the range of T: the minimum signed
+// value divided by -1 gives the maximum. Like the / operator, it panics
+// if b is zero.
+func DivSat[T Integer](a, b T) T {
+	if signed[T]() && a == minOf[T]() && b == ^T(0) {
+		return maxOf[T]()
+	}
+	return a / b
+}
+
+// ConvertSat returns v as a To, clamped to the range of To: negative
+// values become 0 in unsigned types.
+func ConvertSat[To, From Integer](v From) To {
+	if t, err := Convert[To](v); err == nil {
+		return t
+	}
+	if v < 0 {
+		return minOf[To]()
+	}
+	return maxOf[To]()
+}
+
+// AddWrap returns a + b wrapped around modulo 2ⁿ, like the + operator.
+func AddWrap[T Integer](a, b T) T { return a + b }
+
+// SubWrap returns a - b wrapped around modulo 2ⁿ, like the - operator.
+func SubWrap[T Integer](a, b T) T { return a - b }
+
+// MulWrap returns a * b wrapped around modulo 2ⁿ, like the * operator.
+func MulWrap[T Integer](a, b T) T { return a * b }
+
+// DivWrap returns a / b like the / operator, where the minimum signed
+// value divided by -1 wraps around to itself. It panics if b is zero.
+func DivWrap[T Integer](a, b T) T { return a / b }
+
+// ConvertWrap returns v as a To keeping only its low bits, like a Go
+// conversion.
+func ConvertWrap[To, From Integer](v From) To { return To(v) }
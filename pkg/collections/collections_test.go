@@ -0,0 +1,405 @@
+package collections_test
+
+import (
+	"cmp"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"sort"
+	"testing"
+	"testing/quick"
+
+	"golang-learning-project/pkg/collections"
+)
+
+// The property tests have testing/quick generate random sequences of
+// operations, run them on a container and on a simple model built from
+// slices and maps, and compare the two after every step.
+
+// op is one generated operation: Kind picks what to do and Key what to
+// do it to. Its fields are exported so that testing/quick can fill them.
+type op struct {
+	Kind uint8
+	Key  uint8
+}
+
+var quickConfig = &quick.Config{MaxCount: 300}
+
+func check(t *testing.T, property any) {
+	t.Helper()
+	if err := quick.Check(property, quickConfig); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSetProperties(t *testing.T) {
+	check(t, func(as, bs []uint8, removed uint8) bool {
+		a, b := collections.NewSet[uint8](), collections.NewSet(bs...)
+		ma, mb := map[uint8]bool{}, map[uint8]bool{}
+		for _, v := range as {
+			if added := a.Add(v); added == ma[v] {
+				t.Errorf("Add(%d) = %v after %v", v, added, as)
+				return false
+			}
+			ma[v] = true
+		}
+		for _, v := range bs {
+			mb[v] = true
+		}
+		if got := a.Remove(removed); got != ma[removed] {
+			t.Errorf("Remove(%d) = %v; want %v", removed, got, ma[removed])
+			return false
+		}
+		delete(ma, removed)
+
+		union, inter, diff := a.Union(b), a.Intersect(b), a.Diff(b)
+		for v := range 256 {
+			v := uint8(v)
+			if union.Has(v) != (ma[v] || mb[v]) || inter.Has(v) != (ma[v] && mb[v]) || diff.Has(v) != (ma[v] && !mb[v]) {
+				t.Errorf("A = %v, B = %v: union, intersection or difference wrong at %d", ma, mb, v)
+				return false
+			}
+		}
+		// Set algebra laws.
+		return union.Equal(b.Union(a)) &&
+			union.Len() == a.Len()+b.Len()-inter.Len() &&
+			inter.SubsetOf(a) && inter.SubsetOf(b) && a.SubsetOf(union) &&
+			diff.Intersect(b).Len() == 0 &&
+			a.Len() == len(ma)
+	})
+}
+
+func TestOrderedMapProperties(t *testing.T) {
+	check(t, func(ops []op) bool {
+		var m collections.OrderedMap[uint8, int]
+		var order []uint8
+		model := map[uint8]int{}
+		for step, o := range ops {
+			k := o.Key % 20
+			if o.Kind%3 == 0 {
+				_, had := model[k]
+				if m.Delete(k) != had {
+					t.Errorf("step %d: Delete(%d) = %v", step, k, !had)
+					return false
+				}
+				delete(model, k)
+				order = slices.DeleteFunc(order, func(x uint8) bool { return x == k })
+			} else {
+				if _, ok := model[k]; !ok {
+					order = append(order, k)
+				}
+				m.Set(k, step)
+				model[k] = step
+			}
+			if got := slices.Collect(m.Keys()); !slices.Equal(got, order) {
+				t.Errorf("step %d: keys %v; want %v", step, got, order)
+				return false
+			}
+			for k, v := range m.All() {
+				if model[k] != v {
+					t.Errorf("step %d: value of %d is %d; want %d", step, k, v, model[k])
+					return false
+				}
+			}
+			if m.Len() != len(model) {
+				t.Errorf("step %d: Len() = %d; want %d", step, m.Len(), len(model))
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// TestOrderedMapDeleteWhileIterating deletes keys ahead of, at and behind
+// the iterator; a deleted key must not be produced afterwards.
+func TestOrderedMapDeleteWhileIterating(t *testing.T) {
+	tests := []struct {
+		name   string
+		delete map[string][]string // keys to delete on visiting a key
+		want   []string
+	}{
+		{"next", map[string][]string{"a": {"b"}}, []string{"a", "c", "d"}},
+		{"current", map[string][]string{"b": {"b"}}, []string{"a", "b", "c", "d"}},
+		{"current and next", map[string][]string{"b": {"b", "c"}}, []string{"a", "b", "d"}},
+		{"next two", map[string][]string{"a": {"c", "b"}}, []string{"a", "d"}},
+		{"behind", map[string][]string{"c": {"a"}}, []string{"a", "b", "c", "d"}},
+		{"last", map[string][]string{"a": {"d"}}, []string{"a", "b", "c"}},
+		{"all", map[string][]string{"a": {"a", "b", "c", "d"}}, []string{"a"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var m collections.OrderedMap[string, int]
+			for i, k := range []string{"a", "b", "c", "d"} {
+				m.Set(k, i)
+			}
+			var got []string
+			for k := range m.All() {
+				got = append(got, k)
+				for _, d := range tt.delete[k] {
+					m.Delete(d)
+				}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("visited %v; want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortedMapProperties(t *testing.T) {
+	check(t, func(ops []op, from uint8) bool {
+		m := collections.NewSortedMap[uint8, int]()
+		model := map[uint8]int{}
+		for step, o := range ops {
+			k := o.Key % 100
+			if o.Kind%3 == 0 {
+				_, had := model[k]
+				if m.Delete(k) != had {
+					t.Errorf("step %d: Delete(%d) = %v", step, k, !had)
+					return false
+				}
+				delete(model, k)
+			} else {
+				m.Set(k, step)
+				model[k] = step
+			}
+			want, had := model[k]
+			if v, ok := m.Get(k); ok != had || v != want {
+				t.Errorf("step %d: Get(%d) = %d, %v; want %d, %v", step, k, v, ok, want, had)
+				return false
+			}
+		}
+		want := slices.Sorted(maps.Keys(model))
+		var got []uint8
+		for k, v := range m.All() {
+			if model[k] != v {
+				t.Errorf("value of %d is %d; want %d", k, v, model[k])
+				return false
+			}
+			got = append(got, k)
+		}
+		if !slices.Equal(got, want) || m.Len() != len(want) {
+			t.Errorf("keys %v; want %v", got, want)
+			return false
+		}
+		from %= 100
+		i := sort.Search(len(want), func(i int) bool { return want[i] >= from })
+		var gotFrom []uint8
+		for k := range m.From(from) {
+			gotFrom = append(gotFrom, k)
+		}
+		if !slices.Equal(gotFrom, want[i:]) {
+			t.Errorf("From(%d) = %v; want %v", from, gotFrom, want[i:])
+			return false
+		}
+		return true
+	})
+}
+
+func TestCounterProperties(t *testing.T) {
+	check(t, func(ops []op, n uint8) bool {
+		var c collections.Counter[uint8]
+		model := map[uint8]int{}
+		var firstSeen []uint8 // in order of (re)insertion
+		for _, o := range ops {
+			k := o.Key % 15
+			if o.Kind%4 == 0 {
+				c.Remove(k)
+				if model[k] > 0 {
+					if model[k]--; model[k] == 0 {
+						delete(model, k)
+						firstSeen = slices.DeleteFunc(firstSeen, func(x uint8) bool { return x == k })
+					}
+				}
+			} else {
+				c.Add(k)
+				if model[k] == 0 {
+					firstSeen = append(firstSeen, k)
+				}
+				model[k]++
+			}
+		}
+		total := 0
+		for k, want := range model {
+			total += want
+			if got := c.Count(k); got != want {
+				t.Errorf("Count(%d) = %d; want %d", k, got, want)
+				return false
+			}
+		}
+		if c.Total() != total || c.Len() != len(model) {
+			t.Errorf("Total, Len = %d, %d; want %d, %d", c.Total(), c.Len(), total, len(model))
+			return false
+		}
+
+		// MostCommon(n) is the model sorted by count, ties by first
+		// insertion.
+		ranked := slices.Clone(firstSeen)
+		slices.SortStableFunc(ranked, func(a, b uint8) int { return cmp.Compare(model[b], model[a]) })
+		k := int(n) % (len(ranked) + 2)
+		var got []uint8
+		for _, e := range c.MostCommon(k) {
+			got = append(got, e.Item)
+		}
+		if want := ranked[:min(k, len(ranked))]; !slices.Equal(got, want) {
+			t.Errorf("MostCommon(%d) = %v; want %v", k, got, want)
+			return false
+		}
+		return true
+	})
+}
+
+func TestDequeProperties(t *testing.T) {
+	check(t, func(ops []op) bool {
+		var d collections.Deque[int]
+		var model []int
+		for step, o := range ops {
+			switch o.Kind % 4 {
+			case 0:
+				d.PushBack(step)
+				model = append(model, step)
+			case 1:
+				d.PushFront(step)
+				model = slices.Insert(model, 0, step)
+			case 2:
+				v, ok := d.PopFront()
+				if ok != (len(model) > 0) || (ok && v != model[0]) {
+					t.Errorf("step %d: PopFront() = %d, %v; model %v", step, v, ok, model)
+					return false
+				}
+				if ok {
+					model = model[1:]
+				}
+			case 3:
+				v, ok := d.PopBack()
+				if ok != (len(model) > 0) || (ok && v != model[len(model)-1]) {
+					t.Errorf("step %d: PopBack() = %d, %v; model %v", step, v, ok, model)
+					return false
+				}
+				if ok {
+					model = model[:len(model)-1]
+				}
+			}
+			if got := slices.Collect(d.All()); !slices.Equal(got, model) || d.Len() != len(model) {
+				t.Errorf("step %d: contents %v; want %v", step, got, model)
+				return false
+			}
+			for i, want := range model {
+				if got := d.At(i); got != want {
+					t.Errorf("step %d: At(%d) = %d; want %d", step, i, got, want)
+					return false
+				}
+			}
+		}
+		return true
+	})
+}
+
+func TestPriorityQueueProperties(t *testing.T) {
+	check(t, func(pushed []int16) bool {
+		q := collections.NewMinQueue[int16]()
+		for _, v := range pushed {
+			q.Push(v)
+		}
+		want := slices.Sorted(slices.Values(pushed))
+		var popped []int16
+		for q.Len() > 0 {
+			v, _ := q.Pop()
+			popped = append(popped, v)
+		}
+		if !slices.Equal(popped, want) {
+			t.Errorf("popped %v; want %v", popped, want)
+			return false
+		}
+		_, ok := q.Pop()
+		return !ok
+	})
+}
+
+// sink keeps benchmark results alive so the compiler cannot drop the
+// work that produced them.
+var sink any
+
+var benchKeys = func() []int {
+	const n = 10_000
+	r := rand.New(rand.NewPCG(3, 4))
+	keys := make([]int, n)
+	for i := range keys {
+		keys[i] = r.IntN(n * 10)
+	}
+	return keys
+}()
+
+func BenchmarkQueue(b *testing.B) {
+	b.Run("deque", func(b *testing.B) {
+		for range b.N {
+			var d collections.Deque[int]
+			for i, k := range benchKeys {
+				d.PushBack(k)
+				if i%2 == 1 {
+					sink, _ = d.PopFront()
+				}
+			}
+		}
+	})
+	b.Run("loop", func(b *testing.B) {
+		for range b.N {
+			var q []int
+			for i, k := range benchKeys {
+				q = append(q, k)
+				if i%2 == 1 {
+					sink, q = q[0], q[1:]
+				}
+			}
+		}
+	})
+}
+
+func BenchmarkSortedKeys(b *testing.B) {
+	b.Run("sortedmap", func(b *testing.B) {
+		for range b.N {
+			m := collections.NewSortedMap[int, int]()
+			for _, k := range benchKeys {
+				m.Set(k, k)
+			}
+			for k := range m.All() {
+				sink = k
+			}
+		}
+	})
+	b.Run("loop", func(b *testing.B) {
+		for range b.N {
+			m := make(map[int]int)
+			for _, k := range benchKeys {
+				m[k] = k
+			}
+			for _, k := range slices.Sorted(maps.Keys(m)) {
+				sink = k
+			}
+		}
+	})
+}
+
+func BenchmarkTop10(b *testing.B) {
+	b.Run("counter", func(b *testing.B) {
+		for range b.N {
+			var c collections.Counter[int]
+			for _, k := range benchKeys {
+				c.Add(k % 500)
+			}
+			sink = c.MostCommon(10)
+		}
+	})
+	b.Run("loop", func(b *testing.B) {
+		for range b.N {
+			counts := make(map[int]int)
+			for _, k := range benchKeys {
+				counts[k%500]++
+			}
+			top := slices.SortedFunc(maps.Keys(counts), func(a, b int) int {
+				return cmp.Compare(counts[b], counts[a])
+			})
+			sink = top[:10]
+		}
+	})
+}
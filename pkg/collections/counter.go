@@ -0,0 +1,118 @@
+package collections
+
+import "iter"
+
+// Counter is a multiset: it counts how many times each item was added.
+// MostCommon lists the items with the highest counts, the top-N query
+// a plain map[T]int cannot answer without sorting everything.
+type Counter[T comparable] struct {
+	counts map[T]*counted[T]
+	total  int
+	seq    int // insertion counter, for stable ordering of ties
+}
+
+// MultiSet is another name for Counter.
+type MultiSet[T comparable] = Counter[T]
+
+type counted[T comparable] struct {
+	Entry[T]
+	first int // when the item was first added
+}
+
+// Entry is an item and its count.
+type Entry[T comparable] struct {
+	Item  T
+	Count int
+}
+
+// Add counts one more occurrence of item.
+func (c *Counter[T]) Add(item T) { c.AddN(item, 1) }
+
+// AddN changes the count of item by n, which may be negative. Items
+// whose count drops to zero or below are removed.
+func (c *Counter[T]) AddN(item T, n int) {
+	if c.counts == nil {
+		c.counts = make(map[T]*counted[T])
+	}
+	e, ok := c.counts[item]
+	if !ok {
+		if n <= 0 {
+			return
+		}
+		e = &counted[T]{Entry: Entry[T]{Item: item}, first: c.seq}
+		c.seq++
+		c.counts[item] = e
+	}
+	if e.Count+n <= 0 {
+		c.total -= e.Count
+		delete(c.counts, item)
+		return
+	}
+	e.Count += n
+	c.total += n
+}
+
+// Remove counts one occurrence of item less.
+func (c *Counter[T]) Remove(item T) { c.AddN(item, -1) }
+
+// Count returns the count of item, 0 if it was never added.
+func (c *Counter[T]) Count(item T) int {
+	if e, ok := c.counts[item]; ok {
+		return e.Count
+	}
+	return 0
+}
+
+// Len returns the number of distinct items.
+func (c *Counter[T]) Len() int { return len(c.counts) }
+
+// Total returns the sum of all counts.
+func (c *Counter[T]) Total() int { return c.total }
+
+// All returns an iterator over the items and their counts, in no
+// particular order.
+func (c *Counter[T]) All() iter.Seq2[T, int] {
+	return func(yield func(T, int) bool) {
+		for item, e := range c.counts {
+			if !yield(item, e.Count) {
+				return
+			}
+		}
+	}
+}
+
+// MostCommon returns the n items with the highest counts, highest first.
+// Ties go to the item added first, so the result is deterministic. If n
+// is negative or exceeds Len, every item is returned. It runs in
+// O(Len · log n) using a bounded min-heap.
+func (c *Counter[T]) MostCommon(n int) []Entry[T] {
+	if n < 0 || n > len(c.counts) {
+		n = len(c.counts)
+	}
+	if n == 0 {
+		return nil
+	}
+	// worse reports whether a ranks below b.
+	worse := func(a, b *counted[T]) bool {
+		if a.Count != b.Count {
+			return a.Count < b.Count
+		}
+		return a.first > b.first
+	}
+	// The heap's root is the worst of the best n seen so far.
+	pq := NewPriorityQueue(worse)
+	for _, e := range c.counts {
+		if pq.Len() < n {
+			pq.Push(e)
+		} else if root, _ := pq.Peek(); worse(root, e) {
+			pq.Pop()
+			pq.Push(e)
+		}
+	}
+	out := make([]Entry[T], pq.Len())
+	for i := len(out) - 1; i >= 0; i-- {
+		e, _ := pq.Pop()
+		out[i] = e.Entry
+	}
+	return out
+}
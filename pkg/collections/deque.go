@@ -0,0 +1,109 @@
+package collections
+
+import "iter"
+
+// Deque is a double-ended queue on a ring buffer: pushing and popping at
+// either end is amortized O(1), and the buffer is reused instead of
+// re-sliced, unlike a queue built with append and s[1:].
+type Deque[T any] struct {
+	buf  []T // len(buf) is zero or a power of two
+	head int // index of the front element
+	n    int
+}
+
+// Len returns the number of elements.
+func (d *Deque[T]) Len() int { return d.n }
+
+// PushBack adds v at the back.
+func (d *Deque[T]) PushBack(v T) {
+	d.grow()
+	d.buf[(d.head+d.n)&(len(d.buf)-1)] = v
+	d.n++
+}
+
+// PushFront adds v at the front.
+func (d *Deque[T]) PushFront(v T) {
+	d.grow()
+	d.head = (d.head - 1) & (len(d.buf) - 1)
+	d.buf[d.head] = v
+	d.n++
+}
+
+// PopFront removes and returns the front element, or false if d is
+// empty.
+func (d *Deque[T]) PopFront() (T, bool) {
+	var zero T
+	if d.n == 0 {
+		return zero, false
+	}
+	v := d.buf[d.head]
+	d.buf[d.head] = zero // let the garbage collector have it
+	d.head = (d.head + 1) & (len(d.buf) - 1)
+	d.n--
+	return v, true
+}
+
+// PopBack removes and returns the back element, or false if d is empty.
+func (d *Deque[T]) PopBack() (T, bool) {
+	var zero T
+	if d.n == 0 {
+		return zero, false
+	}
+	i := (d.head + d.n - 1) & (len(d.buf) - 1)
+	v := d.buf[i]
+	d.buf[i] = zero
+	d.n--
+	return v, true
+}
+
+// Front returns the front element without removing it.
+func (d *Deque[T]) Front() (T, bool) {
+	if d.n == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.buf[d.head], true
+}
+
+// Back returns the back element without removing it.
+func (d *Deque[T]) Back() (T, bool) {
+	if d.n == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.buf[(d.head+d.n-1)&(len(d.buf)-1)], true
+}
+
+// At returns the i-th element from the front. It panics if i is out of
+// range.
+func (d *Deque[T]) At(i int) T {
+	if i < 0 || i >= d.n {
+		panic("collections: Deque index out of range")
+	}
+	return d.buf[(d.head+i)&(len(d.buf)-1)]
+}
+
+// All returns an iterator over the elements from front to back.
+func (d *Deque[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := range d.n {
+			if !yield(d.At(i)) {
+				return
+			}
+		}
+	}
+}
+
+// grow doubles the buffer when it is full, unwrapping the ring so the
+// front is at index 0.
+func (d *Deque[T]) grow() {
+	if d.n < len(d.buf) {
+		return
+	}
+	buf := make([]T, max(8, 2*len(d.buf)))
+	if d.n > 0 {
+		k := copy(buf, d.buf[d.head:])
+		copy(buf[k:], d.buf[:d.head])
+	}
+	d.buf, d.head = buf, 0
+}
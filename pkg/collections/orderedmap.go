@@ -0,0 +1,100 @@
+package collections
+
+import "iter"
+
+// OrderedMap is a map that remembers the order in which keys were first
+// inserted and iterates in that order. Updating an existing key keeps
+// its position; deleting and re-inserting it moves it to the end.
+type OrderedMap[K comparable, V any] struct {
+	m          map[K]*omEntry[K, V]
+	head, tail *omEntry[K, V]
+}
+
+// omEntry is a node of the doubly linked list that keeps the order.
+type omEntry[K comparable, V any] struct {
+	key        K
+	value      V
+	prev, next *omEntry[K, V]
+	removed    bool // deleted; an iterator standing on it must skip it
+}
+
+// Set sets the value for key.
+func (m *OrderedMap[K, V]) Set(key K, value V) {
+	if e, ok := m.m[key]; ok {
+		e.value = value
+		return
+	}
+	if m.m == nil {
+		m.m = make(map[K]*omEntry[K, V])
+	}
+	e := &omEntry[K, V]{key: key, value: value, prev: m.tail}
+	if m.tail != nil {
+		m.tail.next = e
+	} else {
+		m.head = e
+	}
+	m.tail = e
+	m.m[key] = e
+}
+
+// Get returns the value for key and whether it was present.
+func (m *OrderedMap[K, V]) Get(key K) (V, bool) {
+	if e, ok := m.m[key]; ok {
+		return e.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Delete removes key and reports whether it was present.
+func (m *OrderedMap[K, V]) Delete(key K) bool {
+	e, ok := m.m[key]
+	if !ok {
+		return false
+	}
+	if e.prev != nil {
+		e.prev.next = e.next
+	} else {
+		m.head = e.next
+	}
+	if e.next != nil {
+		e.next.prev = e.prev
+	} else {
+		m.tail = e.prev
+	}
+	// e keeps its next pointer, so that an iterator that has just
+	// yielded it can carry on from there.
+	e.removed = true
+	delete(m.m, key)
+	return true
+}
+
+// Len returns the number of keys.
+func (m *OrderedMap[K, V]) Len() int { return len(m.m) }
+
+// All returns an iterator over the key-value pairs in insertion order.
+// Entries may be deleted during iteration, and a deleted entry is not
+// produced later; entries set during iteration may or may not be.
+func (m *OrderedMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for e := m.head; e != nil; e = e.next {
+			if e.removed {
+				continue
+			}
+			if !yield(e.key, e.value) {
+				return
+			}
+		}
+	}
+}
+
+// Keys returns an iterator over the keys in insertion order.
+func (m *OrderedMap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range m.All() {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
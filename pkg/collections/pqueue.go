@@ -0,0 +1,82 @@
+package collections
+
+import "cmp"
+
+// PriorityQueue is a binary min-heap: Pop always returns the element
+// that sorts first under less. Push and Pop take O(log n).
+type PriorityQueue[T any] struct {
+	items []T
+	less  func(a, b T) bool
+}
+
+// NewPriorityQueue returns an empty queue ordered by less. Pass a
+// "greater" function for a max-heap.
+func NewPriorityQueue[T any](less func(a, b T) bool) *PriorityQueue[T] {
+	return &PriorityQueue[T]{less: less}
+}
+
+// NewMinQueue returns an empty queue that pops the smallest value first.
+func NewMinQueue[T cmp.Ordered]() *PriorityQueue[T] {
+	return NewPriorityQueue(cmp.Less[T])
+}
+
+// Len returns the number of elements.
+func (q *PriorityQueue[T]) Len() int { return len(q.items) }
+
+// Push adds v.
+func (q *PriorityQueue[T]) Push(v T) {
+	q.items = append(q.items, v)
+	q.up(len(q.items) - 1)
+}
+
+// Pop removes and returns the first element, or false if q is empty.
+func (q *PriorityQueue[T]) Pop() (T, bool) {
+	var zero T
+	if len(q.items) == 0 {
+		return zero, false
+	}
+	top := q.items[0]
+	last := len(q.items) - 1
+	q.items[0] = q.items[last]
+	q.items[last] = zero
+	q.items = q.items[:last]
+	q.down(0)
+	return top, true
+}
+
+// Peek returns the first element without removing it.
+func (q *PriorityQueue[T]) Peek() (T, bool) {
+	if len(q.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return q.items[0], true
+}
+
+func (q *PriorityQueue[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !q.less(q.items[i], q.items[parent]) {
+			return
+		}
+		q.items[i], q.items[parent] = q.items[parent], q.items[i]
+		i = parent
+	}
+}
+
+func (q *PriorityQueue[T]) down(i int) {
+	n := len(q.items)
+	for {
+		smallest := i
+		for _, child := range [2]int{2*i + 1, 2*i + 2} {
+			if child < n && q.less(q.items[child], q.items[smallest]) {
+				smallest = child
+			}
+		}
+		if smallest == i {
+			return
+		}
+		q.items[i], q.items[smallest] = q.items[smallest], q.items[i]
+		i = smallest
+	}
+}
@@ -0,0 +1,119 @@
+// Package collections has generic containers beyond the built-in slice
+// and map: Set, OrderedMap (insertion order), SortedMap (key order, on a
+// skip list), Counter (a multiset with MostCommon), Deque (a ring
+// buffer) and PriorityQueue (a binary heap).
+//
+// The zero value of Set, OrderedMap, Counter and Deque is an empty
+// container ready to use; SortedMap and PriorityQueue need an ordering
+// and have constructors. None of them is safe for concurrent use.
+// Iteration uses iter.Seq, so every container works with for range and
+// with pkg/seq pipelines.
+package collections
+
+import (
+	"iter"
+	"maps"
+)
+
+// Set is an unordered set of distinct values.
+type Set[T comparable] struct {
+	m map[T]struct{}
+}
+
+// NewSet returns a set holding items.
+func NewSet[T comparable](items ...T) *Set[T] {
+	s := &Set[T]{m: make(map[T]struct{}, len(items))}
+	for _, v := range items {
+		s.m[v] = struct{}{}
+	}
+	return s
+}
+
+// Add adds v and reports whether it was new.
+func (s *Set[T]) Add(v T) bool {
+	if s.m == nil {
+		s.m = make(map[T]struct{})
+	}
+	if _, ok := s.m[v]; ok {
+		return false
+	}
+	s.m[v] = struct{}{}
+	return true
+}
+
+// Remove removes v and reports whether it was present.
+func (s *Set[T]) Remove(v T) bool {
+	if _, ok := s.m[v]; !ok {
+		return false
+	}
+	delete(s.m, v)
+	return true
+}
+
+// Has reports whether v is in the set.
+func (s *Set[T]) Has(v T) bool {
+	_, ok := s.m[v]
+	return ok
+}
+
+// Len returns the number of values in the set.
+func (s *Set[T]) Len() int { return len(s.m) }
+
+// All returns an iterator over the values, in no particular order.
+func (s *Set[T]) All() iter.Seq[T] { return maps.Keys(s.m) }
+
+// Union returns a new set of the values in s or o.
+func (s *Set[T]) Union(o *Set[T]) *Set[T] {
+	out := &Set[T]{m: maps.Clone(s.m)}
+	if out.m == nil {
+		out.m = make(map[T]struct{}, o.Len())
+	}
+	for v := range o.m {
+		out.m[v] = struct{}{}
+	}
+	return out
+}
+
+// Intersect returns a new set of the values in both s and o.
+func (s *Set[T]) Intersect(o *Set[T]) *Set[T] {
+	small, large := s, o
+	if small.Len() > large.Len() {
+		small, large = large, small
+	}
+	out := &Set[T]{m: make(map[T]struct{})}
+	for v := range small.m {
+		if large.Has(v) {
+			out.m[v] = struct{}{}
+		}
+	}
+	return out
+}
+
+// Diff returns a new set of the values in s that are not in o.
+func (s *Set[T]) Diff(o *Set[T]) *Set[T] {
+	out := &Set[T]{m: make(map[T]struct{})}
+	for v := range s.m {
+		if !o.Has(v) {
+			out.m[v] = struct{}{}
+		}
+	}
+	return out
+}
+
+// SubsetOf reports whether every value of s is in o.
+func (s *Set[T]) SubsetOf(o *Set[T]) bool {
+	if s.Len() > o.Len() {
+		return false
+	}
+	for v := range s.m {
+		if !o.Has(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal reports whether s and o hold the same values.
+func (s *Set[T]) Equal(o *Set[T]) bool {
+	return s.Len() == o.Len() && s.SubsetOf(o)
+}
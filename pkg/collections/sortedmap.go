@@ -0,0 +1,148 @@
+package collections
+
+import (
+	"cmp"
+	"iter"
+	"math/rand/v2"
+)
+
+// maxLevel bounds the height of the skip list; with p = 1/4 it covers
+// far more keys than fit in memory.
+const maxLevel = 24
+
+// SortedMap is a map that iterates in key order. It is a skip list:
+// Get, Set and Delete take O(log n) expected time, and iterating from
+// any key onwards is a walk along the bottom level.
+type SortedMap[K, V any] struct {
+	compare func(a, b K) int
+	head    *slNode[K, V] // sentinel; its key is unused
+	level   int           // levels in use, at least 1
+	length  int
+}
+
+type slNode[K, V any] struct {
+	key   K
+	value V
+	next  []*slNode[K, V] // next[i] is the successor on level i
+}
+
+// NewSortedMap returns an empty SortedMap ordered by cmp.Compare.
+func NewSortedMap[K cmp.Ordered, V any]() *SortedMap[K, V] {
+	return NewSortedMapFunc[K, V](cmp.Compare[K])
+}
+
+// NewSortedMapFunc returns an empty SortedMap ordered by compare, which
+// returns a negative number, zero or a positive number like cmp.Compare.
+func NewSortedMapFunc[K, V any](compare func(a, b K) int) *SortedMap[K, V] {
+	return &SortedMap[K, V]{
+		compare: compare,
+		head:    &slNode[K, V]{next: make([]*slNode[K, V], maxLevel)},
+		level:   1,
+	}
+}
+
+// findPrev fills prev[i] with the last node on level i whose key is less
+// than key, and returns the node after prev[0], which holds key if
+// anything does.
+func (m *SortedMap[K, V]) findPrev(key K, prev []*slNode[K, V]) *slNode[K, V] {
+	x := m.head
+	for i := m.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && m.compare(x.next[i].key, key) < 0 {
+			x = x.next[i]
+		}
+		if prev != nil {
+			prev[i] = x
+		}
+	}
+	return x.next[0]
+}
+
+// Get returns the value for key and whether it was present.
+func (m *SortedMap[K, V]) Get(key K) (V, bool) {
+	if x := m.findPrev(key, nil); x != nil && m.compare(x.key, key) == 0 {
+		return x.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Set sets the value for key.
+func (m *SortedMap[K, V]) Set(key K, value V) {
+	var prev [maxLevel]*slNode[K, V]
+	if x := m.findPrev(key, prev[:]); x != nil && m.compare(x.key, key) == 0 {
+		x.value = value
+		return
+	}
+	lvl := randomLevel()
+	for i := m.level; i < lvl; i++ {
+		prev[i] = m.head
+	}
+	m.level = max(m.level, lvl)
+	x := &slNode[K, V]{key: key, value: value, next: make([]*slNode[K, V], lvl)}
+	for i := range lvl {
+		x.next[i] = prev[i].next[i]
+		prev[i].next[i] = x
+	}
+	m.length++
+}
+
+// Delete removes key and reports whether it was present.
+func (m *SortedMap[K, V]) Delete(key K) bool {
+	var prev [maxLevel]*slNode[K, V]
+	x := m.findPrev(key, prev[:])
+	if x == nil || m.compare(x.key, key) != 0 {
+		return false
+	}
+	for i := range x.next {
+		prev[i].next[i] = x.next[i]
+	}
+	for m.level > 1 && m.head.next[m.level-1] == nil {
+		m.level--
+	}
+	m.length--
+	return true
+}
+
+// Len returns the number of keys.
+func (m *SortedMap[K, V]) Len() int { return m.length }
+
+// Min returns the smallest key and its value, or false if m is empty.
+func (m *SortedMap[K, V]) Min() (K, V, bool) {
+	if x := m.head.next[0]; x != nil {
+		return x.key, x.value, true
+	}
+	var k K
+	var v V
+	return k, v, false
+}
+
+// All returns an iterator over the key-value pairs in ascending key
+// order.
+func (m *SortedMap[K, V]) All() iter.Seq2[K, V] {
+	return m.walk(m.head.next[0])
+}
+
+// From returns an iterator over the pairs whose key is at least key, in
+// ascending order. Stop ranging to end a range query early.
+func (m *SortedMap[K, V]) From(key K) iter.Seq2[K, V] {
+	return m.walk(m.findPrev(key, nil))
+}
+
+func (m *SortedMap[K, V]) walk(start *slNode[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for x := start; x != nil; x = x.next[0] {
+			if !yield(x.key, x.value) {
+				return
+			}
+		}
+	}
+}
+
+// randomLevel picks a node height: each extra level with probability 1/4.
+func randomLevel() int {
+	lvl := 1
+	for lvl < maxLevel && rand.IntN(4) == 0 {
+		lvl++
+	}
+	return lvl
+}
@@ -0,0 +1,81 @@
+// Package errs is a small error taxonomy: sentinel errors to match with
+// errors.Is, an OpError that records which operation failed on which
+// operands, wrapping that keeps the chain intact for errors.Is and
+// errors.As, and a Collector that aggregates several errors into one.
+//
+// Errors created here can carry the call stack where they were made.
+// Capturing it costs a runtime.Callers call per error, so it is off by
+// default; build with -tags errstack to turn it on, and print it with
+// StackTrace.
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Sentinel errors. Match them with errors.Is; they are never returned
+// bare by this package, always wrapped with context.
+var (
+	ErrInvalidArgument  = errors.New("invalid argument")
+	ErrNotFound         = errors.New("not found")
+	ErrAlreadyExists    = errors.New("already exists")
+	ErrConflict         = errors.New("conflict")
+	ErrPermissionDenied = errors.New("permission denied")
+	ErrUnauthenticated  = errors.New("unauthenticated")
+	ErrDivideByZero     = errors.New("division by zero")
+)
+
+// OpError records an operation that failed, the operands it was called
+// with, and the underlying error.
+type OpError struct {
+	Op       string // e.g. "divide"
+	Operands []any  // the arguments, in order; may be empty
+	Err      error  // the cause, often a sentinel
+	stack    []uintptr
+}
+
+// Op returns an *OpError for op applied to operands failing with err.
+func Op(op string, err error, operands ...any) *OpError {
+	return &OpError{Op: op, Operands: operands, Err: err, stack: callers()}
+}
+
+// Error formats the error as a call, e.g. "divide(10, 0): division by
+// zero".
+func (e *OpError) Error() string {
+	args := make([]string, len(e.Operands))
+	for i, v := range e.Operands {
+		args[i] = fmt.Sprint(v)
+	}
+	return fmt.Sprintf("%s(%s): %v", e.Op, strings.Join(args, ", "), e.Err)
+}
+
+func (e *OpError) Unwrap() error { return e.Err }
+
+// wrapError is an error with a message prepended, like fmt.Errorf with
+// %w, plus the stack where it was wrapped.
+type wrapError struct {
+	msg   string
+	err   error
+	stack []uintptr
+}
+
+// Wrap returns err with the formatted message prepended: "msg: err". It
+// returns nil if err is nil, so it can wrap a result unconditionally.
+func Wrap(err error, format string, args ...any) error {
+	if err == nil {
+		return nil
+	}
+	return &wrapError{msg: fmt.Sprintf(format, args...), err: err, stack: callers()}
+}
+
+func (e *wrapError) Error() string { return e.msg + ": " + e.err.Error() }
+func (e *wrapError) Unwrap() error { return e.err }
+
+// New returns an error wrapping the sentinel kind with a message:
+// New(ErrNotFound, "task %d", 7) reads "task 7: not found" and matches
+// errors.Is(err, ErrNotFound).
+func New(kind error, format string, args ...any) error {
+	return &wrapError{msg: fmt.Sprintf(format, args...), err: kind, stack: callers()}
+}
@@ -0,0 +1,148 @@
+package errs_test
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"testing"
+
+	"golang-learning-project/pkg/errs"
+)
+
+// chain wraps a divide-by-zero OpError in Wrap, fmt.Errorf and Wrap
+// again, as an error returned up a few calls looks.
+func chain() error {
+	err := error(errs.Op("divide", errs.ErrDivideByZero, 10, 0))
+	err = errs.Wrap(err, "average of %d values", 2)
+	err = fmt.Errorf("report: %w", err)
+	return errs.Wrap(err, "handle %s", "/stats")
+}
+
+func TestMessages(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{errs.Op("divide", errs.ErrDivideByZero, 10, 0), "divide(10, 0): division by zero"},
+		{errs.Op("now", errs.ErrConflict), "now(): conflict"},
+		{errs.Op("lookup", errs.New(errs.ErrNotFound, "task %d", 7), "tasks", 7), "lookup(tasks, 7): task 7: not found"},
+		{errs.New(errs.ErrNotFound, "task %d", 7), "task 7: not found"},
+		{errs.Wrap(errs.ErrConflict, "save"), "save: conflict"},
+		{chain(), "handle /stats: report: average of 2 values: divide(10, 0): division by zero"},
+	}
+	for _, tt := range tests {
+		if got := tt.err.Error(); got != tt.want {
+			t.Errorf("Error() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestIsThroughChain(t *testing.T) {
+	err := chain()
+	if !errors.Is(err, errs.ErrDivideByZero) {
+		t.Errorf("errors.Is(%v, ErrDivideByZero) = false", err)
+	}
+	for _, other := range []error{errs.ErrNotFound, errs.ErrInvalidArgument, fs.ErrNotExist} {
+		if errors.Is(err, other) {
+			t.Errorf("errors.Is(%v, %v) = true", err, other)
+		}
+	}
+
+	var op *errs.OpError
+	if !errors.As(err, &op) {
+		t.Fatalf("errors.As(%v, *OpError) = false", err)
+	}
+	if op.Op != "divide" || len(op.Operands) != 2 || op.Operands[0] != 10 || op.Err != errs.ErrDivideByZero {
+		t.Errorf("OpError = %+v", op)
+	}
+
+	// A foreign error keeps matching under any number of wraps
+	wrapped := errs.Wrap(errs.Op("open", fs.ErrNotExist, "a.txt"), "load")
+	var pe *fs.PathError
+	if !errors.Is(wrapped, fs.ErrNotExist) || errors.As(wrapped, &pe) {
+		t.Errorf("errors.Is/As on %v", wrapped)
+	}
+	pathErr := errs.Wrap(&fs.PathError{Op: "open", Path: "a.txt", Err: fs.ErrPermission}, "load")
+	if !errors.As(pathErr, &pe) || pe.Path != "a.txt" || !errors.Is(pathErr, fs.ErrPermission) {
+		t.Errorf("errors.As(%v, *fs.PathError) = %v", pathErr, pe)
+	}
+}
+
+func TestWrapNil(t *testing.T) {
+	if err := errs.Wrap(nil, "nothing %d", 1); err != nil {
+		t.Errorf("Wrap(nil) = %v, want nil", err)
+	}
+}
+
+func TestCollector(t *testing.T) {
+	var empty errs.Collector
+	empty.Add(nil)
+	if err := empty.Err(); err != nil || empty.Len() != 0 {
+		t.Errorf("Err() of an empty collector = %v (Len %d), want nil", err, empty.Len())
+	}
+
+	title := errs.New(errs.ErrInvalidArgument, "title")
+	due := errs.New(errs.ErrInvalidArgument, "due")
+	conflict := errs.Wrap(errs.ErrConflict, "version")
+
+	var inner errs.Collector
+	inner.Add(title)
+	inner.Add(due)
+	var middle errs.Collector
+	middle.Add(inner.Err())
+	middle.Add(nil)
+	var outer errs.Collector
+	outer.Add(middle.Err())
+	outer.Add(conflict)
+
+	err := outer.Err()
+	var m *errs.MultiError
+	if !errors.As(err, &m) {
+		t.Fatalf("Err() = %T, want *MultiError", err)
+	}
+	got := m.Errors()
+	if len(got) != 3 || got[0] != title || got[1] != due || got[2] != conflict {
+		t.Errorf("Errors() = %v, want title, due and version flattened in order", got)
+	}
+	if outer.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", outer.Len())
+	}
+	if !errors.Is(err, errs.ErrConflict) || !errors.Is(err, errs.ErrInvalidArgument) || errors.Is(err, errs.ErrNotFound) {
+		t.Errorf("errors.Is does not search every collected error of %v", err)
+	}
+
+	// Err returns a copy: adding more does not change an earlier result
+	outer.Add(errs.New(errs.ErrNotFound, "task"))
+	if len(m.Errors()) != 3 {
+		t.Errorf("an earlier Err() grew to %d errors", len(m.Errors()))
+	}
+}
+
+func TestMultiErrorMessage(t *testing.T) {
+	collect := func(list ...error) error {
+		var c errs.Collector
+		for _, err := range list {
+			c.Add(err)
+		}
+		return c.Err()
+	}
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{collect(errs.New(errs.ErrInvalidArgument, "title")), "title: invalid argument"},
+		{
+			collect(errs.New(errs.ErrInvalidArgument, "title"), errs.Op("divide", errs.ErrDivideByZero, 1, 0)),
+			"2 errors: title: invalid argument; divide(1, 0): division by zero",
+		},
+		{
+			collect(errors.New("a"), collect(errors.New("b"), errors.New("c"))),
+			"3 errors: a; b; c",
+		},
+	}
+	for _, tt := range tests {
+		if got := tt.err.Error(); got != tt.want {
+			t.Errorf("Error() = %q, want %q", got, tt.want)
+		}
+	}
+}
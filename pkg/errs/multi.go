@@ -0,0 +1,61 @@
+package errs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MultiError holds several errors. errors.Is and errors.As search every
+// one of them.
+type MultiError struct {
+	errs []error
+}
+
+// Errors returns the collected errors in the order they were added.
+func (m *MultiError) Errors() []error { return m.errs }
+
+func (m *MultiError) Unwrap() []error { return m.errs }
+
+// Error lists every error: "2 errors: first; second".
+func (m *MultiError) Error() string {
+	if len(m.errs) == 1 {
+		return m.errs[0].Error()
+	}
+	msgs := make([]string, len(m.errs))
+	for i, err := range m.errs {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("%d errors: %s", len(m.errs), strings.Join(msgs, "; "))
+}
+
+// Collector gathers the errors of a sequence of independent steps, such
+// as validating every field of a form, so they can be reported together.
+// The zero value is ready to use.
+type Collector struct {
+	errs []error
+}
+
+// Add records err if it is not nil. The errors of a *MultiError are
+// added one by one, so collectors can be nested without nesting the
+// result.
+func (c *Collector) Add(err error) {
+	if err == nil {
+		return
+	}
+	if m, ok := err.(*MultiError); ok {
+		c.errs = append(c.errs, m.errs...)
+		return
+	}
+	c.errs = append(c.errs, err)
+}
+
+// Len returns the number of errors collected so far.
+func (c *Collector) Len() int { return len(c.errs) }
+
+// Err returns nil if no errors were added and a *MultiError otherwise.
+func (c *Collector) Err() error {
+	if len(c.errs) == 0 {
+		return nil
+	}
+	return &MultiError{errs: append([]error(nil), c.errs...)}
+}
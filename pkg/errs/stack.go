@@ -0,0 +1,46 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// StackTrace returns the call stack recorded by the innermost error in
+// err's chain that has one, one "function\n\tfile:line" entry per frame,
+// or "" if there is none. Stacks are only recorded in builds with the
+// errstack tag.
+func StackTrace(err error) string {
+	var pcs []uintptr
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		switch e := e.(type) {
+		case *OpError:
+			pcs = preferInner(pcs, e.stack)
+		case *wrapError:
+			pcs = preferInner(pcs, e.stack)
+		}
+	}
+	if len(pcs) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	frames := runtime.CallersFrames(pcs)
+	for {
+		f, more := frames.Next()
+		fmt.Fprintf(&sb, "%s\n\t%s:%d\n", f.Function, f.File, f.Line)
+		if !more {
+			break
+		}
+	}
+	return sb.String()
+}
+
+// preferInner prefers the stack of an inner error: it was captured closer
+// to where things went wrong.
+func preferInner(outer, inner []uintptr) []uintptr {
+	if len(inner) > 0 {
+		return inner
+	}
+	return outer
+}
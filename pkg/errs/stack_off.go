@@ -0,0 +1,6 @@
+//go:build !errstack
+
+package errs
+
+// callers records nothing unless the errstack build tag is set.
+func callers() []uintptr { return nil }
@@ -0,0 +1,15 @@
+//go:build !errstack
+
+package errs_test
+
+import (
+	"testing"
+
+	"golang-learning-project/pkg/errs"
+)
+
+func TestStackTraceOff(t *testing.T) {
+	if trace := errs.StackTrace(errs.Wrap(errs.Op("step", errs.ErrConflict), "run")); trace != "" {
+		t.Errorf("StackTrace without -tags errstack = %q, want empty", trace)
+	}
+}
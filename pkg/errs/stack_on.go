@@ -0,0 +1,13 @@
+//go:build errstack
+
+package errs
+
+import "runtime"
+
+// callers returns the stack of the function that created the error.
+func callers() []uintptr {
+	pcs := make([]uintptr, 32)
+	// Skip runtime.Callers, callers and the constructor (Op, Wrap, New).
+	n := runtime.Callers(3, pcs)
+	return pcs[:n]
+}
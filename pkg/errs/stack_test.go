@@ -0,0 +1,50 @@
+//go:build errstack
+
+package errs_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"golang-learning-project/pkg/errs"
+)
+
+//go:noinline
+func failingStep() error {
+	return errs.Op("step", errs.ErrConflict)
+}
+
+func TestStackTrace(t *testing.T) {
+	err := errs.Wrap(failingStep(), "run")
+	trace := stackTrace(t, err)
+
+	// The innermost stack wins: it starts in the function that made the
+	// error, not in the constructor or in this test where it was wrapped
+	first, _, _ := strings.Cut(trace, "\n")
+	if !strings.HasSuffix(first, ".failingStep") {
+		t.Errorf("first frame %q, want failingStep\n%s", first, trace)
+	}
+	if !strings.Contains(trace, "stack_test.go:") || !strings.Contains(trace, ".TestStackTrace") {
+		t.Errorf("trace does not reach the caller:\n%s", trace)
+	}
+	if strings.Contains(trace, "errs.Op") || strings.Contains(trace, "errs.callers") {
+		t.Errorf("trace includes the package's own frames:\n%s", trace)
+	}
+
+	// A wrap with no stack of its own below it records where it wrapped
+	wrapped := errs.Wrap(fmt.Errorf("plain"), "load")
+	if first, _, _ := strings.Cut(stackTrace(t, wrapped), "\n"); !strings.HasSuffix(first, ".TestStackTrace") {
+		t.Errorf("first frame of a wrap %q, want TestStackTrace", first)
+	}
+}
+
+// stackTrace returns errs.StackTrace(err), failing if it is empty.
+func stackTrace(t *testing.T, err error) string {
+	t.Helper()
+	trace := errs.StackTrace(err)
+	if trace == "" {
+		t.Fatalf("StackTrace(%v) is empty with -tags errstack", err)
+	}
+	return trace
+}
@@ -0,0 +1,122 @@
+// Package fn provides generic higher-order functions over slices and
+// iterators: the typed-once versions of helpers like mapInts and
+// filterInts in examples/01-basics/functions.
+//
+// The slice functions are eager and return new slices; the functions
+// with a Seq suffix, in seq.go, are lazy and work on iter.Seq values.
+// Unless a function says otherwise, the input is never modified.
+package fn
+
+// Map returns a new slice holding f applied to every element of s.
+func Map[S ~[]E, E, R any](s S, f func(E) R) []R {
+	out := make([]R, len(s))
+	for i, v := range s {
+		out[i] = f(v)
+	}
+	return out
+}
+
+// Filter returns a new slice holding the elements of s for which keep
+// returns true, in their original order.
+func Filter[S ~[]E, E any](s S, keep func(E) bool) S {
+	var out S
+	for _, v := range s {
+		if keep(v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// Reduce folds s into a single value, calling f with the accumulator and
+// each element in turn, starting from init.
+func Reduce[S ~[]E, E, A any](s S, init A, f func(A, E) A) A {
+	acc := init
+	for _, v := range s {
+		acc = f(acc, v)
+	}
+	return acc
+}
+
+// FlatMap applies f to every element of s and concatenates the results.
+func FlatMap[S ~[]E, E, R any](s S, f func(E) []R) []R {
+	var out []R
+	for _, v := range s {
+		out = append(out, f(v)...)
+	}
+	return out
+}
+
+// GroupBy splits s into groups of elements with the same key. Each group
+// keeps the elements in their original order.
+func GroupBy[S ~[]E, E any, K comparable](s S, key func(E) K) map[K]S {
+	groups := make(map[K]S)
+	for _, v := range s {
+		k := key(v)
+		groups[k] = append(groups[k], v)
+	}
+	return groups
+}
+
+// Partition splits s into the elements for which pred returns true and
+// those for which it returns false, both in their original order.
+func Partition[S ~[]E, E any](s S, pred func(E) bool) (yes, no S) {
+	for _, v := range s {
+		if pred(v) {
+			yes = append(yes, v)
+		} else {
+			no = append(no, v)
+		}
+	}
+	return yes, no
+}
+
+// Chunk splits s into consecutive sub-slices of n elements; the last one
+// may be shorter. The chunks share s's backing array but have their
+// capacity clipped, so appending to one never overwrites the next.
+// Chunk panics if n is less than 1.
+func Chunk[S ~[]E, E any](s S, n int) []S {
+	if n < 1 {
+		panic("fn.Chunk: n must be at least 1")
+	}
+	out := make([]S, 0, (len(s)+n-1)/n)
+	for i := 0; i < len(s); i += n {
+		end := min(i+n, len(s))
+		out = append(out, s[i:end:end])
+	}
+	return out
+}
+
+// Window returns every run of n consecutive elements of s, sliding by
+// one: Window([1 2 3 4], 2) is [[1 2] [2 3] [3 4]]. Like Chunk, the
+// windows share s's backing array with clipped capacity. Window returns
+// nil if s has fewer than n elements and panics if n is less than 1.
+func Window[S ~[]E, E any](s S, n int) []S {
+	if n < 1 {
+		panic("fn.Window: n must be at least 1")
+	}
+	if len(s) < n {
+		return nil
+	}
+	out := make([]S, 0, len(s)-n+1)
+	for i := 0; i+n <= len(s); i++ {
+		out = append(out, s[i:i+n:i+n])
+	}
+	return out
+}
+
+// Pair holds one element from each input of Zip.
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// Zip pairs up the elements of a and b by index. The result is as long as
+// the shorter input.
+func Zip[A, B any](a []A, b []B) []Pair[A, B] {
+	out := make([]Pair[A, B], min(len(a), len(b)))
+	for i := range out {
+		out[i] = Pair[A, B]{a[i], b[i]}
+	}
+	return out
+}
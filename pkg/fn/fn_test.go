@@ -0,0 +1,255 @@
+package fn_test
+
+import (
+	"iter"
+	"maps"
+	"reflect"
+	"slices"
+	"testing"
+
+	"golang-learning-project/pkg/fn"
+)
+
+func double(n int) int    { return n * 2 }
+func isEven(n int) bool   { return n%2 == 0 }
+func mod7(n int) int      { return n % 7 }
+func add(acc, n int) int  { return acc + n }
+func pair(n int) []int    { return []int{n, -n} }
+func small(n int) bool    { return n < 5 }
+func letter(n int) string { return string(rune('a' + n%26)) }
+
+func partition(yes, no []int) [2][]int { return [2][]int{yes, no} }
+
+// TestFunctions compares each function with the result the obvious loop
+// gives, including the edge cases of the slicing helpers.
+func TestFunctions(t *testing.T) {
+	in := []int{1, 2, 3, 4, 5, 6, 7}
+	values := func(s []int) iter.Seq[int] { return slices.Values(s) }
+
+	tests := []struct {
+		name      string
+		got, want any
+	}{
+		{"Map", fn.Map(in, double), []int{2, 4, 6, 8, 10, 12, 14}},
+		{"Map to another type", fn.Map([]int{0, 1, 27}, letter), []string{"a", "b", "b"}},
+		{"Map of empty", fn.Map([]int{}, double), []int{}},
+		{"MapSeq", slices.Collect(fn.MapSeq(values(in), double)), []int{2, 4, 6, 8, 10, 12, 14}},
+		{"Filter", fn.Filter(in, isEven), []int{2, 4, 6}},
+		{"Filter none", fn.Filter(in, func(int) bool { return false }), []int(nil)},
+		{"FilterSeq", slices.Collect(fn.FilterSeq(values(in), isEven)), []int{2, 4, 6}},
+		{"Reduce", fn.Reduce(in, 0, add), 28},
+		{"Reduce of empty", fn.Reduce([]int{}, 10, add), 10},
+		{"ReduceSeq", fn.ReduceSeq(values(in), 0, add), 28},
+		{"FlatMap", fn.FlatMap([]int{1, 2}, pair), []int{1, -1, 2, -2}},
+		{"FlatMapSeq", slices.Collect(fn.FlatMapSeq(values([]int{1, 2}), func(n int) iter.Seq[int] {
+			return slices.Values(pair(n))
+		})), []int{1, -1, 2, -2}},
+		{"GroupBy", fn.GroupBy(in, isEven), map[bool][]int{true: {2, 4, 6}, false: {1, 3, 5, 7}}},
+		{"GroupBySeq", fn.GroupBySeq(values(in), isEven), map[bool][]int{true: {2, 4, 6}, false: {1, 3, 5, 7}}},
+		{"Partition", partition(fn.Partition(in, small)), [2][]int{{1, 2, 3, 4}, {5, 6, 7}}},
+		{"PartitionSeq", partition(fn.PartitionSeq(values(in), small)), [2][]int{{1, 2, 3, 4}, {5, 6, 7}}},
+		{"Chunk", fn.Chunk(in, 3), [][]int{{1, 2, 3}, {4, 5, 6}, {7}}},
+		{"Chunk exact", fn.Chunk(in[:6], 3), [][]int{{1, 2, 3}, {4, 5, 6}}},
+		{"Chunk of empty", fn.Chunk([]int{}, 3), [][]int{}},
+		{"ChunkSeq", slices.Collect(fn.ChunkSeq(values(in), 3)), [][]int{{1, 2, 3}, {4, 5, 6}, {7}}},
+		{"ChunkSeq of empty", slices.Collect(fn.ChunkSeq(values(nil), 3)), [][]int(nil)},
+		{"Window", fn.Window(in[:4], 2), [][]int{{1, 2}, {2, 3}, {3, 4}}},
+		{"Window whole", fn.Window(in[:3], 3), [][]int{{1, 2, 3}}},
+		{"Window too short", fn.Window(in[:1], 2), [][]int(nil)},
+		{"WindowSeq", slices.Collect(fn.WindowSeq(values(in[:4]), 2)), [][]int{{1, 2}, {2, 3}, {3, 4}}},
+		{"WindowSeq too short", slices.Collect(fn.WindowSeq(values(in[:1]), 2)), [][]int(nil)},
+		{"Zip", fn.Zip(in[:2], []string{"a", "b", "c"}), []fn.Pair[int, string]{{First: 1, Second: "a"}, {First: 2, Second: "b"}}},
+		{"ZipSeq", maps.Collect(fn.ZipSeq(values(in), slices.Values([]string{"a", "b"}))), map[int]string{1: "a", 2: "b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+// TestClippedCapacity checks that chunks and windows, which share the
+// input, can be appended to without overwriting their neighbours.
+func TestClippedCapacity(t *testing.T) {
+	in := []int{1, 2, 3, 4, 5, 6, 7}
+	chunks := fn.Chunk(in, 3)
+	_ = append(chunks[0], 99)
+	if chunks[1][0] != 4 {
+		t.Errorf("append to a chunk: next chunk starts with %d, want 4", chunks[1][0])
+	}
+	windows := fn.Window(in, 2)
+	_ = append(windows[0], 99)
+	if in[2] != 3 {
+		t.Errorf("append to a window: input[2] = %d, want 3", in[2])
+	}
+}
+
+// TestSeqStopsEarly checks that the lazy functions stop pulling from
+// their input once the consumer stops.
+func TestSeqStopsEarly(t *testing.T) {
+	tests := []struct {
+		name string
+		seq  func(iter.Seq[int]) iter.Seq[int]
+	}{
+		{"MapSeq", func(s iter.Seq[int]) iter.Seq[int] { return fn.MapSeq(s, double) }},
+		{"FilterSeq", func(s iter.Seq[int]) iter.Seq[int] { return fn.FilterSeq(s, isEven) }},
+		{"ChunkSeq", func(s iter.Seq[int]) iter.Seq[int] { return fn.MapSeq(fn.ChunkSeq(s, 3), slices.Max) }},
+		{"WindowSeq", func(s iter.Seq[int]) iter.Seq[int] { return fn.MapSeq(fn.WindowSeq(s, 3), slices.Max) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pulled := 0
+			counting := func(yield func(int) bool) {
+				for i := range 100 {
+					pulled++
+					if !yield(i) {
+						return
+					}
+				}
+			}
+			for range tt.seq(counting) {
+				break
+			}
+			if pulled > 3 {
+				t.Errorf("pulled %d values for the first result, want at most 3", pulled)
+			}
+		})
+	}
+}
+
+func TestPanics(t *testing.T) {
+	tests := map[string]func(){
+		"Chunk":     func() { fn.Chunk([]int{1}, 0) },
+		"Window":    func() { fn.Window([]int{1}, 0) },
+		"ChunkSeq":  func() { fn.ChunkSeq(slices.Values([]int{1}), 0) },
+		"WindowSeq": func() { fn.WindowSeq(slices.Values([]int{1}), 0) },
+	}
+	for name, f := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s with n = 0 did not panic", name)
+				}
+			}()
+			f()
+		})
+	}
+}
+
+// sink keeps benchmark results alive so the compiler cannot drop the
+// work that produced them.
+var sink any
+
+// benchInput is the slice every benchmark works on. Each benchmark has a
+// "loop" variant, the hand-written code the function replaces.
+var benchInput = func() []int {
+	s := make([]int, 10_000)
+	for i := range s {
+		s[i] = i
+	}
+	return s
+}()
+
+func BenchmarkMap(b *testing.B) {
+	b.Run("fn", func(b *testing.B) {
+		for range b.N {
+			sink = fn.Map(benchInput, double)
+		}
+	})
+	b.Run("seq", func(b *testing.B) {
+		for range b.N {
+			sink = slices.Collect(fn.MapSeq(slices.Values(benchInput), double))
+		}
+	})
+	b.Run("loop", func(b *testing.B) {
+		for range b.N {
+			out := make([]int, len(benchInput))
+			for i, n := range benchInput {
+				out[i] = n * 2
+			}
+			sink = out
+		}
+	})
+}
+
+func BenchmarkFilter(b *testing.B) {
+	b.Run("fn", func(b *testing.B) {
+		for range b.N {
+			sink = fn.Filter(benchInput, isEven)
+		}
+	})
+	b.Run("seq", func(b *testing.B) {
+		for range b.N {
+			sink = slices.Collect(fn.FilterSeq(slices.Values(benchInput), isEven))
+		}
+	})
+	b.Run("loop", func(b *testing.B) {
+		for range b.N {
+			var out []int
+			for _, n := range benchInput {
+				if n%2 == 0 {
+					out = append(out, n)
+				}
+			}
+			sink = out
+		}
+	})
+}
+
+func BenchmarkReduce(b *testing.B) {
+	b.Run("fn", func(b *testing.B) {
+		for range b.N {
+			sink = fn.Reduce(benchInput, 0, add)
+		}
+	})
+	b.Run("loop", func(b *testing.B) {
+		for range b.N {
+			sum := 0
+			for _, n := range benchInput {
+				sum += n
+			}
+			sink = sum
+		}
+	})
+}
+
+func BenchmarkGroupBy(b *testing.B) {
+	b.Run("fn", func(b *testing.B) {
+		for range b.N {
+			sink = fn.GroupBy(benchInput, mod7)
+		}
+	})
+	b.Run("loop", func(b *testing.B) {
+		for range b.N {
+			groups := make(map[int][]int)
+			for _, n := range benchInput {
+				groups[n%7] = append(groups[n%7], n)
+			}
+			sink = groups
+		}
+	})
+}
+
+func BenchmarkWindow(b *testing.B) {
+	b.Run("fn", func(b *testing.B) {
+		for range b.N {
+			sink = fn.Window(benchInput, 8)
+		}
+	})
+	b.Run("seq", func(b *testing.B) {
+		for range b.N {
+			sink = slices.Collect(fn.WindowSeq(slices.Values(benchInput), 8))
+		}
+	})
+	b.Run("loop", func(b *testing.B) {
+		for range b.N {
+			var out [][]int
+			for i := 0; i+8 <= len(benchInput); i++ {
+				out = append(out, benchInput[i:i+8:i+8])
+			}
+			sink = out
+		}
+	})
+}
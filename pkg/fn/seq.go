@@ -0,0 +1,137 @@
+package fn
+
+import (
+	"iter"
+	"slices"
+)
+
+// MapSeq returns an iterator over f applied to every value of seq.
+func MapSeq[E, R any](seq iter.Seq[E], f func(E) R) iter.Seq[R] {
+	return func(yield func(R) bool) {
+		for v := range seq {
+			if !yield(f(v)) {
+				return
+			}
+		}
+	}
+}
+
+// FilterSeq returns an iterator over the values of seq for which keep
+// returns true.
+func FilterSeq[E any](seq iter.Seq[E], keep func(E) bool) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		for v := range seq {
+			if keep(v) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// ReduceSeq folds seq into a single value; see Reduce. It consumes the
+// whole sequence, so seq must be finite.
+func ReduceSeq[E, A any](seq iter.Seq[E], init A, f func(A, E) A) A {
+	acc := init
+	for v := range seq {
+		acc = f(acc, v)
+	}
+	return acc
+}
+
+// FlatMapSeq returns an iterator over the values of every sequence f
+// returns, one after the other.
+func FlatMapSeq[E, R any](seq iter.Seq[E], f func(E) iter.Seq[R]) iter.Seq[R] {
+	return func(yield func(R) bool) {
+		for v := range seq {
+			for r := range f(v) {
+				if !yield(r) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// GroupBySeq collects seq into groups by key; see GroupBy.
+func GroupBySeq[E any, K comparable](seq iter.Seq[E], key func(E) K) map[K][]E {
+	groups := make(map[K][]E)
+	for v := range seq {
+		k := key(v)
+		groups[k] = append(groups[k], v)
+	}
+	return groups
+}
+
+// PartitionSeq collects seq into the values for which pred returns true
+// and those for which it returns false; see Partition.
+func PartitionSeq[E any](seq iter.Seq[E], pred func(E) bool) (yes, no []E) {
+	for v := range seq {
+		if pred(v) {
+			yes = append(yes, v)
+		} else {
+			no = append(no, v)
+		}
+	}
+	return yes, no
+}
+
+// ChunkSeq returns an iterator over consecutive chunks of n values of
+// seq; the last one may be shorter. Each chunk is a new slice. ChunkSeq
+// panics if n is less than 1.
+func ChunkSeq[E any](seq iter.Seq[E], n int) iter.Seq[[]E] {
+	if n < 1 {
+		panic("fn.ChunkSeq: n must be at least 1")
+	}
+	return func(yield func([]E) bool) {
+		chunk := make([]E, 0, n)
+		for v := range seq {
+			chunk = append(chunk, v)
+			if len(chunk) == n {
+				if !yield(chunk) {
+					return
+				}
+				chunk = make([]E, 0, n)
+			}
+		}
+		if len(chunk) > 0 {
+			yield(chunk)
+		}
+	}
+}
+
+// WindowSeq returns an iterator over every run of n consecutive values of
+// seq, sliding by one; see Window. Each window is a new slice. WindowSeq
+// panics if n is less than 1.
+func WindowSeq[E any](seq iter.Seq[E], n int) iter.Seq[[]E] {
+	if n < 1 {
+		panic("fn.WindowSeq: n must be at least 1")
+	}
+	return func(yield func([]E) bool) {
+		window := make([]E, 0, n)
+		for v := range seq {
+			if len(window) == n {
+				copy(window, window[1:])
+				window = window[:n-1]
+			}
+			window = append(window, v)
+			if len(window) == n && !yield(slices.Clone(window)) {
+				return
+			}
+		}
+	}
+}
+
+// ZipSeq returns an iterator over pairs of values taken from a and b in
+// step. It stops as soon as either sequence ends.
+func ZipSeq[A, B any](a iter.Seq[A], b iter.Seq[B]) iter.Seq2[A, B] {
+	return func(yield func(A, B) bool) {
+		nextB, stop := iter.Pull(b)
+		defer stop()
+		for va := range a {
+			vb, ok := nextB()
+			if !ok || !yield(va, vb) {
+				return
+			}
+		}
+	}
+}
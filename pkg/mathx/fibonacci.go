@@ -0,0 +1,135 @@
+package mathx
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/bits"
+)
+
+// maxFibonacci is the largest n for which F(n) fits in an int: F(92) on
+// 64-bit platforms and F(46) on 32-bit ones.
+const maxFibonacci = 46 + 46*(bits.UintSize/64)
+
+// Fibonacci returns F(n), with F(0) = 0 and F(1) = 1, by iterating
+// from the bottom up: O(n) additions and no recursion. It returns
+// ErrOverflow past F(92) on 64-bit platforms.
+func Fibonacci(n int) (int, error) {
+	if n < 0 {
+		return 0, fmt.Errorf("Fibonacci(%d): %w", n, ErrNegative)
+	}
+	if n == 0 {
+		return 0, nil
+	}
+	a, b := 0, 1 // F(k-1), F(k) for k = 1
+	for range n - 1 {
+		if b > math.MaxInt-a {
+			return 0, fmt.Errorf("Fibonacci(%d): %w", n, ErrOverflow)
+		}
+		a, b = b, a+b
+	}
+	return b, nil
+}
+
+// FibonacciMemo returns F(n) with the textbook recursion, remembering
+// every value it computes so each is computed once: O(n) instead of the
+// naive O(φⁿ). The memo lives for one call. Like Fibonacci it returns
+// ErrOverflow past F(92), but before allocating the memo, so a huge n
+// costs nothing.
+func FibonacciMemo(n int) (int, error) {
+	switch {
+	case n < 0:
+		return 0, fmt.Errorf("FibonacciMemo(%d): %w", n, ErrNegative)
+	case n > maxFibonacci:
+		return 0, fmt.Errorf("FibonacciMemo(%d): %w", n, ErrOverflow)
+	}
+	memo := make([]int, n+1)
+	var fib func(int) int
+	fib = func(k int) int {
+		if k <= 1 {
+			return k
+		}
+		if memo[k] == 0 {
+			memo[k] = fib(k-1) + fib(k-2)
+		}
+		return memo[k]
+	}
+	return fib(n), nil
+}
+
+// FibonacciBig returns F(n) by iteration, like Fibonacci, but without an
+// upper bound. It costs O(n) big additions.
+func FibonacciBig(n int) (*big.Int, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("FibonacciBig(%d): %w", n, ErrNegative)
+	}
+	a, b := big.NewInt(0), big.NewInt(1)
+	for range n {
+		a.Add(a, b)
+		a, b = b, a
+	}
+	return a, nil
+}
+
+// FibonacciDoubling returns F(n) with the fast-doubling identities
+//
+//	F(2k)   = F(k) · (2·F(k+1) − F(k))
+//	F(2k+1) = F(k)² + F(k+1)²
+//
+// walking the bits of n from the top: O(log n) big multiplications.
+func FibonacciDoubling(n int) (*big.Int, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("FibonacciDoubling(%d): %w", n, ErrNegative)
+	}
+	a, b := big.NewInt(0), big.NewInt(1) // F(k), F(k+1) for k = 0
+	t1, t2 := new(big.Int), new(big.Int)
+	for i := bits.Len(uint(n)) - 1; i >= 0; i-- {
+		// c = F(2k), d = F(2k+1)
+		t1.Lsh(b, 1).Sub(t1, a).Mul(t1, a) // c
+		t2.Mul(a, a)
+		b.Mul(b, b).Add(b, t2) // d
+		a.Set(t1)
+		if n>>i&1 == 1 {
+			a.Add(a, b)
+			a, b = b, a // F(2k+1), F(2k+2)
+		}
+	}
+	return a, nil
+}
+
+// FibonacciMatrix returns F(n) by raising [[1 1] [1 0]] to the n-th
+// power with repeated squaring; the result is [[F(n+1) F(n)] [F(n)
+// F(n-1)]]. Also O(log n), but with more multiplications per step than
+// FibonacciDoubling.
+func FibonacciMatrix(n int) (*big.Int, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("FibonacciMatrix(%d): %w", n, ErrNegative)
+	}
+	result := identity2()
+	base := mat2{big.NewInt(1), big.NewInt(1), big.NewInt(1), big.NewInt(0)}
+	for k := n; k > 0; k >>= 1 {
+		if k&1 == 1 {
+			result = result.mul(base)
+		}
+		base = base.mul(base)
+	}
+	return result[1], nil
+}
+
+// mat2 is a 2×2 matrix in row-major order.
+type mat2 [4]*big.Int
+
+func identity2() mat2 {
+	return mat2{big.NewInt(1), big.NewInt(0), big.NewInt(0), big.NewInt(1)}
+}
+
+func (m mat2) mul(o mat2) mat2 {
+	dot := func(a, b, c, d *big.Int) *big.Int {
+		x := new(big.Int).Mul(a, b)
+		return x.Add(x, new(big.Int).Mul(c, d))
+	}
+	return mat2{
+		dot(m[0], o[0], m[1], o[2]), dot(m[0], o[1], m[1], o[3]),
+		dot(m[2], o[0], m[3], o[2]), dot(m[2], o[1], m[3], o[3]),
+	}
+}
@@ -0,0 +1,178 @@
+package mathx_test
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+
+	"golang-learning-project/pkg/mathx"
+)
+
+// naiveFibonacci and recursiveFactorial are the versions from
+// examples/01-basics/functions.
+func naiveFibonacci(n int) int {
+	if n <= 1 {
+		return n
+	}
+	return naiveFibonacci(n-1) + naiveFibonacci(n-2)
+}
+
+func recursiveFactorial(n int) int {
+	if n <= 1 {
+		return 1
+	}
+	return n * recursiveFactorial(n-1)
+}
+
+var intFibs = map[string]func(int) (int, error){
+	"Fibonacci":     mathx.Fibonacci,
+	"FibonacciMemo": mathx.FibonacciMemo,
+}
+
+var bigFibs = map[string]func(int) (*big.Int, error){
+	"FibonacciBig":      mathx.FibonacciBig,
+	"FibonacciDoubling": mathx.FibonacciDoubling,
+	"FibonacciMatrix":   mathx.FibonacciMatrix,
+}
+
+func TestFibonacciSmall(t *testing.T) {
+	for n := 0; n <= 25; n++ {
+		want := naiveFibonacci(n)
+		for name, f := range intFibs {
+			if got, err := f(n); got != want || err != nil {
+				t.Errorf("%s(%d) = %d, %v; want %d", name, n, got, err, want)
+			}
+		}
+	}
+}
+
+// TestFibonacciAgree compares the big.Int algorithms with each other, and
+// the int ones with them wherever the result fits.
+func TestFibonacciAgree(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 3, 10, 46, 47, 91, 92, 93, 1000, 4097} {
+		want, _ := mathx.FibonacciBig(n)
+		for name, f := range bigFibs {
+			if got, err := f(n); err != nil || got.Cmp(want) != 0 {
+				t.Errorf("%s(%d) = %v, %v; want %v", name, n, got, err, want)
+			}
+		}
+		fits := want.IsInt64() && want.Int64() <= math.MaxInt
+		for name, f := range intFibs {
+			got, err := f(n)
+			switch {
+			case fits && (err != nil || int64(got) != want.Int64()):
+				t.Errorf("%s(%d) = %d, %v; want %v", name, n, got, err, want)
+			case !fits && !errors.Is(err, mathx.ErrOverflow):
+				t.Errorf("%s(%d) error = %v; want ErrOverflow", name, n, err)
+			}
+		}
+	}
+}
+
+// TestFibonacciHuge checks that a huge n fails fast rather than trying to
+// allocate or loop n times.
+func TestFibonacciHuge(t *testing.T) {
+	for _, n := range []int{1 << 40, math.MaxInt} {
+		for name, f := range intFibs {
+			if _, err := f(n); !errors.Is(err, mathx.ErrOverflow) {
+				t.Errorf("%s(%d) error = %v; want ErrOverflow", name, n, err)
+			}
+		}
+	}
+}
+
+func TestNegative(t *testing.T) {
+	for name, f := range intFibs {
+		if _, err := f(-1); !errors.Is(err, mathx.ErrNegative) {
+			t.Errorf("%s(-1) error = %v; want ErrNegative", name, err)
+		}
+	}
+	for name, f := range bigFibs {
+		if _, err := f(-1); !errors.Is(err, mathx.ErrNegative) {
+			t.Errorf("%s(-1) error = %v; want ErrNegative", name, err)
+		}
+	}
+	if _, err := mathx.Factorial(-1); !errors.Is(err, mathx.ErrNegative) {
+		t.Errorf("Factorial(-1) error = %v; want ErrNegative", err)
+	}
+	if _, err := mathx.FactorialBig(-1); !errors.Is(err, mathx.ErrNegative) {
+		t.Errorf("FactorialBig(-1) error = %v; want ErrNegative", err)
+	}
+}
+
+func TestFactorial(t *testing.T) {
+	for n := 0; n <= 30; n++ {
+		want, _ := mathx.FactorialBig(n)
+		got, err := mathx.Factorial(n)
+		fits := want.IsInt64() && want.Int64() <= math.MaxInt
+		switch {
+		case fits && (err != nil || int64(got) != want.Int64()):
+			t.Errorf("Factorial(%d) = %d, %v; want %v", n, got, err, want)
+		case !fits && !errors.Is(err, mathx.ErrOverflow):
+			t.Errorf("Factorial(%d) error = %v; want ErrOverflow", n, err)
+		case fits && n <= 20 && got != recursiveFactorial(n):
+			t.Errorf("Factorial(%d) = %d; the recursion gives %d", n, got, recursiveFactorial(n))
+		}
+	}
+}
+
+// sink keeps benchmark results alive so the compiler cannot drop the
+// work that produced them.
+var sink any
+
+func BenchmarkFactorial20(b *testing.B) {
+	b.Run("recursive", func(b *testing.B) {
+		for range b.N {
+			sink = recursiveFactorial(20)
+		}
+	})
+	b.Run("iter", func(b *testing.B) {
+		for range b.N {
+			sink, _ = mathx.Factorial(20)
+		}
+	})
+	b.Run("big", func(b *testing.B) {
+		for range b.N {
+			sink, _ = mathx.FactorialBig(20)
+		}
+	})
+}
+
+func BenchmarkFib30(b *testing.B) {
+	b.Run("naive", func(b *testing.B) {
+		for range b.N {
+			sink = naiveFibonacci(30)
+		}
+	})
+	b.Run("memo", func(b *testing.B) {
+		for range b.N {
+			sink, _ = mathx.FibonacciMemo(30)
+		}
+	})
+	b.Run("iter", func(b *testing.B) {
+		for range b.N {
+			sink, _ = mathx.Fibonacci(30)
+		}
+	})
+}
+
+func benchBigFib(b *testing.B, n int, algorithms ...string) {
+	for _, name := range algorithms {
+		f := bigFibs[name]
+		b.Run(strings.TrimPrefix(name, "Fibonacci"), func(b *testing.B) {
+			for range b.N {
+				sink, _ = f(n)
+			}
+		})
+	}
+}
+
+func BenchmarkFib10k(b *testing.B) {
+	benchBigFib(b, 10_000, "FibonacciBig", "FibonacciDoubling", "FibonacciMatrix")
+}
+
+func BenchmarkFib1M(b *testing.B) {
+	benchBigFib(b, 1_000_000, "FibonacciDoubling", "FibonacciMatrix")
+}
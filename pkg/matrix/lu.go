@@ -0,0 +1,161 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+)
+
+// LU is the decomposition PA = LU of a square matrix A, where P is a row
+// permutation, L is lower triangular with ones on the diagonal and U is
+// upper triangular. Once computed, it gives the determinant, solutions
+// of Ax = b and the inverse without eliminating again.
+type LU struct {
+	n        int
+	lu       []float64 // L below the diagonal, U on and above it
+	perm     []int     // row i of PA is row perm[i] of A
+	sign     float64   // determinant of P: +1 or -1
+	singular bool
+}
+
+// Decompose computes the LU decomposition of the square matrix m by
+// Gaussian elimination with partial pivoting: each column's pivot is its
+// largest remaining entry, which keeps rounding errors small. A pivot
+// that is zero, or negligible next to the largest entry of its original
+// row, marks the matrix as singular.
+func Decompose[T Number](m *Matrix[T]) (*LU, error) {
+	if m.rows != m.cols {
+		return nil, fmt.Errorf("Decompose: %dx%d: %w", m.rows, m.cols, ErrNotSquare)
+	}
+	n := m.rows
+	d := &LU{n: n, lu: make([]float64, n*n), perm: make([]int, n), sign: 1}
+	scale := make([]float64, n) // largest entry of each row, times n·ε
+	for i, v := range m.data {
+		d.lu[i] = float64(v)
+		scale[i/n] = max(scale[i/n], math.Abs(d.lu[i]))
+	}
+	for i := range d.perm {
+		d.perm[i] = i
+		scale[i] *= float64(n) * 0x1p-52
+	}
+
+	a := d.lu
+	for k := range n {
+		p := k
+		for i := k + 1; i < n; i++ {
+			if math.Abs(a[i*n+k]) > math.Abs(a[p*n+k]) {
+				p = i
+			}
+		}
+		if math.Abs(a[p*n+k]) <= scale[p] {
+			d.singular = true
+			continue
+		}
+		if p != k {
+			for j := range n {
+				a[k*n+j], a[p*n+j] = a[p*n+j], a[k*n+j]
+			}
+			d.perm[k], d.perm[p] = d.perm[p], d.perm[k]
+			scale[k], scale[p] = scale[p], scale[k]
+			d.sign = -d.sign
+		}
+		pivot := a[k*n+k]
+		for i := k + 1; i < n; i++ {
+			f := a[i*n+k] / pivot
+			a[i*n+k] = f
+			for j := k + 1; j < n; j++ {
+				a[i*n+j] -= f * a[k*n+j]
+			}
+		}
+	}
+	return d, nil
+}
+
+// Singular reports whether the matrix has no inverse.
+func (d *LU) Singular() bool { return d.singular }
+
+// Det returns the determinant: the product of U's diagonal times the
+// sign of the permutation, or 0 for a singular matrix.
+func (d *LU) Det() float64 {
+	if d.singular {
+		return 0
+	}
+	det := d.sign
+	for i := range d.n {
+		det *= d.lu[i*d.n+i]
+	}
+	return det
+}
+
+// Solve returns x with Ax = b, by forward substitution with L and back
+// substitution with U.
+func (d *LU) Solve(b []float64) ([]float64, error) {
+	if len(b) != d.n {
+		return nil, fmt.Errorf("Solve: %d equations, %d right-hand sides: %w", d.n, len(b), ErrShape)
+	}
+	if d.singular {
+		return nil, fmt.Errorf("Solve: %w", ErrSingular)
+	}
+	n, a := d.n, d.lu
+	x := make([]float64, n)
+	for i := range n {
+		sum := b[d.perm[i]]
+		for j := range i {
+			sum -= a[i*n+j] * x[j]
+		}
+		x[i] = sum
+	}
+	for i := n - 1; i >= 0; i-- {
+		sum := x[i]
+		for j := i + 1; j < n; j++ {
+			sum -= a[i*n+j] * x[j]
+		}
+		x[i] = sum / a[i*n+i]
+	}
+	return x, nil
+}
+
+// Inverse returns A⁻¹, solving for one column of the identity at a time.
+func (d *LU) Inverse() (*Matrix[float64], error) {
+	if d.singular {
+		return nil, fmt.Errorf("Inverse: %w", ErrSingular)
+	}
+	inv := New[float64](d.n, d.n)
+	e := make([]float64, d.n)
+	for j := range d.n {
+		clear(e)
+		e[j] = 1
+		col, _ := d.Solve(e)
+		for i, v := range col {
+			inv.data[i*d.n+j] = v
+		}
+	}
+	return inv, nil
+}
+
+// Det returns the determinant of the square matrix m.
+func (m *Matrix[T]) Det() (float64, error) {
+	d, err := Decompose(m)
+	if err != nil {
+		return 0, err
+	}
+	return d.Det(), nil
+}
+
+// Inverse returns the inverse of the square matrix m.
+func (m *Matrix[T]) Inverse() (*Matrix[float64], error) {
+	d, err := Decompose(m)
+	if err != nil {
+		return nil, err
+	}
+	return d.Inverse()
+}
+
+// Solve returns x with m·x = b. To solve for several b with the same m,
+// Decompose once and call LU.Solve for each.
+func (m *Matrix[T]) Solve(b []float64) ([]float64, error) {
+	d, err := Decompose(m)
+	if err != nil {
+		return nil, err
+	}
+	return d.Solve(b)
+}
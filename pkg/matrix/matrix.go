@@ -0,0 +1,203 @@
+// Package matrix has a dense, row-major matrix of any numeric type and
+// the basic linear algebra on it: Add, Mul, Transpose, Identity, and,
+// through an LU decomposition, Det, Inverse and Solve.
+//
+// The [][]int matrix of examples/01-basics/collections is a slice of
+// separately allocated rows. A Matrix keeps all elements in one slice,
+// row after row, so element (i, j) is data[i*cols+j]: one allocation,
+// no row pointers to follow, and consecutive elements of a row sit next
+// to each other in memory. `go test -bench . ./pkg/matrix` measures the
+// difference.
+//
+// Add and Mul work in T. Det, Inverse and Solve need division and work
+// in float64 whatever T is.
+package matrix
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Number is any integer or floating-point type.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+var (
+	// ErrShape is returned when the dimensions of the operands do not
+	// fit the operation.
+	ErrShape = errors.New("matrix: dimension mismatch")
+
+	// ErrNotSquare is returned by operations defined only for square
+	// matrices.
+	ErrNotSquare = errors.New("matrix: not square")
+
+	// ErrSingular is returned by Inverse and Solve when the matrix has
+	// no inverse, or is too close to singular to invert reliably.
+	ErrSingular = errors.New("matrix: singular")
+)
+
+// Matrix is a rows × cols matrix stored in row-major order.
+type Matrix[T Number] struct {
+	rows, cols int
+	data       []T
+}
+
+// New returns a rows × cols matrix of zeros. It panics if either
+// dimension is negative.
+func New[T Number](rows, cols int) *Matrix[T] {
+	if rows < 0 || cols < 0 {
+		panic(fmt.Sprintf("matrix: negative dimension %dx%d", rows, cols))
+	}
+	return &Matrix[T]{rows: rows, cols: cols, data: make([]T, rows*cols)}
+}
+
+// FromRows returns a matrix holding a copy of rows. All rows must have
+// the same length.
+func FromRows[T Number](rows [][]T) (*Matrix[T], error) {
+	cols := 0
+	if len(rows) > 0 {
+		cols = len(rows[0])
+	}
+	m := New[T](len(rows), cols)
+	for i, r := range rows {
+		if len(r) != cols {
+			return nil, fmt.Errorf("FromRows: row %d has %d elements, row 0 has %d: %w", i, len(r), cols, ErrShape)
+		}
+		copy(m.data[i*cols:], r)
+	}
+	return m, nil
+}
+
+// Identity returns the n × n identity matrix.
+func Identity[T Number](n int) *Matrix[T] {
+	m := New[T](n, n)
+	for i := range n {
+		m.data[i*n+i] = 1
+	}
+	return m
+}
+
+// Rows returns the number of rows.
+func (m *Matrix[T]) Rows() int { return m.rows }
+
+// Cols returns the number of columns.
+func (m *Matrix[T]) Cols() int { return m.cols }
+
+// At returns the element in row i, column j.
+func (m *Matrix[T]) At(i, j int) T { return m.data[m.index(i, j)] }
+
+// Set sets the element in row i, column j.
+func (m *Matrix[T]) Set(i, j int, v T) { m.data[m.index(i, j)] = v }
+
+// index checks both coordinates: data[i*cols+j] alone would let an
+// out-of-range j silently address the next row. The panic is in a
+// separate function, kept out of line, so that index itself inlines.
+func (m *Matrix[T]) index(i, j int) int {
+	if uint(i) >= uint(m.rows) || uint(j) >= uint(m.cols) {
+		m.outOfRange(i, j)
+	}
+	return i*m.cols + j
+}
+
+//go:noinline
+func (m *Matrix[T]) outOfRange(i, j int) {
+	panic(fmt.Sprintf("matrix: index (%d, %d) out of range for %dx%d", i, j, m.rows, m.cols))
+}
+
+// Row returns row i. It shares memory with m, so writes to it change m;
+// its capacity is clipped so that appending to it cannot.
+func (m *Matrix[T]) Row(i int) []T {
+	if i < 0 || i >= m.rows {
+		panic(fmt.Sprintf("matrix: row %d out of range for %dx%d", i, m.rows, m.cols))
+	}
+	return m.data[i*m.cols : (i+1)*m.cols : (i+1)*m.cols]
+}
+
+// ToRows returns a copy of m as a slice of rows.
+func (m *Matrix[T]) ToRows() [][]T {
+	out := make([][]T, m.rows)
+	for i := range out {
+		out[i] = append([]T(nil), m.Row(i)...)
+	}
+	return out
+}
+
+// Clone returns a copy of m.
+func (m *Matrix[T]) Clone() *Matrix[T] {
+	return &Matrix[T]{rows: m.rows, cols: m.cols, data: append([]T(nil), m.data...)}
+}
+
+// Equal reports whether m and o have the same shape and elements.
+func (m *Matrix[T]) Equal(o *Matrix[T]) bool {
+	if m.rows != o.rows || m.cols != o.cols {
+		return false
+	}
+	for i, v := range m.data {
+		if o.data[i] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// String formats m like the [][]T it represents: [[1 2] [3 4]].
+func (m *Matrix[T]) String() string {
+	var b strings.Builder
+	b.WriteByte('[')
+	for i := range m.rows {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		fmt.Fprint(&b, m.Row(i))
+	}
+	b.WriteByte(']')
+	return b.String()
+}
+
+// Transpose returns the cols × rows matrix with m's rows as columns.
+func (m *Matrix[T]) Transpose() *Matrix[T] {
+	t := New[T](m.cols, m.rows)
+	for i := range m.rows {
+		for j, v := range m.Row(i) {
+			t.data[j*m.rows+i] = v
+		}
+	}
+	return t
+}
+
+// Add returns m + o.
+func (m *Matrix[T]) Add(o *Matrix[T]) (*Matrix[T], error) {
+	if m.rows != o.rows || m.cols != o.cols {
+		return nil, fmt.Errorf("Add: %dx%d + %dx%d: %w", m.rows, m.cols, o.rows, o.cols, ErrShape)
+	}
+	sum := New[T](m.rows, m.cols)
+	for i, v := range m.data {
+		sum.data[i] = v + o.data[i]
+	}
+	return sum, nil
+}
+
+// Sub returns m - o.
+func (m *Matrix[T]) Sub(o *Matrix[T]) (*Matrix[T], error) {
+	if m.rows != o.rows || m.cols != o.cols {
+		return nil, fmt.Errorf("Sub: %dx%d - %dx%d: %w", m.rows, m.cols, o.rows, o.cols, ErrShape)
+	}
+	diff := New[T](m.rows, m.cols)
+	for i, v := range m.data {
+		diff.data[i] = v - o.data[i]
+	}
+	return diff, nil
+}
+
+// Scale returns m with every element multiplied by k.
+func (m *Matrix[T]) Scale(k T) *Matrix[T] {
+	out := New[T](m.rows, m.cols)
+	for i, v := range m.data {
+		out.data[i] = v * k
+	}
+	return out
+}
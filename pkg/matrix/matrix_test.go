@@ -0,0 +1,308 @@
+package matrix_test
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"golang-learning-project/pkg/matrix"
+)
+
+// nestedMul is the [][]T version of MulNaive, the way the 2D slice of
+// examples/01-basics/collections would be multiplied.
+func nestedMul[T matrix.Number](a, b [][]T) [][]T {
+	p := make([][]T, len(a))
+	for i := range a {
+		p[i] = make([]T, len(b[0]))
+		for j := range b[0] {
+			var sum T
+			for k := range b {
+				sum += a[i][k] * b[k][j]
+			}
+			p[i][j] = sum
+		}
+	}
+	return p
+}
+
+func nested(rows, cols int) [][]float64 {
+	m := make([][]float64, rows)
+	for i := range m {
+		m[i] = make([]float64, cols)
+	}
+	return m
+}
+
+func randomMatrix(r *rand.Rand, rows, cols int) *matrix.Matrix[float64] {
+	m := matrix.New[float64](rows, cols)
+	for i := range rows {
+		for j := range cols {
+			m.Set(i, j, r.Float64()*2-1)
+		}
+	}
+	return m
+}
+
+func randomInts(r *rand.Rand, rows, cols int) *matrix.Matrix[int] {
+	m := matrix.New[int](rows, cols)
+	for i := range rows {
+		for j := range cols {
+			m.Set(i, j, r.IntN(201)-100)
+		}
+	}
+	return m
+}
+
+// TestMul compares Mul and MulNaive with the [][]int product on random
+// integer matrices, whose products are exact.
+func TestMul(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for range 50 {
+		// Up to 150 so that some shapes span several Mul blocks and end
+		// in a partial one.
+		n, k, m := 1+r.IntN(150), 1+r.IntN(150), 1+r.IntN(150)
+		a, b := randomInts(r, n, k), randomInts(r, k, m)
+		want, err := matrix.FromRows(nestedMul(a.ToRows(), b.ToRows()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		naive, err := a.MulNaive(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		blocked, err := a.Mul(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !naive.Equal(want) {
+			t.Errorf("MulNaive of %dx%d and %dx%d differs from the [][]int product", n, k, k, m)
+		}
+		if !blocked.Equal(want) {
+			t.Errorf("Mul of %dx%d and %dx%d differs from the [][]int product", n, k, k, m)
+		}
+
+		// (AB)ᵀ = BᵀAᵀ, and A + C - C = A.
+		bt, _ := b.Transpose().Mul(a.Transpose())
+		if !bt.Equal(want.Transpose()) {
+			t.Errorf("(AB)ᵀ != BᵀAᵀ for %dx%d and %dx%d", n, k, k, m)
+		}
+		c := randomInts(r, n, k)
+		sum, _ := a.Add(c)
+		back, _ := sum.Sub(c)
+		if !back.Equal(a) {
+			t.Errorf("A + C - C != A for %dx%d", n, k)
+		}
+		if _, err := a.Mul(a); k != n && !errors.Is(err, matrix.ErrShape) {
+			t.Errorf("Mul of %dx%d by itself: got %v, want %v", n, k, err, matrix.ErrShape)
+		}
+	}
+}
+
+// TestLU checks Solve, Inverse and Det by their defining identities on
+// random float matrices.
+func TestLU(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4))
+	for range 50 {
+		n := 1 + r.IntN(30)
+		a := randomMatrix(r, n, n)
+		b := make([]float64, n)
+		for i := range b {
+			b[i] = r.Float64()*2 - 1
+		}
+		d, err := matrix.Decompose(a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d.Singular() {
+			continue // possible in principle for a random matrix; nothing to check
+		}
+
+		x, err := d.Solve(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := range n {
+			ax := 0.0
+			for j := range n {
+				ax += a.At(i, j) * x[j]
+			}
+			if math.Abs(ax-b[i]) > 1e-8 {
+				t.Errorf("row %d of A·Solve(b) for n=%d: got %v, want %v", i, n, ax, b[i])
+			}
+		}
+
+		inv, err := d.Inverse()
+		if err != nil {
+			t.Fatal(err)
+		}
+		id, _ := a.Mul(inv)
+		for i := range n {
+			for j := range n {
+				want := 0.0
+				if i == j {
+					want = 1
+				}
+				if got := id.At(i, j); math.Abs(got-want) > 1e-8 {
+					t.Errorf("(A·A⁻¹)[%d][%d] for n=%d: got %v, want %v", i, j, n, got, want)
+				}
+			}
+		}
+
+		// det(AC) = det(A)·det(C).
+		c := randomMatrix(r, n, n)
+		ac, _ := a.Mul(c)
+		detC, _ := c.Det()
+		detAC, _ := ac.Det()
+		if detA := d.Det(); math.Abs(detAC-detA*detC) > 1e-6*math.Abs(detAC) {
+			t.Errorf("det(AC) for n=%d: got %v, want %v", n, detAC, detA*detC)
+		}
+	}
+}
+
+// TestDet checks matrices with known determinants.
+func TestDet(t *testing.T) {
+	tests := []struct {
+		name string
+		rows [][]float64
+		want float64
+	}{
+		{"2x2", [][]float64{{3, 8}, {4, 6}}, -14},
+		{"singular", [][]float64{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}}, 0},
+		{"badly scaled", [][]float64{{1e20, 0}, {0, 1e-20}}, 1},
+		{"row swap", [][]float64{{0, 1}, {1, 0}}, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := matrix.FromRows(tt.rows)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, err := m.Det(); err != nil || got != tt.want {
+				t.Errorf("Det = %v, %v; want %v", got, err, tt.want)
+			}
+		})
+	}
+	if got, _ := matrix.Identity[int](5).Det(); got != 1 {
+		t.Errorf("Det of the 5x5 identity = %v; want 1", got)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	singular, _ := matrix.FromRows([][]int{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}})
+	if _, err := singular.Inverse(); !errors.Is(err, matrix.ErrSingular) {
+		t.Errorf("Inverse of a singular matrix: got %v, want %v", err, matrix.ErrSingular)
+	}
+	if _, err := singular.Solve([]float64{1, 2, 3}); !errors.Is(err, matrix.ErrSingular) {
+		t.Errorf("Solve with a singular matrix: got %v, want %v", err, matrix.ErrSingular)
+	}
+	if _, err := matrix.Identity[int](3).Solve([]float64{1, 2}); !errors.Is(err, matrix.ErrShape) {
+		t.Errorf("Solve with 2 right-hand sides for 3 equations: got %v, want %v", err, matrix.ErrShape)
+	}
+	rect := matrix.New[int](2, 3)
+	if _, err := rect.Det(); !errors.Is(err, matrix.ErrNotSquare) {
+		t.Errorf("Det of a 2x3 matrix: got %v, want %v", err, matrix.ErrNotSquare)
+	}
+	if _, err := rect.Inverse(); !errors.Is(err, matrix.ErrNotSquare) {
+		t.Errorf("Inverse of a 2x3 matrix: got %v, want %v", err, matrix.ErrNotSquare)
+	}
+	if _, err := matrix.FromRows([][]int{{1, 2}, {3}}); !errors.Is(err, matrix.ErrShape) {
+		t.Errorf("FromRows with ragged rows: got %v, want %v", err, matrix.ErrShape)
+	}
+}
+
+// sink keeps benchmark results alive so the compiler cannot drop the
+// work that produced them.
+var sink any
+
+// The benchmarks compare the flat row-major Matrix with the [][]float64
+// it replaces.
+const big, mul = 500, 250
+
+func BenchmarkAlloc500(b *testing.B) {
+	b.Run("nested", func(b *testing.B) {
+		for range b.N {
+			sink = nested(big, big)
+		}
+	})
+	b.Run("flat", func(b *testing.B) {
+		for range b.N {
+			sink = matrix.New[float64](big, big)
+		}
+	})
+}
+
+func BenchmarkRowSum500(b *testing.B) {
+	flat := randomMatrix(rand.New(rand.NewPCG(5, 6)), big, big)
+	rows := flat.ToRows()
+	b.Run("nested", func(b *testing.B) {
+		for range b.N {
+			sum := 0.0
+			for _, row := range rows {
+				for _, v := range row {
+					sum += v
+				}
+			}
+			sink = sum
+		}
+	})
+	b.Run("flat", func(b *testing.B) {
+		for range b.N {
+			sum := 0.0
+			for i := range big {
+				for _, v := range flat.Row(i) {
+					sum += v
+				}
+			}
+			sink = sum
+		}
+	})
+}
+
+func BenchmarkColSum500(b *testing.B) {
+	flat := randomMatrix(rand.New(rand.NewPCG(5, 6)), big, big)
+	rows := flat.ToRows()
+	b.Run("nested", func(b *testing.B) {
+		for range b.N {
+			sum := 0.0
+			for j := range big {
+				for i := range big {
+					sum += rows[i][j]
+				}
+			}
+			sink = sum
+		}
+	})
+	b.Run("flat", func(b *testing.B) {
+		for range b.N {
+			sum := 0.0
+			for j := range big {
+				for i := range big {
+					sum += flat.At(i, j)
+				}
+			}
+			sink = sum
+		}
+	})
+}
+
+func BenchmarkMul250(b *testing.B) {
+	r := rand.New(rand.NewPCG(7, 8))
+	x, y := randomMatrix(r, mul, mul), randomMatrix(r, mul, mul)
+	nx, ny := x.ToRows(), y.ToRows()
+	b.Run("nested", func(b *testing.B) {
+		for range b.N {
+			sink = nestedMul(nx, ny)
+		}
+	})
+	b.Run("flat", func(b *testing.B) {
+		for range b.N {
+			sink, _ = x.MulNaive(y)
+		}
+	})
+	b.Run("blocked", func(b *testing.B) {
+		for range b.N {
+			sink, _ = x.Mul(y)
+		}
+	})
+}
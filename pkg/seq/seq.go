@@ -0,0 +1,125 @@
+// Package seq builds lazy pipelines over iter.Seq and iter.Seq2.
+//
+// Every stage is a function from one sequence to another, so pipelines
+// compose by nesting and nothing runs until the result is ranged over:
+//
+//	sc := bufio.NewScanner(f)
+//	long := seq.Filter(seq.Lines(sc), func(s string) bool { return len(s) > 80 })
+//	for i, line := range seq.Enumerate(seq.Take(long, 10)) {
+//		fmt.Println(i, line)
+//	}
+//	if err := sc.Err(); err != nil { ... }
+//
+// Each value flows through the whole pipeline before the next is read,
+// and stages such as Take stop the source early, so large or endless
+// inputs are processed without intermediate slices. The adapters in
+// source.go turn slices, maps, channels, lines and readers into
+// sequences. Map, Filter and Zip are the iterator functions of pkg/fn,
+// re-exported so a pipeline needs only this package.
+package seq
+
+import (
+	"iter"
+
+	"golang-learning-project/pkg/fn"
+)
+
+// Map returns a sequence of f applied to every value of s.
+func Map[E, R any](s iter.Seq[E], f func(E) R) iter.Seq[R] {
+	return fn.MapSeq(s, f)
+}
+
+// Filter returns a sequence of the values of s for which keep returns
+// true.
+func Filter[E any](s iter.Seq[E], keep func(E) bool) iter.Seq[E] {
+	return fn.FilterSeq(s, keep)
+}
+
+// Zip returns a sequence of pairs taken from a and b in step, ending with
+// the shorter one. b is advanced with iter.Pull.
+func Zip[A, B any](a iter.Seq[A], b iter.Seq[B]) iter.Seq2[A, B] {
+	return fn.ZipSeq(a, b)
+}
+
+// Take returns a sequence of the first n values of s. It stops pulling
+// from s once it has them.
+func Take[E any](s iter.Seq[E], n int) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		if n <= 0 {
+			return
+		}
+		i := 0
+		for v := range s {
+			if !yield(v) {
+				return
+			}
+			if i++; i == n {
+				return
+			}
+		}
+	}
+}
+
+// Skip returns a sequence of the values of s after the first n.
+func Skip[E any](s iter.Seq[E], n int) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		i := 0
+		for v := range s {
+			if i < n {
+				i++
+				continue
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// TakeWhile returns a sequence of the values of s up to, not including,
+// the first one for which pred returns false.
+func TakeWhile[E any](s iter.Seq[E], pred func(E) bool) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		for v := range s {
+			if !pred(v) || !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Enumerate pairs every value of s with its 0-based position.
+func Enumerate[E any](s iter.Seq[E]) iter.Seq2[int, E] {
+	return func(yield func(int, E) bool) {
+		i := 0
+		for v := range s {
+			if !yield(i, v) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// Map2 returns a sequence of f applied to every pair of s.
+func Map2[K, V, R any](s iter.Seq2[K, V], f func(K, V) R) iter.Seq[R] {
+	return func(yield func(R) bool) {
+		for k, v := range s {
+			if !yield(f(k, v)) {
+				return
+			}
+		}
+	}
+}
+
+// Filter2 returns a sequence of the pairs of s for which keep returns
+// true.
+func Filter2[K, V any](s iter.Seq2[K, V], keep func(K, V) bool) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range s {
+			if keep(k, v) && !yield(k, v) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,251 @@
+package seq_test
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"iter"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"golang-learning-project/pkg/seq"
+)
+
+// naturals returns the endless sequence 0, 1, 2, ... and counts in
+// *pulled how many values were taken from it.
+func naturals(pulled *int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i := 0; ; i++ {
+			*pulled++
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+// first ranges over s and stops after n values, as a loop with a break
+// does, so that yield returns false.
+func first[E any](s iter.Seq[E], n int) []E {
+	var out []E
+	for v := range s {
+		out = append(out, v)
+		if len(out) == n {
+			break
+		}
+	}
+	return out
+}
+
+func less(n int) func(int) bool { return func(v int) bool { return v < n } }
+
+// TestEarlyStop checks that each stage stops pulling from its source as
+// soon as it has what it needs, both when it ends by itself and when the
+// loop over it breaks.
+func TestEarlyStop(t *testing.T) {
+	tests := []struct {
+		name   string
+		stage  func(iter.Seq[int]) iter.Seq[int]
+		n      int // values the loop takes before it breaks; 0 ranges to the end
+		want   []int
+		pulled int
+	}{
+		{"Take to the end", func(s iter.Seq[int]) iter.Seq[int] { return seq.Take(s, 3) }, 0, []int{0, 1, 2}, 3},
+		{"Take, break", func(s iter.Seq[int]) iter.Seq[int] { return seq.Take(s, 3) }, 1, []int{0}, 1},
+		{"Take 0", func(s iter.Seq[int]) iter.Seq[int] { return seq.Take(s, 0) }, 0, nil, 0},
+		{"Take negative", func(s iter.Seq[int]) iter.Seq[int] { return seq.Take(s, -1) }, 0, nil, 0},
+		{"Skip, break", func(s iter.Seq[int]) iter.Seq[int] { return seq.Skip(s, 2) }, 2, []int{2, 3}, 4},
+		{"Skip 0, break", func(s iter.Seq[int]) iter.Seq[int] { return seq.Skip(s, 0) }, 1, []int{0}, 1},
+		{"TakeWhile to the end", func(s iter.Seq[int]) iter.Seq[int] { return seq.TakeWhile(s, less(3)) }, 0, []int{0, 1, 2}, 4},
+		{"TakeWhile, break", func(s iter.Seq[int]) iter.Seq[int] { return seq.TakeWhile(s, less(3)) }, 2, []int{0, 1}, 2},
+		{"TakeWhile none", func(s iter.Seq[int]) iter.Seq[int] { return seq.TakeWhile(s, less(0)) }, 0, nil, 1},
+		{"Skip then Take", func(s iter.Seq[int]) iter.Seq[int] { return seq.Take(seq.Skip(s, 5), 2) }, 0, []int{5, 6}, 7},
+		{"Map, break", func(s iter.Seq[int]) iter.Seq[int] { return seq.Map(s, func(v int) int { return v * 10 }) }, 2, []int{0, 10}, 2},
+		{"Filter, break", func(s iter.Seq[int]) iter.Seq[int] { return seq.Filter(s, func(v int) bool { return v%3 == 0 }) }, 2, []int{0, 3}, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pulled := 0
+			got := first(tt.stage(naturals(&pulled)), tt.n)
+			if !slices.Equal(got, tt.want) || pulled != tt.pulled {
+				t.Errorf("got %v after pulling %d values, want %v after %d", got, pulled, tt.want, tt.pulled)
+			}
+		})
+	}
+}
+
+func TestPairs(t *testing.T) {
+	words := seq.FromSlice([]string{"go", "iter", "seq", "range"})
+
+	var got []string
+	for i, w := range seq.Enumerate(words) {
+		got = append(got, strings.Repeat(w, i))
+	}
+	if want := []string{"", "iter", "seqseq", "rangerangerange"}; !slices.Equal(got, want) {
+		t.Errorf("Enumerate: %q, want %q", got, want)
+	}
+
+	pulled := 0
+	for i, v := range seq.Enumerate(naturals(&pulled)) {
+		if i != v {
+			t.Errorf("Enumerate: %d at position %d", v, i)
+		}
+		if i == 2 {
+			break
+		}
+	}
+	if pulled != 3 {
+		t.Errorf("Enumerate pulled %d values for a loop that took 3", pulled)
+	}
+
+	long := seq.Filter2(seq.Enumerate(words), func(i int, w string) bool { return len(w) > 2 && i != 2 })
+	labels := seq.Map2(long, func(i int, w string) string { return strings.ToUpper(w[:1]) + w[1:] })
+	if got, want := slices.Collect(labels), []string{"Iter", "Range"}; !slices.Equal(got, want) {
+		t.Errorf("Map2 of Filter2: %q, want %q", got, want)
+	}
+	if got := first(labels, 1); !slices.Equal(got, []string{"Iter"}) {
+		t.Errorf("Map2 of Filter2, break: %q", got)
+	}
+
+	m := map[string]int{"b": 2, "c": 3, "a": 1}
+	var keys []string
+	for k, v := range seq.FromMapSorted(m) {
+		if m[k] != v {
+			t.Errorf("FromMapSorted: %s = %d, want %d", k, v, m[k])
+		}
+		keys = append(keys, k)
+	}
+	if !slices.Equal(keys, []string{"a", "b", "c"}) {
+		t.Errorf("FromMapSorted keys %q", keys)
+	}
+	if got := maps.Collect(seq.FromMap(m)); !maps.Equal(got, m) {
+		t.Errorf("FromMap = %v", got)
+	}
+}
+
+func TestFromChan(t *testing.T) {
+	ch := make(chan int, 5)
+	for i := range 5 {
+		ch <- i
+	}
+	close(ch)
+
+	// Stopping early leaves the rest in the channel for the next reader
+	if got := first(seq.FromChan(ch), 2); !slices.Equal(got, []int{0, 1}) {
+		t.Errorf("FromChan, break: %v", got)
+	}
+	if got := slices.Collect(seq.FromChan(ch)); !slices.Equal(got, []int{2, 3, 4}) {
+		t.Errorf("FromChan after an early stop: %v, want the rest", got)
+	}
+	if got := slices.Collect(seq.FromChan(ch)); got != nil {
+		t.Errorf("FromChan of a drained channel: %v", got)
+	}
+}
+
+var errDisk = errors.New("disk on fire")
+
+func TestLines(t *testing.T) {
+	tests := []struct {
+		name string
+		r    io.Reader
+		want []string
+		err  error
+	}{
+		{"lines", strings.NewReader("one\ntwo\r\n\nfour"), []string{"one", "two", "", "four"}, nil},
+		{"empty", strings.NewReader(""), nil, nil},
+		{"read error", io.MultiReader(strings.NewReader("one\ntwo\n"), iotest.ErrReader(errDisk)), []string{"one", "two"}, errDisk},
+	}
+	for _, tt := range tests {
+		sc := bufio.NewScanner(tt.r)
+		got := slices.Collect(seq.Lines(sc))
+		if !slices.Equal(got, tt.want) || sc.Err() != tt.err {
+			t.Errorf("%s: Lines = %q, Err %v; want %q, %v", tt.name, got, sc.Err(), tt.want, tt.err)
+		}
+	}
+
+	// Breaking out leaves the scanner at the next line
+	sc := bufio.NewScanner(strings.NewReader("a\nb\nc\n"))
+	if got := first(seq.Lines(sc), 1); !slices.Equal(got, []string{"a"}) {
+		t.Errorf("Lines, break: %q", got)
+	}
+	if got := slices.Collect(seq.Lines(sc)); !slices.Equal(got, []string{"b", "c"}) {
+		t.Errorf("Lines after an early stop: %q", got)
+	}
+}
+
+func TestChunks(t *testing.T) {
+	type chunk struct {
+		data string
+		err  error
+	}
+	collect := func(r io.Reader, size int) []chunk {
+		var out []chunk
+		for b, err := range seq.Chunks(r, size) {
+			out = append(out, chunk{string(b), err})
+		}
+		return out
+	}
+	tests := []struct {
+		name string
+		r    io.Reader
+		size int
+		want []chunk
+	}{
+		{"exact", strings.NewReader("abcdef"), 3, []chunk{{"abc", nil}, {"def", nil}}},
+		{"short last", strings.NewReader("abcdefg"), 3, []chunk{{"abc", nil}, {"def", nil}, {"g", nil}}},
+		{"empty", strings.NewReader(""), 3, nil},
+		{"one byte reads", iotest.OneByteReader(strings.NewReader("abcde")), 2, []chunk{{"ab", nil}, {"cd", nil}, {"e", nil}}},
+		{
+			"read error",
+			io.MultiReader(strings.NewReader("abcde"), iotest.ErrReader(errDisk)), 2,
+			[]chunk{{"ab", nil}, {"cd", nil}, {"e", nil}, {"", errDisk}},
+		},
+		{"error first", iotest.ErrReader(errDisk), 2, []chunk{{"", errDisk}}},
+	}
+	for _, tt := range tests {
+		if got := collect(tt.r, tt.size); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: Chunks = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	// The buffer is reused: a chunk kept without a clone is overwritten
+	// by the next read
+	var kept, cloned [][]byte
+	for b, err := range seq.Chunks(strings.NewReader("abcdef"), 3) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		kept = append(kept, b)
+		cloned = append(cloned, slices.Clone(b))
+	}
+	if len(kept) != 2 || &kept[0][0] != &kept[1][0] || string(kept[0]) != "def" {
+		t.Errorf("kept chunks %q, want both to be the reused buffer holding def", kept)
+	}
+	if string(cloned[0]) != "abc" || string(cloned[1]) != "def" {
+		t.Errorf("cloned chunks %q", cloned)
+	}
+
+	// Stopping early reads no further
+	r := strings.NewReader("abcdef")
+	for b := range seq.Chunks(r, 2) {
+		if string(b) != "ab" {
+			t.Errorf("first chunk %q", b)
+		}
+		break
+	}
+	if r.Len() != 4 {
+		t.Errorf("Chunks read %d bytes for a loop that took one 2-byte chunk", 6-r.Len())
+	}
+}
+
+func TestChunksSize(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Chunks with size 0 did not panic")
+		}
+	}()
+	seq.Chunks(strings.NewReader("x"), 0)
+}
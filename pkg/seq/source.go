@@ -0,0 +1,89 @@
+package seq
+
+import (
+	"bufio"
+	"cmp"
+	"io"
+	"iter"
+	"maps"
+	"slices"
+)
+
+// FromSlice returns a sequence of the elements of s in order.
+func FromSlice[S ~[]E, E any](s S) iter.Seq[E] {
+	return slices.Values(s)
+}
+
+// FromMap returns a sequence of the key-value pairs of m in Go's
+// unspecified map order.
+func FromMap[M ~map[K]V, K comparable, V any](m M) iter.Seq2[K, V] {
+	return maps.All(m)
+}
+
+// FromMapSorted returns a sequence of the key-value pairs of m in
+// ascending key order, for output that must be reproducible. It sorts the
+// keys up front, so it costs one slice of len(m) keys.
+func FromMapSorted[M ~map[K]V, K cmp.Ordered, V any](m M) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, k := range slices.Sorted(maps.Keys(m)) {
+			if !yield(k, m[k]) {
+				return
+			}
+		}
+	}
+}
+
+// FromChan returns a sequence of the values received from ch until it is
+// closed. Stopping early leaves the remaining values in the channel.
+func FromChan[E any](ch <-chan E) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		for v := range ch {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Lines returns a sequence of the tokens of sc, which are lines unless
+// sc.Split was changed. Like any loop over a Scanner, check sc.Err after
+// the sequence ends.
+func Lines(sc *bufio.Scanner) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for sc.Scan() {
+			if !yield(sc.Text()) {
+				return
+			}
+		}
+	}
+}
+
+// Chunks returns a sequence of the contents of r read size bytes at a
+// time, paired with the read error if there was one. The last chunk may
+// be shorter, and io.EOF ends the sequence instead of being yielded.
+//
+// To avoid an allocation per chunk, the same buffer is reused for every
+// read: a chunk is only valid until the loop asks for the next one, so
+// clone it to keep it.
+func Chunks(r io.Reader, size int) iter.Seq2[[]byte, error] {
+	if size < 1 {
+		panic("seq.Chunks: size must be at least 1")
+	}
+	return func(yield func([]byte, error) bool) {
+		buf := make([]byte, size)
+		for {
+			n, err := io.ReadFull(r, buf)
+			if n > 0 && !yield(buf[:n], nil) {
+				return
+			}
+			switch err {
+			case nil:
+			case io.EOF, io.ErrUnexpectedEOF:
+				return
+			default:
+				yield(nil, err)
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package slicetrace
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxCell is the widest an element is drawn; longer values are cut.
+const maxCell = 8
+
+// draw renders every referenced array with the traced slices that point
+// into it, then the traced slices that have no array.
+func (t *Trace[T]) draw() string {
+	pad := len("array")
+	for _, n := range t.names {
+		pad = max(pad, len(n))
+	}
+	var b strings.Builder
+	for _, a := range t.arrays {
+		if a.gone {
+			continue
+		}
+		cells := make([]string, len(a.keep))
+		width := 1
+		for i, v := range a.keep {
+			cells[i] = cut(fmt.Sprint(v))
+			width = max(width, utf8.RuneCountInString(cells[i]))
+		}
+		stride := width + 3 // "| " + cell + " "
+
+		fmt.Fprintf(&b, "   array %s @%p\n", a.label, &a.keep[0])
+		var index strings.Builder
+		for i := range cells {
+			fmt.Fprintf(&index, "  %-*d ", width, i)
+		}
+		fmt.Fprintf(&b, "   %*s %s\n", pad, "", strings.TrimRight(index.String(), " "))
+		fmt.Fprintf(&b, "   %*s ", pad, "")
+		for _, c := range cells {
+			fmt.Fprintf(&b, "| %s%s ", c, strings.Repeat(" ", width-utf8.RuneCountInString(c)))
+		}
+		b.WriteString("|\n")
+
+		for _, n := range t.names {
+			s := t.values[n]
+			if t.arrayOf(s) != a {
+				continue
+			}
+			start, _ := bounds(s)
+			off := int((start - a.lo) / size[T]())
+			bar := []byte(strings.Repeat(" ", len(cells)*stride+1))
+			for i := off; i < off+cap(s); i++ {
+				fill := byte('.')
+				if i < off+len(s) {
+					fill = '='
+				}
+				for j := i * stride; j < (i+1)*stride; j++ {
+					bar[j] = fill
+				}
+			}
+			bar[off*stride] = '['
+			bar[(off+cap(s))*stride] = ']'
+			fmt.Fprintf(&b, "   %-*s %s  len %d cap %d\n", pad, n, bar, len(s), cap(s))
+		}
+		b.WriteByte('\n')
+	}
+	for _, n := range t.names {
+		if s := t.values[n]; cap(s) == 0 {
+			state := "empty, no backing array"
+			if s == nil {
+				state = "nil"
+			}
+			fmt.Fprintf(&b, "   %-*s %s\n\n", pad, n, state)
+		}
+	}
+	return b.String()
+}
+
+// cut shortens s to maxCell runes.
+func cut(s string) string {
+	if utf8.RuneCountInString(s) <= maxCell {
+		return s
+	}
+	return string([]rune(s)[:maxCell-1]) + "~"
+}
@@ -0,0 +1,386 @@
+// Package slicetrace records slice operations and draws what they do to
+// the backing arrays.
+//
+// A slice is a pointer into an array plus a length and a capacity, so
+// several slices can share one array, and append either writes into the
+// spare capacity of that array or, when it does not fit, copies
+// everything to a new one. Prints of len and cap alone hide which of the
+// two happened. A Trace performs append, reslice and copy on named
+// slices and after each step draws every array with the slices that
+// point into it:
+//
+//	var t slicetrace.Trace[string]
+//	colors := t.Literal("colors", "red", "green", "blue", "yellow")
+//	rest := t.AppendSlice("rest", colors[:1], colors[2:])
+//	fmt.Print(t.String())
+//
+// draws, after the literal's step,
+//
+//	...
+//	2. rest := append(colors[:1], colors[2:]...)
+//	   fits in cap 4: written in place into array A, no allocation
+//	   rest shares array A with colors: no copy was made
+//	   colors[1] changed: green -> blue
+//	   colors[2] changed: blue -> yellow
+//
+//	   array A @0xc000102000
+//	            0        1        2        3
+//	          | red    | blue   | yellow | yellow |
+//	   colors [===================================]  len 4 cap 4
+//	   rest   [==========================.........]  len 3 cap 4
+//
+// '=' marks the elements each slice can read and '.' its spare capacity,
+// which the next append writes into.
+//
+// Arrays are told apart by address range, and the Trace keeps every
+// array it has drawn alive so that an address is never reused for a
+// different array during the trace.
+package slicetrace
+
+import (
+	"fmt"
+	"strings"
+	"unsafe"
+)
+
+// Trace records operations on named slices of T. The zero value is ready
+// to use. It is not safe for concurrent use.
+type Trace[T any] struct {
+	names  []string // in order of first use
+	values map[string][]T
+	arrays []*array[T]
+	steps  []Step
+}
+
+// Step is one recorded operation.
+type Step struct {
+	Code    string   // the operation, written as Go
+	Notes   []string // what happened: allocations, aliasing, overwrites
+	Diagram string   // the arrays and slices after the operation
+}
+
+// array is a backing array seen during the trace, known by the address
+// range [lo, hi) that traced slices have covered.
+type array[T any] struct {
+	label  string
+	lo, hi uintptr
+	keep   []T  // holds the array alive
+	gone   bool // no longer referenced, already reported
+}
+
+// Steps returns the recorded steps.
+func (t *Trace[T]) Steps() []Step { return t.steps }
+
+// Literal records name := []T{vs...}.
+func (t *Trace[T]) Literal(name string, vs ...T) []T {
+	s := make([]T, len(vs)) // not slices.Clone, which may round cap up
+	copy(s, vs)
+	return t.Assign(name, s, fmt.Sprintf("%T%s", s, braces(vs)))
+}
+
+// Make records name := make([]T, n, c).
+func (t *Trace[T]) Make(name string, n, c int) []T {
+	s := make([]T, n, c)
+	return t.Assign(name, s, fmt.Sprintf("make(%T, %d, %d)", s, n, c))
+}
+
+// Assign records that name was set to s by the Go expression code, for
+// operations the Trace has no method for, such as slices.Clone.
+func (t *Trace[T]) Assign(name string, s []T, code string) []T {
+	t.record(name, s, name+t.assignOp(name)+code, nil)
+	return s
+}
+
+// Append records name = append(s, vs...).
+func (t *Trace[T]) Append(name string, s []T, vs ...T) []T {
+	args := []string{t.describe(s)}
+	for _, v := range vs {
+		args = append(args, fmt.Sprintf("%#v", v))
+	}
+	return t.appendValues(name, s, vs, strings.Join(args, ", "))
+}
+
+// AppendSlice records name = append(s, other...).
+func (t *Trace[T]) AppendSlice(name string, s, other []T) []T {
+	return t.appendValues(name, s, other, t.describe(s)+", "+t.describe(other)+"...")
+}
+
+func (t *Trace[T]) appendValues(name string, s, vs []T, args string) []T {
+	code := fmt.Sprintf("%s%sappend(%s)", name, t.assignOp(name), args)
+	var notes []string
+	if need := len(s) + len(vs); need > cap(s) {
+		out := append(s, vs...)
+		a := t.arrayOf(out)
+		if cap(s) == 0 {
+			notes = append(notes, fmt.Sprintf("no capacity: allocated a new array %s with cap %d", a.label, cap(out)))
+		} else {
+			notes = append(notes, fmt.Sprintf("needed len %d > cap %d: copied %d elements to a new array %s with cap %d",
+				need, cap(s), len(s), a.label, cap(out)),
+				fmt.Sprintf("backing array pointer changed: %p -> %p", unsafe.SliceData(s), unsafe.SliceData(out)))
+		}
+		t.record(name, out, code, notes)
+		return out
+	}
+	var out []T
+	changes := t.watch(s[len(s):len(s)+len(vs)], name, func() { out = append(s, vs...) })
+	notes = append(notes, fmt.Sprintf("fits in cap %d: written in place into array %s, no allocation",
+		cap(s), t.arrayOf(s).label))
+	notes = append(notes, t.sharing(name, out)...)
+	t.record(name, out, code, append(notes, changes...))
+	return out
+}
+
+// Slice records name = s[lo:hi].
+func (t *Trace[T]) Slice(name string, s []T, lo, hi int) []T {
+	out := s[lo:hi]
+	code := fmt.Sprintf("%s%s%s[%s:%d]", name, t.assignOp(name), t.describe(s), low(lo), hi)
+	t.record(name, out, code, t.sharing(name, out))
+	return out
+}
+
+// Slice3 records name = s[lo:hi:max], which also limits the capacity.
+func (t *Trace[T]) Slice3(name string, s []T, lo, hi, max int) []T {
+	out := s[lo:hi:max]
+	code := fmt.Sprintf("%s%s%s[%d:%d:%d]", name, t.assignOp(name), t.describe(s), lo, hi, max)
+	notes := t.sharing(name, out)
+	if len(out) == cap(out) {
+		notes = append(notes, fmt.Sprintf("len == cap, so the next append to %s copies instead of writing into the shared array", name))
+	}
+	t.record(name, out, code, notes)
+	return out
+}
+
+// Copy records copy(dst, src) and returns the number of elements copied.
+func (t *Trace[T]) Copy(dst, src []T) int {
+	code := fmt.Sprintf("copy(%s, %s)", t.describe(dst), t.describe(src))
+	var n int
+	changes := t.watch(dst[:min(len(dst), len(src))], "", func() { n = copy(dst, src) })
+	notes := []string{fmt.Sprintf("copied %d elements (the shorter of len %d and len %d)", n, len(dst), len(src))}
+	if n > 0 && t.arrayOf(dst) == t.arrayOf(src) {
+		notes = append(notes, fmt.Sprintf("source and destination share array %s; copy handles overlap like memmove", t.arrayOf(dst).label))
+	}
+	t.record("", nil, code, append(notes, changes...))
+	return n
+}
+
+// String returns every step with its notes and diagram.
+func (t *Trace[T]) String() string {
+	var b strings.Builder
+	for i, s := range t.steps {
+		fmt.Fprintf(&b, "%d. %s\n", i+1, s.Code)
+		for _, n := range s.Notes {
+			fmt.Fprintf(&b, "   %s\n", n)
+		}
+		b.WriteByte('\n')
+		b.WriteString(s.Diagram)
+	}
+	return b.String()
+}
+
+func (t *Trace[T]) assignOp(name string) string {
+	if _, ok := t.values[name]; ok {
+		return " = "
+	}
+	return " := "
+}
+
+// record sets name to s (unless name is empty) and appends a step.
+func (t *Trace[T]) record(name string, s []T, code string, notes []string) {
+	if t.values == nil {
+		t.values = make(map[string][]T)
+	}
+	if name != "" {
+		if _, ok := t.values[name]; !ok {
+			t.names = append(t.names, name)
+		}
+		t.values[name] = s
+		known := len(t.arrays)
+		if a := t.arrayOf(s); len(t.arrays) > known {
+			notes = append(notes, fmt.Sprintf("new array %s with cap %d", a.label, cap(s)))
+		}
+	}
+	for _, a := range t.arrays {
+		if !a.gone && !t.referenced(a) {
+			a.gone = true
+			notes = append(notes, fmt.Sprintf("array %s is no longer referenced by any traced slice", a.label))
+		}
+	}
+	t.steps = append(t.steps, Step{Code: code, Notes: notes, Diagram: t.draw()})
+}
+
+// arrayOf returns the array s points into, registering it if it is new.
+// It returns nil for slices with no capacity.
+func (t *Trace[T]) arrayOf(s []T) *array[T] {
+	if cap(s) == 0 {
+		return nil
+	}
+	lo, hi := bounds(s)
+	for _, a := range t.arrays {
+		if lo < a.hi && a.lo < hi {
+			if lo < a.lo || hi > a.hi {
+				base := unsafe.SliceData(a.keep)
+				if lo < a.lo {
+					base = unsafe.SliceData(s)
+				}
+				a.lo, a.hi = min(a.lo, lo), max(a.hi, hi)
+				a.keep = unsafe.Slice(base, (a.hi-a.lo)/size[T]())
+			}
+			return a
+		}
+	}
+	a := &array[T]{label: label(len(t.arrays)), lo: lo, hi: hi, keep: s[:cap(s)]}
+	t.arrays = append(t.arrays, a)
+	return a
+}
+
+func (t *Trace[T]) referenced(a *array[T]) bool {
+	for _, s := range t.values {
+		if t.arrayOf(s) == a {
+			return true
+		}
+	}
+	return false
+}
+
+// sharing notes which other traced slices s shares an array with.
+func (t *Trace[T]) sharing(name string, s []T) []string {
+	a := t.arrayOf(s)
+	if a == nil {
+		return nil
+	}
+	var others []string
+	for _, n := range t.names {
+		if n != name && t.arrayOf(t.values[n]) == a {
+			others = append(others, n)
+		}
+	}
+	if len(others) == 0 {
+		return nil
+	}
+	return []string{fmt.Sprintf("%s shares array %s with %s: no copy was made", name, a.label, strings.Join(others, ", "))}
+}
+
+// watch runs op, which writes to the elements of region, and returns
+// notes on the elements of other traced slices (except skip) whose
+// values changed as a result.
+func (t *Trace[T]) watch(region []T, skip string, op func()) []string {
+	type watched struct {
+		name  string
+		i     int
+		old   string
+		value *T
+	}
+	var ws []watched
+	lo, _ := bounds(region)
+	hi := lo + uintptr(len(region))*size[T]()
+	for _, n := range t.names {
+		s := t.values[n]
+		if n == skip || len(s) == 0 {
+			continue
+		}
+		for i := range s {
+			p := uintptr(unsafe.Pointer(&s[i]))
+			if lo <= p && p < hi {
+				ws = append(ws, watched{n, i, fmt.Sprint(s[i]), &s[i]})
+			}
+		}
+	}
+	op()
+	var notes []string
+	for _, w := range ws {
+		if now := fmt.Sprint(*w.value); now != w.old {
+			notes = append(notes, fmt.Sprintf("%s[%d] changed: %s -> %s", w.name, w.i, w.old, now))
+		}
+	}
+	return notes
+}
+
+// describe writes s as an expression over a traced slice, such as
+// "colors" or "colors[1:3]", or as a composite literal if it points
+// into no traced slice.
+func (t *Trace[T]) describe(s []T) string {
+	if cap(s) == 0 {
+		for _, n := range t.names {
+			if v := t.values[n]; cap(v) == 0 && (v == nil) == (s == nil) {
+				return n
+			}
+		}
+		if s == nil {
+			return "nil"
+		}
+	}
+	best, bestScore := "", 0
+	for _, n := range t.names {
+		v := t.values[n]
+		if cap(v) == 0 || cap(s) == 0 || t.arrayOf(v) != t.arrayOf(s) {
+			continue
+		}
+		start, _ := bounds(v)
+		p, _ := bounds(s)
+		if p < start {
+			continue
+		}
+		lo := int((p - start) / size[T]())
+		hi := lo + len(s)
+		if hi > cap(v) {
+			continue
+		}
+		expr, score := n, 3
+		switch {
+		case lo == 0 && len(s) == len(v) && cap(s) == cap(v):
+		case cap(s) != cap(v)-lo:
+			expr, score = fmt.Sprintf("%s[%d:%d:%d]", n, lo, hi, lo+cap(s)), 1
+		default:
+			expr, score = fmt.Sprintf("%s[%s:%d]", n, low(lo), hi), 2
+			if hi == len(v) {
+				expr = fmt.Sprintf("%s[%s:]", n, low(lo))
+			}
+		}
+		if score > bestScore {
+			best, bestScore = expr, score
+		}
+	}
+	if best != "" {
+		return best
+	}
+	return fmt.Sprintf("%T%s", s, braces(s))
+}
+
+func braces[T any](vs []T) string {
+	parts := make([]string, len(vs))
+	for i, v := range vs {
+		parts[i] = fmt.Sprintf("%#v", v)
+	}
+	return "{" + strings.Join(parts, ", ") + "}"
+}
+
+// low formats the low bound of a slice expression, leaving it out when
+// it is zero. The high bound is always written: s[:0] is not s[:].
+func low(i int) string {
+	if i == 0 {
+		return ""
+	}
+	return fmt.Sprint(i)
+}
+
+// bounds returns the address range of s's capacity.
+func bounds[T any](s []T) (lo, hi uintptr) {
+	lo = uintptr(unsafe.Pointer(unsafe.SliceData(s)))
+	return lo, lo + uintptr(cap(s))*size[T]()
+}
+
+// size returns the element size, counting zero-size types as 1 so that
+// address ranges stay non-empty.
+func size[T any]() uintptr {
+	var zero T
+	return max(unsafe.Sizeof(zero), 1)
+}
+
+// label names the i-th array A, B, ..., Z, A1, B1, ...
+func label(i int) string {
+	l := string(rune('A' + i%26))
+	if i >= 26 {
+		l += fmt.Sprint(i / 26)
+	}
+	return l
+}
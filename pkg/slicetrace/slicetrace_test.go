@@ -0,0 +1,182 @@
+package slicetrace_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"golang-learning-project/pkg/slicetrace"
+)
+
+// last returns the most recent step of t.
+func last[T any](t *slicetrace.Trace[T]) slicetrace.Step {
+	steps := t.Steps()
+	return steps[len(steps)-1]
+}
+
+// hasNote reports whether one of step's notes contains want.
+func hasNote(step slicetrace.Step, want string) bool {
+	return slices.ContainsFunc(step.Notes, func(n string) bool { return strings.Contains(n, want) })
+}
+
+func TestSlice(t *testing.T) {
+	tests := []struct {
+		name   string
+		lo, hi int
+		from   func(s []int) []int // the slice to reslice; s itself if nil
+		code   string
+		want   []int
+	}{
+		{"whole", 0, 6, nil, "e := s[:6]", []int{0, 1, 2, 3, 4, 5}},
+		{"empty at the start", 0, 0, nil, "e := s[:0]", []int{}},
+		{"empty in the middle", 3, 3, nil, "e := s[3:3]", []int{}},
+		{"prefix", 0, 2, nil, "e := s[:2]", []int{0, 1}},
+		{"middle", 1, 4, nil, "e := s[1:4]", []int{1, 2, 3}},
+		{"of a suffix", 0, 1, func(s []int) []int { return s[2:] }, "e := s[2:][:1]", []int{2}},
+		{"of a middle", 1, 2, func(s []int) []int { return s[2:4] }, "e := s[2:4][1:2]", []int{3}},
+		{"of an empty prefix", 0, 3, func(s []int) []int { return s[:0] }, "e := s[:0][:3]", []int{0, 1, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var tr slicetrace.Trace[int]
+			s := tr.Literal("s", 0, 1, 2, 3, 4, 5)
+			from := s
+			if tt.from != nil {
+				from = tt.from(s)
+			}
+			got := tr.Slice("e", from, tt.lo, tt.hi)
+			step := last(&tr)
+			if step.Code != tt.code || !slices.Equal(got, tt.want) {
+				t.Errorf("Slice(%d, %d) = %v, code %q; want %v, %q", tt.lo, tt.hi, got, step.Code, tt.want, tt.code)
+			}
+			if !hasNote(step, "e shares array A with s") {
+				t.Errorf("notes %q, want e to share array A with s", step.Notes)
+			}
+		})
+	}
+}
+
+func TestSlice3(t *testing.T) {
+	var tr slicetrace.Trace[int]
+	s := tr.Make("s", 4, 8)
+	c := tr.Slice3("c", s, 1, 3, 3)
+	step := last(&tr)
+	if step.Code != "c := s[1:3:3]" || len(c) != 2 || cap(c) != 2 {
+		t.Errorf("Slice3 = len %d cap %d, code %q", len(c), cap(c), step.Code)
+	}
+	if !hasNote(step, "next append to c copies") {
+		t.Errorf("notes %q, want the len == cap warning", step.Notes)
+	}
+
+	// The next append copies, so s is left alone
+	tr.Append("c", c, 9)
+	if step := last(&tr); !hasNote(step, "copied 2 elements to a new array B") || s[3] != 0 {
+		t.Errorf("append to a full three-index slice: notes %q, s %v", step.Notes, s)
+	}
+}
+
+func TestAppend(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(tr *slicetrace.Trace[string]) []string
+		code  string
+		want  []string
+		notes []string
+	}{
+		{
+			"in place",
+			func(tr *slicetrace.Trace[string]) []string { return tr.Make("s", 1, 4) },
+			`a := append(s, "x", "y")`,
+			[]string{"", "x", "y"},
+			[]string{"fits in cap 4: written in place into array A, no allocation", "a shares array A with s"},
+		},
+		{
+			"reallocated",
+			func(tr *slicetrace.Trace[string]) []string { return tr.Literal("s", "p", "q") },
+			`a := append(s, "x", "y")`,
+			[]string{"p", "q", "x", "y"},
+			[]string{"needed len 4 > cap 2: copied 2 elements to a new array B", "backing array pointer changed"},
+		},
+		{
+			"nil",
+			func(tr *slicetrace.Trace[string]) []string { return tr.Assign("s", nil, "nil") },
+			`a := append(s, "x", "y")`,
+			[]string{"x", "y"},
+			[]string{"no capacity: allocated a new array A"},
+		},
+		{
+			"onto a prefix",
+			func(tr *slicetrace.Trace[string]) []string {
+				return tr.Literal("s", "p", "q", "r")[:0]
+			},
+			`a := append(s[:0], "x", "y")`,
+			[]string{"x", "y"},
+			[]string{"fits in cap 3", "s[0] changed: p -> x", "s[1] changed: q -> y"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var tr slicetrace.Trace[string]
+			got := tr.Append("a", tt.setup(&tr), "x", "y")
+			step := last(&tr)
+			if step.Code != tt.code || !slices.Equal(got, tt.want) {
+				t.Errorf("Append = %q, code %q; want %q, %q", got, step.Code, tt.want, tt.code)
+			}
+			for _, n := range tt.notes {
+				if !hasNote(step, n) {
+					t.Errorf("notes %q, want %q", step.Notes, n)
+				}
+			}
+		})
+	}
+}
+
+// TestAliasing checks the report of who shares what: an append through
+// one slice that overwrites what another can see, and a copy within one
+// array.
+func TestAliasing(t *testing.T) {
+	var tr slicetrace.Trace[string]
+	colors := tr.Literal("colors", "red", "green", "blue", "yellow")
+	rest := tr.AppendSlice("rest", colors[:1], colors[2:])
+	step := last(&tr)
+	want := []string{
+		"fits in cap 4: written in place into array A, no allocation",
+		"rest shares array A with colors: no copy was made",
+		"colors[1] changed: green -> blue",
+		"colors[2] changed: blue -> yellow",
+	}
+	if step.Code != "rest := append(colors[:1], colors[2:]...)" || !slices.Equal(step.Notes, want) {
+		t.Errorf("AppendSlice: %q\n%q\nwant %q", step.Code, step.Notes, want)
+	}
+	if !slices.Equal(rest, []string{"red", "blue", "yellow"}) {
+		t.Errorf("rest = %q", rest)
+	}
+	for _, line := range []string{"colors [", "len 4 cap 4", "rest   [", "len 3 cap 4", "| red    | blue   | yellow | yellow |"} {
+		if !strings.Contains(step.Diagram, line) {
+			t.Errorf("diagram has no %q:\n%s", line, step.Diagram)
+		}
+	}
+
+	n := tr.Copy(colors[1:], colors)
+	step = last(&tr)
+	if n != 3 || step.Code != "copy(colors[1:], colors)" || !hasNote(step, "share array A; copy handles overlap like memmove") {
+		t.Errorf("Copy = %d, %q %q", n, step.Code, step.Notes)
+	}
+	if !slices.Equal(colors, []string{"red", "red", "blue", "yellow"}) {
+		t.Errorf("colors after the overlapping copy = %q", colors)
+	}
+
+	// A clone shares nothing
+	tr.Assign("own", slices.Clone(colors[:2]), "slices.Clone(colors[:2])")
+	if step := last(&tr); hasNote(step, "shares") || !hasNote(step, "new array B") {
+		t.Errorf("clone notes %q, want a new array and no sharing", step.Notes)
+	}
+
+	// Once no traced slice points into array A, it is reported gone
+	tr.Assign("colors", nil, "nil")
+	tr.Assign("rest", nil, "nil")
+	if step := last(&tr); !hasNote(step, "array A is no longer referenced by any traced slice") ||
+		strings.Contains(step.Diagram, "array A") {
+		t.Errorf("after dropping colors and rest: %q\n%s", step.Notes, step.Diagram)
+	}
+}
@@ -0,0 +1,134 @@
+package textinspect
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"unicode"
+	"unicode/utf8"
+)
+
+// WriteText writes r as a human-readable report. Columns that hold the
+// characters themselves come last on each line, because emoji and East
+// Asian characters are two columns wide and would push the rest out of
+// line. Invalid bytes are shown there as U+FFFD; the escaped columns
+// keep them exact.
+func (r Report) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	valid := "valid UTF-8"
+	if !r.Valid {
+		valid = fmt.Sprintf("NOT valid UTF-8 (%d bad byte(s))", len(r.Invalid))
+	}
+	fmt.Fprintf(tw, "input:\t%s\n", printable(r.Input))
+	fmt.Fprintf(tw, "escaped:\t\"%s\"\n", r.Escaped)
+	fmt.Fprintf(tw, "length:\t%d bytes, %d runes, %d graphemes, %s\n",
+		len(r.Bytes), len(r.Runes), len(r.Graphemes), valid)
+	tw.Flush()
+
+	fmt.Fprintln(w, "\nbytes:")
+	hexdump(w, r.Bytes)
+
+	fmt.Fprintln(w, "\nrunes:")
+	tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "  offset\tcode point\tcat\tutf-8\tbits (marker|payload)\tname\tchar")
+	for _, c := range r.Runes {
+		if !c.Valid {
+			fmt.Fprintf(tw, "  %d\tinvalid\t\t%02x\t%08b\t-\t\\x%02x\n", c.Offset, c.Encoding[0], c.Encoding[0], c.Encoding[0])
+			continue
+		}
+		fmt.Fprintf(tw, "  %d\tU+%04X\t%s\t% x\t%s\t%s\t%s\n",
+			c.Offset, c.Rune, c.Category, []byte(c.Encoding), bits(c.Encoding), c.Name, display(c.Rune))
+	}
+	tw.Flush()
+
+	if len(r.Invalid) > 0 {
+		fmt.Fprintln(w, "\ninvalid UTF-8:")
+		for _, bad := range r.Invalid {
+			fmt.Fprintf(w, "  offset %d, byte 0x%02x: %s\n", bad.Offset, bad.Byte, bad.Reason)
+		}
+	}
+
+	fmt.Fprintln(w, "\ngraphemes (user-perceived characters):")
+	tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	offset := 0
+	for i, g := range r.Graphemes {
+		fmt.Fprintf(tw, "  %d\toffset %d\t%d bytes\t%d runes\t\"%s\"\t%s\n",
+			i, offset, len(g), utf8.RuneCountInString(g), Escape(g), printable(g))
+		offset += len(g)
+	}
+	tw.Flush()
+
+	fmt.Fprintln(w, "\nnormalization forms:")
+	tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, f := range r.Forms {
+		same := "differs from input"
+		if f.Same {
+			same = "same as input"
+		}
+		fmt.Fprintf(tw, "  %s\t%d bytes\t%d runes\t%s\t\"%s\"\t%s\n", f.Name, f.Bytes, f.Runes, same, f.Escape, printable(f.Text))
+	}
+	return tw.Flush()
+}
+
+// hexdump writes b 16 bytes to a line, with the printable ASCII bytes
+// alongside.
+func hexdump(w io.Writer, b []byte) {
+	if len(b) == 0 {
+		fmt.Fprintln(w, "  (empty)")
+		return
+	}
+	for i := 0; i < len(b); i += 16 {
+		line := b[i:min(i+16, len(b))]
+		var ascii strings.Builder
+		for _, c := range line {
+			if c >= 0x20 && c < 0x7F {
+				ascii.WriteByte(c)
+			} else {
+				ascii.WriteByte('.')
+			}
+		}
+		fmt.Fprintf(w, "  %04x  %-47s  %s\n", i, fmt.Sprintf("% x", line), ascii.String())
+	}
+}
+
+// bits shows how a UTF-8 sequence carries its code point: each byte
+// split into the marker bits that give its role and the payload bits
+// that, concatenated, are the code point.
+func bits(enc []byte) string {
+	markers := map[int]int{1: 1, 2: 3, 3: 4, 4: 5} // lead marker length by sequence length
+	parts := make([]string, len(enc))
+	for i, b := range enc {
+		m := 2 // continuation byte: 10|xxxxxx
+		if i == 0 {
+			m = markers[len(enc)]
+		}
+		s := fmt.Sprintf("%08b", b)
+		parts[i] = s[:m] + "|" + s[m:]
+	}
+	return strings.Join(parts, " ")
+}
+
+// display returns r in a form that can be shown in a terminal: combining
+// marks on a dotted circle, and escapes for controls and other invisible
+// runes.
+func display(r rune) string {
+	switch {
+	case unicode.In(r, unicode.Mn, unicode.Me):
+		return "\u25cc" + string(r) // dotted circle
+	case unicode.IsGraphic(r) && !unicode.IsSpace(r):
+		return string(r)
+	case r < 0x80:
+		q := strconv.QuoteRune(r) // '\n', ' '
+		return q[1 : len(q)-1]
+	}
+	return Escape(string(r))
+}
+
+// printable replaces invalid UTF-8 with U+FFFD. Besides being what a
+// terminal would show anyway, this keeps byte 0xff away from tabwriter,
+// which uses it as its escape character.
+func printable(s string) string {
+	return strings.ToValidUTF8(s, "\uFFFD")
+}
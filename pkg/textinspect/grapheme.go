@@ -0,0 +1,136 @@
+package textinspect
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// Graphemes splits s into grapheme clusters, the units a reader sees as
+// one character: a letter with its combining accents, an emoji with its
+// skin-tone modifier, a ZWJ family sequence, a flag made of two regional
+// indicators, or a Hangul syllable spelled with jamo.
+//
+// It implements the common rules of Unicode's extended grapheme cluster
+// boundaries (UAX #29) with approximate property tables; rare cases such
+// as Indic conjuncts and prepended marks may split differently from a
+// full implementation. Each invalid byte is a cluster of its own.
+func Graphemes(s string) []string {
+	var out []string
+	start := 0
+	var prev rune = -1
+	riRun := 0 // regional indicators in a row ending at prev
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			r = -2 // breaks on both sides, like a control
+		}
+		if prev != -1 && isBoundary(prev, r, riRun) {
+			out = append(out, s[start:i])
+			start = i
+		}
+		if isRegional(r) {
+			riRun++
+		} else {
+			riRun = 0
+		}
+		prev = r
+		i += size
+	}
+	if start < len(s) {
+		out = append(out, s[start:])
+	}
+	return out
+}
+
+// isBoundary reports whether there is a cluster boundary between a and
+// b, where riRun counts the regional indicators ending at a.
+func isBoundary(a, b rune, riRun int) bool {
+	switch {
+	case a == '\r' && b == '\n': // GB3
+		return false
+	case isControl(a) || isControl(b): // GB4, GB5
+		return true
+	case hangulJoins(a, b): // GB6-GB8
+		return false
+	case isExtend(b) || b == zwj || isSpacingMark(b): // GB9, GB9a
+		return false
+	case a == zwj && isPictographic(b): // GB11, without checking what precedes the ZWJ
+		return false
+	case isRegional(a) && isRegional(b): // GB12, GB13: flags are pairs
+		return riRun%2 == 0
+	}
+	return true // GB999
+}
+
+// zwj is the zero width joiner that glues emoji into one sequence.
+const zwj = '\u200d'
+
+func isControl(r rune) bool {
+	return r < 0 || r == '\r' || r == '\n' ||
+		(unicode.In(r, unicode.Cc, unicode.Zl, unicode.Zp)) ||
+		(unicode.Is(unicode.Cf, r) && r != zwj && r != '\u200c' && !isTag(r))
+}
+
+func isExtend(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me) ||
+		r == '\u200c' || // zero width non-joiner
+		(r >= 0x1F3FB && r <= 0x1F3FF) || // emoji skin-tone modifiers
+		isTag(r)
+}
+
+// isTag reports whether r is a tag character, as used in subdivision
+// flags such as England's.
+func isTag(r rune) bool { return r >= 0xE0020 && r <= 0xE007F }
+
+func isSpacingMark(r rune) bool { return unicode.Is(unicode.Mc, r) }
+
+func isRegional(r rune) bool { return r >= 0x1F1E6 && r <= 0x1F1FF }
+
+// isPictographic approximates the Extended_Pictographic property with
+// the symbol blocks that hold emoji.
+func isPictographic(r rune) bool {
+	return (r >= 0x1F000 && r <= 0x1FAFF) || (r >= 0x2600 && r <= 0x27BF) ||
+		(r >= 0x2190 && r <= 0x21FF) || (r >= 0x2B00 && r <= 0x2BFF) ||
+		r == 0x00A9 || r == 0x00AE || r == 0x203C || r == 0x2049 || r == 0x2122
+}
+
+// Hangul syllable types: leading consonant, vowel, trailing consonant,
+// and precomposed LV and LVT syllables.
+const (
+	hangulNone = iota
+	hangulL
+	hangulV
+	hangulT
+	hangulLV
+	hangulLVT
+)
+
+func hangulType(r rune) int {
+	switch {
+	case r >= 0x1100 && r <= 0x115F, r >= 0xA960 && r <= 0xA97C:
+		return hangulL
+	case r >= 0x1160 && r <= 0x11A7, r >= 0xD7B0 && r <= 0xD7C6:
+		return hangulV
+	case r >= 0x11A8 && r <= 0x11FF, r >= 0xD7CB && r <= 0xD7FB:
+		return hangulT
+	case r >= 0xAC00 && r <= 0xD7A3:
+		if (r-0xAC00)%28 == 0 {
+			return hangulLV
+		}
+		return hangulLVT
+	}
+	return hangulNone
+}
+
+func hangulJoins(a, b rune) bool {
+	ta, tb := hangulType(a), hangulType(b)
+	switch ta {
+	case hangulL:
+		return tb == hangulL || tb == hangulV || tb == hangulLV || tb == hangulLVT
+	case hangulLV, hangulV:
+		return tb == hangulV || tb == hangulT
+	case hangulLVT, hangulT:
+		return tb == hangulT
+	}
+	return false
+}
@@ -0,0 +1,219 @@
+// Package textinspect takes a string apart: its bytes, the runes they
+// decode to, the UTF-8 encoding of each rune bit by bit, the positions
+// of bytes that are not valid UTF-8, the user-perceived characters
+// (grapheme clusters) and the four Unicode normalization forms.
+//
+// These are the layers that string bugs hide between. len("é") is 2 or
+// 3 depending on how the é was typed; "👍🏽" is 8 bytes, 2 runes and one
+// character; s[:n] can cut a rune in half; and two strings that print
+// the same can still compare unequal because one is NFC and the other
+// NFD. Inspect shows all of it, and `demo inspect` prints the result.
+package textinspect
+
+import (
+	"fmt"
+	"slices"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+	"golang.org/x/text/unicode/runenames"
+)
+
+// Report describes a string.
+type Report struct {
+	Input     string    `json:"input"`
+	Escaped   string    `json:"escaped"` // Input with non-ASCII escaped, see Escape
+	Bytes     Hex       `json:"bytes"`
+	Valid     bool      `json:"valid_utf8"`
+	Runes     []Rune    `json:"runes"`
+	Invalid   []Invalid `json:"invalid,omitempty"`
+	Graphemes []string  `json:"graphemes"`
+	Forms     []Form    `json:"forms"`
+}
+
+// Rune is one decoded position of a string: a rune, or an invalid byte
+// that decodes to utf8.RuneError.
+type Rune struct {
+	Offset   int    `json:"offset"` // byte offset in the input
+	Rune     rune   `json:"rune"`
+	Encoding Hex    `json:"encoding"`
+	Valid    bool   `json:"valid"`
+	Category string `json:"category,omitempty"` // general category, such as "Lu"
+	Name     string `json:"name,omitempty"`
+}
+
+// Hex is a byte slice that encodes to JSON as hex ("c3 a9") rather
+// than base64.
+type Hex []byte
+
+// MarshalText implements encoding.TextMarshaler.
+func (h Hex) MarshalText() ([]byte, error) {
+	return fmt.Appendf(nil, "% x", []byte(h)), nil
+}
+
+// Invalid is a byte that does not start a valid UTF-8 sequence.
+type Invalid struct {
+	Offset int    `json:"offset"`
+	Byte   byte   `json:"byte"`
+	Reason string `json:"reason"`
+}
+
+// Form is the input in one Unicode normalization form.
+type Form struct {
+	Name   string `json:"name"` // NFC, NFD, NFKC or NFKD
+	Text   string `json:"text"`
+	Bytes  int    `json:"bytes"`
+	Runes  int    `json:"runes"`
+	Same   bool   `json:"same_as_input"`
+	Escape string `json:"escaped"` // Text with every non-ASCII rune escaped
+}
+
+// Inspect returns the Report for s.
+func Inspect(s string) Report {
+	r := Report{
+		Input:     s,
+		Escaped:   Escape(s),
+		Bytes:     Hex(s),
+		Valid:     utf8.ValidString(s),
+		Graphemes: Graphemes(s),
+	}
+	seq, end := 0, 0 // offsets of the last invalid sequence explained
+	for i := 0; i < len(s); {
+		c, size := utf8.DecodeRuneInString(s[i:])
+		info := Rune{Offset: i, Rune: c, Encoding: Hex(s[i : i+size]), Valid: c != utf8.RuneError || size > 1}
+		switch {
+		case info.Valid:
+			info.Category = Category(c)
+			info.Name = Name(c)
+		case i < end:
+			// The decoder resyncs one byte at a time; this byte was
+			// already explained with its lead byte
+			r.Invalid = append(r.Invalid, Invalid{Offset: i, Byte: s[i],
+				Reason: fmt.Sprintf("continuation byte of the invalid sequence at offset %d", seq)})
+		default:
+			reason, n := invalidReason(s[i:])
+			seq, end = i, i+n
+			r.Invalid = append(r.Invalid, Invalid{Offset: i, Byte: s[i], Reason: reason})
+		}
+		r.Runes = append(r.Runes, info)
+		i += size
+	}
+	for _, f := range []norm.Form{norm.NFC, norm.NFD, norm.NFKC, norm.NFKD} {
+		text := f.String(s)
+		r.Forms = append(r.Forms, Form{
+			Name:   formName(f),
+			Text:   text,
+			Bytes:  len(text),
+			Runes:  utf8.RuneCountInString(text),
+			Same:   text == s,
+			Escape: Escape(text),
+		})
+	}
+	return r
+}
+
+func formName(f norm.Form) string {
+	return [...]string{norm.NFC: "NFC", norm.NFD: "NFD", norm.NFKC: "NFKC", norm.NFKD: "NFKD"}[f]
+}
+
+// invalidReason explains why the UTF-8 sequence at the start of s is
+// invalid, and returns how many bytes of s the explanation covers: the
+// lead byte and the continuation bytes that follow it.
+func invalidReason(s string) (reason string, size int) {
+	b := s[0]
+	need := 0
+	switch {
+	case b&0xC0 == 0x80:
+		return "continuation byte (10xxxxxx) not preceded by a valid lead byte", 1
+	case b == 0xC0 || b == 0xC1:
+		return "lead byte of an overlong 2-byte encoding of an ASCII character", 1
+	case b >= 0xF5:
+		return "byte never used in UTF-8 (lead byte above U+10FFFF)", 1
+	case b&0xE0 == 0xC0:
+		need = 2
+	case b&0xF0 == 0xE0:
+		need = 3
+	default:
+		need = 4
+	}
+	have := 1
+	for have < need && have < len(s) && s[have]&0xC0 == 0x80 {
+		have++
+	}
+	// Four lead bytes take a narrower range of second bytes. Outside it
+	// the prefix is wrong already, however many bytes follow.
+	if have > 1 {
+		switch {
+		case b == 0xE0 && s[1] < 0xA0, b == 0xF0 && s[1] < 0x90:
+			return fmt.Sprintf("overlong: starts a %d-byte encoding of a code point that fits in fewer bytes", need), have
+		case b == 0xED && s[1] >= 0xA0:
+			return "starts a UTF-16 surrogate (U+D800-U+DFFF), which is not a valid code point", have
+		case b == 0xF4 && s[1] >= 0x90:
+			return "starts a value above U+10FFFF, the largest code point", have
+		}
+	}
+	if have == len(s) {
+		return fmt.Sprintf("truncated: lead byte of a %d-byte sequence at the end of the input, %d byte(s) missing", need, need-have), have
+	}
+	return fmt.Sprintf("lead byte of a %d-byte sequence followed by %d continuation byte(s) instead of %d", need, have-1, need-1), have
+}
+
+// categories are the two-letter general categories, sorted so that
+// Category is deterministic.
+var categories = func() []string {
+	var names []string
+	for name := range unicode.Categories {
+		if len(name) == 2 && name != "LC" { // LC is Lu, Ll and Lt together
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}()
+
+// Category returns the Unicode general category of r, such as "Lu"
+// (uppercase letter), "Mn" (combining mark) or "Cc" (control), or "Cn"
+// for an unassigned code point.
+func Category(r rune) string {
+	for _, name := range categories {
+		if unicode.Is(unicode.Categories[name], r) {
+			return name
+		}
+	}
+	return "Cn"
+}
+
+// Name returns the Unicode character name of r, such as "LATIN SMALL
+// LETTER E WITH ACUTE". Runes without a name get their category in
+// angle brackets, such as "<Co>" for private use.
+func Name(r rune) string {
+	if name := runenames.Name(r); name != "" {
+		return name
+	}
+	return fmt.Sprintf("<%s>", Category(r))
+}
+
+// Escape returns s with every rune outside printable ASCII written as a
+// Go escape (\u00e9, \U0001f44d, \xff for invalid bytes), so that strings
+// that look identical can be told apart.
+func Escape(s string) string {
+	var out []byte
+	for i := 0; i < len(s); {
+		c, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case c == utf8.RuneError && size == 1:
+			out = fmt.Appendf(out, `\x%02x`, s[i])
+		case c == '\\':
+			out = append(out, `\\`...)
+		case c >= 0x20 && c < 0x7F:
+			out = append(out, byte(c))
+		case c <= 0xFFFF:
+			out = fmt.Appendf(out, `\u%04x`, c)
+		default:
+			out = fmt.Appendf(out, `\U%08x`, c)
+		}
+		i += size
+	}
+	return string(out)
+}
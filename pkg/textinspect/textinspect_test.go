@@ -0,0 +1,135 @@
+package textinspect_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"golang-learning-project/pkg/textinspect"
+)
+
+func TestInspect(t *testing.T) {
+	r := textinspect.Inspect("e\u0301\U0001f44d\U0001f3fd")
+	if !r.Valid || len(r.Bytes) != 11 || len(r.Runes) != 4 || len(r.Graphemes) != 2 || r.Invalid != nil {
+		t.Errorf("Inspect: valid %v, %d bytes, %d runes, %d graphemes, invalid %v",
+			r.Valid, len(r.Bytes), len(r.Runes), len(r.Graphemes), r.Invalid)
+	}
+	want := []textinspect.Rune{
+		{Offset: 0, Rune: 'e', Encoding: textinspect.Hex("e"), Valid: true, Category: "Ll", Name: "LATIN SMALL LETTER E"},
+		{Offset: 1, Rune: 0x301, Encoding: textinspect.Hex("\u0301"), Valid: true, Category: "Mn", Name: "COMBINING ACUTE ACCENT"},
+		{Offset: 3, Rune: 0x1F44D, Encoding: textinspect.Hex("\U0001f44d"), Valid: true, Category: "So", Name: "THUMBS UP SIGN"},
+		{Offset: 7, Rune: 0x1F3FD, Encoding: textinspect.Hex("\U0001f3fd"), Valid: true, Category: "Sk", Name: "EMOJI MODIFIER FITZPATRICK TYPE-4"},
+	}
+	for i, got := range r.Runes {
+		if got.Offset != want[i].Offset || got.Rune != want[i].Rune || string(got.Encoding) != string(want[i].Encoding) ||
+			got.Category != want[i].Category || got.Name != want[i].Name {
+			t.Errorf("rune %d = %+v, want %+v", i, got, want[i])
+		}
+	}
+
+	forms := map[string]struct {
+		runes int
+		same  bool
+	}{"NFC": {3, false}, "NFD": {4, true}, "NFKC": {3, false}, "NFKD": {4, true}}
+	for _, f := range r.Forms {
+		if w := forms[f.Name]; f.Runes != w.runes || f.Same != w.same {
+			t.Errorf("%s: %d runes, same %v; want %d, %v", f.Name, f.Runes, f.Same, w.runes, w.same)
+		}
+	}
+	if r.Forms[0].Escape != `\u00e9\U0001f44d\U0001f3fd` {
+		t.Errorf("NFC escaped = %q", r.Forms[0].Escape)
+	}
+}
+
+func TestInvalid(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string // the reason for each invalid byte, by substring
+	}{
+		{"\x80", []string{"continuation byte (10xxxxxx) not preceded"}},
+		{"\xc1\xbf", []string{"overlong 2-byte encoding of an ASCII character", "not preceded"}},
+		{"\xff", []string{"never used in UTF-8"}},
+		{"a\xe2\x82", []string{"truncated: lead byte of a 3-byte sequence at the end of the input, 1 byte(s) missing", "sequence at offset 1"}},
+		{"\xf0\x9f\x91", []string{"truncated: lead byte of a 4-byte sequence", "sequence at offset 0", "sequence at offset 0"}},
+		{"\xe2\x82x", []string{"followed by 1 continuation byte(s) instead of 2", "sequence at offset 0"}},
+		{"\xe2x\x82", []string{"followed by 0 continuation byte(s) instead of 2", "not preceded"}},
+
+		// The second byte is checked before the rest are counted
+		{"\xe0\x80", []string{"overlong: starts a 3-byte encoding", "continuation byte of the invalid sequence at offset 0"}},
+		{"\xe0\x80\x80", []string{"overlong: starts a 3-byte encoding", "sequence at offset 0", "sequence at offset 0"}},
+		{"\xf0\x8f\xbf\xbf", []string{"overlong: starts a 4-byte encoding", "sequence at offset 0", "sequence at offset 0", "sequence at offset 0"}},
+		{"\xed\xa0", []string{"UTF-16 surrogate", "sequence at offset 0"}},
+		{"\xed\xa0\x80", []string{"UTF-16 surrogate", "sequence at offset 0", "sequence at offset 0"}},
+		{"\xf4\x90", []string{"above U+10FFFF", "sequence at offset 0"}},
+		{"\xf4\x90\x80\x80", []string{"above U+10FFFF", "sequence at offset 0", "sequence at offset 0", "sequence at offset 0"}},
+
+		// The last byte of the range is fine, and a new lead byte starts over
+		{"\xed\x9f\xbf\xe0\x80", []string{"overlong", "sequence at offset 3"}},
+	}
+	for _, tt := range tests {
+		r := textinspect.Inspect(tt.in)
+		if r.Valid {
+			t.Errorf("Inspect(%q) is valid", tt.in)
+		}
+		var got []string
+		for _, bad := range r.Invalid {
+			got = append(got, bad.Reason)
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("Inspect(%q) invalid bytes: %q, want %d", tt.in, got, len(tt.want))
+			continue
+		}
+		for i, want := range tt.want {
+			if !strings.Contains(got[i], want) {
+				t.Errorf("Inspect(%q) byte at offset %d: %q, want %q", tt.in, r.Invalid[i].Offset, got[i], want)
+			}
+		}
+	}
+}
+
+func TestEscape(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"plain ASCII", "plain ASCII"},
+		{`a\b`, `a\\b`},
+		{"tab\there\n", `tab\u0009here\u000a`},
+		{"\u00e9", `\u00e9`},
+		{"e\u0301", `e\u0301`},
+		{"\U0001f44d", `\U0001f44d`},
+		{"\xff\xe0\x80", `\xff\xe0\x80`},
+		{"\u00a0", `\u00a0`},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := textinspect.Escape(tt.in); got != tt.want {
+			t.Errorf("Escape(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestGraphemes(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want []string
+	}{
+		{"ASCII", "abc", []string{"a", "b", "c"}},
+		{"combining accents", "e\u0301\u0323x", []string{"e\u0301\u0323", "x"}},
+		{"skin-tone modifier", "\U0001f44d\U0001f3fd\U0001f44d", []string{"\U0001f44d\U0001f3fd", "\U0001f44d"}},
+		{"ZWJ family", "\U0001f468\u200d\U0001f469\u200d\U0001f467!", []string{"\U0001f468\u200d\U0001f469\u200d\U0001f467", "!"}},
+		{"ZWJ with a modifier", "\U0001f469\U0001f3fd\u200d\U0001f4bb", []string{"\U0001f469\U0001f3fd\u200d\U0001f4bb"}},
+		{"flags", "\U0001f1ef\U0001f1f5\U0001f1eb\U0001f1f7", []string{"\U0001f1ef\U0001f1f5", "\U0001f1eb\U0001f1f7"}},
+		{"odd regional indicator", "\U0001f1ef\U0001f1f5\U0001f1eb", []string{"\U0001f1ef\U0001f1f5", "\U0001f1eb"}},
+		{"subdivision flag", "\U0001f3f4\U000E0067\U000E0062\U000E0065\U000E006E\U000E0067\U000E007F", []string{"\U0001f3f4\U000E0067\U000E0062\U000E0065\U000E006E\U000E0067\U000E007F"}},
+		{"Hangul precomposed", "\ud55c\uae00", []string{"\ud55c", "\uae00"}},
+		{"Hangul jamo", "\u1112\u1161\u11ab\u1100\u1173", []string{"\u1112\u1161\u11ab", "\u1100\u1173"}},
+		{"CRLF", "a\r\nb\n\rc", []string{"a", "\r\n", "b", "\n", "\r", "c"}},
+		{"accent after a newline", "\n\u0301", []string{"\n", "\u0301"}},
+		{"invalid bytes", "a\xff\u0301", []string{"a", "\xff", "\u0301"}},
+		{"empty", "", nil},
+	}
+	for _, tt := range tests {
+		if got := textinspect.Graphemes(tt.in); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: Graphemes(%q) = %q, want %q", tt.name, tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package textstat
+
+import (
+	"bufio"
+	"io"
+	"slices"
+	"strings"
+
+	"golang-learning-project/pkg/collections"
+)
+
+// Options configures an Analyzer.
+type Options struct {
+	// TopK is the number of terms Top returns. Defaults to 10.
+	TopK int
+
+	// Fold case folds every word, so "The" and "the" count as one.
+	Fold bool
+
+	// StopWords are dropped before counting. They should already be
+	// case folded when Fold is set.
+	StopWords *collections.Set[string]
+
+	// NGram counts runs of N consecutive words, joined by a space,
+	// instead of single words. Defaults to 1.
+	NGram int
+
+	// MaxMemory is an approximate budget in bytes for the counts. Past
+	// it the Analyzer stops counting exactly and switches to a
+	// count-min sketch of about that size. 0 means no limit.
+	MaxMemory int64
+}
+
+// Entry is a term and its count.
+type Entry struct {
+	Term  string `json:"term"`
+	Count int    `json:"count"`
+}
+
+// entryOverhead approximates the bytes an exact count costs besides the
+// term itself: map slot, string header, counter struct and pointer.
+const entryOverhead = 96
+
+// sketchDepth is the number of rows of the count-min sketch.
+const sketchDepth = 4
+
+// Analyzer counts the terms of everything read through it.
+type Analyzer struct {
+	opts   Options
+	tokens int      // words kept after stop-word filtering
+	window []string // the last NGram-1 words, for n-grams
+
+	// Exact counting, until the budget is reached.
+	exact *collections.Counter[string]
+	bytes int64
+
+	// Approximate counting afterwards.
+	sketch *CountMinSketch
+	top    *topK
+}
+
+// New returns an Analyzer for opts.
+func New(opts Options) *Analyzer {
+	if opts.TopK <= 0 {
+		opts.TopK = 10
+	}
+	if opts.NGram <= 0 {
+		opts.NGram = 1
+	}
+	return &Analyzer{opts: opts, exact: new(collections.Counter[string])}
+}
+
+// Read tokenizes r with ScanWords and counts its terms. N-grams do not
+// span separate calls to Read.
+func (a *Analyzer) Read(r io.Reader) error {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	sc.Split(ScanWords)
+	a.window = a.window[:0]
+	for sc.Scan() {
+		a.Add(sc.Text())
+	}
+	return sc.Err()
+}
+
+// Add counts one word, applying folding, stop words and n-grams.
+func (a *Analyzer) Add(word string) {
+	if a.opts.Fold {
+		word = Fold(word)
+	}
+	if a.opts.StopWords != nil && a.opts.StopWords.Has(word) {
+		return
+	}
+	a.tokens++
+	if a.opts.NGram == 1 {
+		a.count(word)
+		return
+	}
+	a.window = append(a.window, word)
+	if len(a.window) < a.opts.NGram {
+		return
+	}
+	a.count(strings.Join(a.window, " "))
+	a.window = slices.Delete(a.window, 0, 1)
+}
+
+func (a *Analyzer) count(term string) {
+	if a.sketch != nil {
+		a.top.offer(term, a.sketch.Add(term, 1))
+		return
+	}
+	if a.exact.Count(term) == 0 {
+		// Copy the term: a caller of Add may pass a substring that
+		// would otherwise keep a much larger string alive.
+		term = strings.Clone(term)
+		a.bytes += int64(len(term)) + entryOverhead
+	}
+	a.exact.Add(term)
+	if a.opts.MaxMemory > 0 && a.bytes > a.opts.MaxMemory {
+		a.switchToSketch()
+	}
+}
+
+// switchToSketch moves the exact counts into a count-min sketch and a
+// top-K heap, and drops them.
+func (a *Analyzer) switchToSketch() {
+	width := max(1024, int(a.opts.MaxMemory/(4*sketchDepth)))
+	a.sketch = NewCountMinSketch(width, sketchDepth)
+	a.top = newTopK(a.opts.TopK)
+	for term, n := range a.exact.All() {
+		a.top.offer(term, a.sketch.Add(term, n))
+	}
+	a.exact = nil
+}
+
+// Tokens returns the number of words counted, after stop words were
+// dropped.
+func (a *Analyzer) Tokens() int { return a.tokens }
+
+// Approximate reports whether the memory budget was exceeded, so that
+// counts are count-min estimates: never too low, possibly too high.
+func (a *Analyzer) Approximate() bool { return a.sketch != nil }
+
+// Distinct returns the number of distinct terms, or -1 once counting is
+// approximate.
+func (a *Analyzer) Distinct() int {
+	if a.exact == nil {
+		return -1
+	}
+	return a.exact.Len()
+}
+
+// Top returns the Options.TopK most frequent terms, most frequent first;
+// ties are broken alphabetically.
+func (a *Analyzer) Top() []Entry {
+	var out []Entry
+	if a.exact != nil {
+		// Rank every term, so ties at the cut-off are broken by term
+		// rather than by first appearance.
+		for _, e := range a.exact.MostCommon(-1) {
+			out = append(out, Entry{Term: e.Item, Count: e.Count})
+		}
+	} else {
+		out = slices.Clone(a.top.items)
+	}
+	slices.SortFunc(out, func(x, y Entry) int {
+		if x.Count != y.Count {
+			return y.Count - x.Count
+		}
+		return strings.Compare(x.Term, y.Term)
+	})
+	return out[:min(len(out), a.opts.TopK)]
+}
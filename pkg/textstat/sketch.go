@@ -0,0 +1,136 @@
+package textstat
+
+import (
+	"container/heap"
+	"fmt"
+	"hash/maphash"
+	"math"
+)
+
+// MaxSketchDepth is the most rows a CountMinSketch may have. Each row
+// halves the chance of a large overestimate, so 16 is far more than is
+// ever useful.
+const MaxSketchDepth = 16
+
+// CountMinSketch estimates how often each key was added using a fixed
+// depth × width table of counters, however many distinct keys there are.
+// An estimate is never below the true count; with width w and depth d it
+// exceeds it by more than 2N/w (N being the total added) with
+// probability at most 1/2^d.
+type CountMinSketch struct {
+	width  uint64
+	depth  int
+	counts []uint32 // depth rows of width counters
+	seed   maphash.Seed
+}
+
+// NewCountMinSketch returns a sketch with the given table size. It
+// panics unless width > 0 and 1 <= depth <= MaxSketchDepth.
+func NewCountMinSketch(width, depth int) *CountMinSketch {
+	if width <= 0 || depth < 1 || depth > MaxSketchDepth {
+		panic(fmt.Sprintf("textstat: invalid count-min sketch size %dx%d", depth, width))
+	}
+	return &CountMinSketch{
+		width:  uint64(width),
+		depth:  depth,
+		counts: make([]uint32, width*depth),
+		seed:   maphash.MakeSeed(),
+	}
+}
+
+// Bytes returns the size of the counter table.
+func (s *CountMinSketch) Bytes() int { return len(s.counts) * 4 }
+
+// Add counts key n more times and returns its new estimate. It uses
+// conservative update: only the counters that would fall below the new
+// estimate are raised, which makes overestimates much smaller than with
+// plain increments.
+func (s *CountMinSketch) Add(key string, n int) int {
+	var idx [MaxSketchDepth]uint64
+	est := s.indexes(key, idx[:s.depth])
+	next := min(uint64(est)+uint64(n), math.MaxUint32)
+	for _, i := range idx[:s.depth] {
+		if uint64(s.counts[i]) < next {
+			s.counts[i] = uint32(next)
+		}
+	}
+	return int(next)
+}
+
+// Estimate returns the estimated count of key.
+func (s *CountMinSketch) Estimate(key string) int {
+	var idx [MaxSketchDepth]uint64
+	return int(s.indexes(key, idx[:s.depth]))
+}
+
+// indexes fills idx with the counter of key in each row, derived from
+// one 64-bit hash by double hashing, and returns the smallest of them.
+func (s *CountMinSketch) indexes(key string, idx []uint64) uint32 {
+	h := maphash.String(s.seed, key)
+	h1, h2 := h&math.MaxUint32, h>>32|1
+	est := uint32(math.MaxUint32)
+	for row := range idx {
+		i := uint64(row)*s.width + (h1+uint64(row)*h2)%s.width
+		idx[row] = i
+		est = min(est, s.counts[i])
+	}
+	return est
+}
+
+// topK keeps the k terms with the highest counts seen so far, as a
+// min-heap on count so the weakest candidate is at the root.
+type topK struct {
+	k     int
+	items []Entry
+	index map[string]int // term -> position in items
+}
+
+func newTopK(k int) *topK {
+	return &topK{k: k, index: make(map[string]int, k)}
+}
+
+// offer records that term now has count, keeping it if it is among the
+// top k.
+func (t *topK) offer(term string, count int) {
+	if i, ok := t.index[term]; ok {
+		t.items[i].Count = count
+		heap.Fix(t, i)
+		return
+	}
+	if len(t.items) < t.k {
+		heap.Push(t, Entry{Term: term, Count: count})
+		return
+	}
+	if count <= t.items[0].Count {
+		return
+	}
+	delete(t.index, t.items[0].Term)
+	t.items[0] = Entry{Term: term, Count: count}
+	t.index[term] = 0
+	heap.Fix(t, 0)
+}
+
+// heap.Interface.
+func (t *topK) Len() int { return len(t.items) }
+func (t *topK) Less(i, j int) bool {
+	if t.items[i].Count != t.items[j].Count {
+		return t.items[i].Count < t.items[j].Count
+	}
+	return t.items[i].Term > t.items[j].Term
+}
+func (t *topK) Swap(i, j int) {
+	t.items[i], t.items[j] = t.items[j], t.items[i]
+	t.index[t.items[i].Term] = i
+	t.index[t.items[j].Term] = j
+}
+func (t *topK) Push(x any) {
+	e := x.(Entry)
+	t.index[e.Term] = len(t.items)
+	t.items = append(t.items, e)
+}
+func (t *topK) Pop() any {
+	e := t.items[len(t.items)-1]
+	t.items = t.items[:len(t.items)-1]
+	delete(t.index, e.Term)
+	return e
+}
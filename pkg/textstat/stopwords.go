@@ -0,0 +1,41 @@
+package textstat
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	"golang-learning-project/pkg/collections"
+)
+
+// english is a short list of the most frequent English function words.
+const english = `a about above after again against all am an and any are as at
+be because been before being below between both but by can could did do does
+doing down during each few for from further had has have having he her here
+hers herself him himself his how i if in into is it its itself just me more
+most my myself no nor not now of off on once only or other our ours ourselves
+out over own same she should so some such than that the their theirs them
+themselves then there these they this those through to too under until up
+very was we were what when where which while who whom why will with would you
+your yours yourself yourselves`
+
+// EnglishStopWords returns a new set of common English words that carry
+// little meaning in a frequency count.
+func EnglishStopWords() *collections.Set[string] {
+	return collections.NewSet(strings.Fields(english)...)
+}
+
+// ReadStopWords reads a stop-word list with one word per line. Blank
+// lines and lines starting with # are ignored; words are case folded.
+func ReadStopWords(r io.Reader) (*collections.Set[string], error) {
+	words := collections.NewSet[string]()
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		words.Add(Fold(line))
+	}
+	return words, sc.Err()
+}
@@ -0,0 +1,191 @@
+package textstat_test
+
+import (
+	"bufio"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"golang-learning-project/pkg/textstat"
+)
+
+// words splits text with ScanWords, reading one byte at a time when
+// slow is set so that every word and rune is split across reads.
+func words(text string, slow bool) []string {
+	r := strings.NewReader(text)
+	sc := bufio.NewScanner(r)
+	if slow {
+		sc = bufio.NewScanner(iotest.OneByteReader(r))
+	}
+	sc.Split(textstat.ScanWords)
+	var out []string
+	for sc.Scan() {
+		out = append(out, sc.Text())
+	}
+	return out
+}
+
+func TestScanWords(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want []string
+	}{
+		{"spaces and punctuation", "  Hello, world!  Go.", []string{"Hello", "world", "Go"}},
+		{"apostrophes", "don't it’s 'quoted' rock'n'roll", []string{"don't", "it’s", "quoted", "rock'n'roll"}},
+		{"trailing apostrophe", "the dogs' bowls", []string{"the", "dogs", "bowls"}},
+		{"hyphens", "well-known x-ray - dash -- double- trailing", []string{"well-known", "x-ray", "dash", "double", "trailing"}},
+		{"digits and underscores", "go1.24 snake_case 42", []string{"go1", "24", "snake_case", "42"}},
+		{"accents and marks", "café naïve é", []string{"café", "naïve", "é"}},
+		{"CJK one per character", "日本語のテキスト", []string{"日", "本", "語", "の", "テ", "キ", "ス", "ト"}},
+		{"CJK next to Latin", "Go言語 is fun", []string{"Go", "言", "語", "is", "fun"}},
+		{"Cyrillic and Greek", "Привет мир, γειά σου", []string{"Привет", "мир", "γειά", "σου"}},
+		{"empty", " ,.!? ", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := words(tt.in, false); !slices.Equal(got, tt.want) {
+				t.Errorf("ScanWords(%q) = %q; want %q", tt.in, got, tt.want)
+			}
+			if got := words(tt.in, true); !slices.Equal(got, tt.want) {
+				t.Errorf("ScanWords(%q), one byte at a time = %q; want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFold(t *testing.T) {
+	tests := []struct{ a, b string }{
+		{"Go", "go"},
+		{"GO", "go"},
+		{"ΣΊΣΥΦΟΣ", "σίσυφος"},
+		{"K", "k"}, // Kelvin sign
+		{"ÉCOLE", "école"},
+	}
+	for _, tt := range tests {
+		if textstat.Fold(tt.a) != textstat.Fold(tt.b) {
+			t.Errorf("Fold(%q) = %q, Fold(%q) = %q; want equal", tt.a, textstat.Fold(tt.a), tt.b, textstat.Fold(tt.b))
+		}
+	}
+	// Simple folding maps rune to rune, so ß does not become ss.
+	if textstat.Fold("Straße") == textstat.Fold("STRASSE") {
+		t.Errorf("Fold folded Straße and STRASSE together")
+	}
+}
+
+func TestAnalyzer(t *testing.T) {
+	a := textstat.New(textstat.Options{
+		TopK:      3,
+		Fold:      true,
+		StopWords: textstat.EnglishStopWords(),
+	})
+	if err := a.Read(strings.NewReader("The cat and the Cat. A dog, the DOG, the dog! Bird.")); err != nil {
+		t.Fatal(err)
+	}
+	want := []textstat.Entry{{Term: "dog", Count: 3}, {Term: "cat", Count: 2}, {Term: "bird", Count: 1}}
+	if got := a.Top(); !slices.Equal(got, want) {
+		t.Errorf("Top() = %v; want %v", got, want)
+	}
+	if a.Tokens() != 6 || a.Distinct() != 3 || a.Approximate() {
+		t.Errorf("Tokens, Distinct, Approximate = %d, %d, %v; want 6, 3, false", a.Tokens(), a.Distinct(), a.Approximate())
+	}
+}
+
+func TestAnalyzerNGrams(t *testing.T) {
+	a := textstat.New(textstat.Options{NGram: 2, Fold: true})
+	a.Read(strings.NewReader("to be or not to be"))
+	want := []textstat.Entry{{Term: "to be", Count: 2}, {Term: "be or", Count: 1}, {Term: "not to", Count: 1}, {Term: "or not", Count: 1}}
+	if got := a.Top(); !slices.Equal(got, want) {
+		t.Errorf("Top() = %v; want %v", got, want)
+	}
+}
+
+// TestAnalyzerSketch pushes an Analyzer past its memory budget and
+// checks that the approximate counts still find the frequent terms and
+// never undercount them.
+func TestAnalyzerSketch(t *testing.T) {
+	a := textstat.New(textstat.Options{TopK: 5, MaxMemory: 64 << 10})
+	exact := map[string]int{}
+	add := func(w string) {
+		a.Add(w)
+		exact[w]++
+	}
+	// A long tail of rare words, with five frequent ones spread through
+	// it, some of them only appearing after the switch.
+	frequent := []string{"alpha", "bravo", "charlie", "delta", "echo"}
+	for i := range 20_000 {
+		add(fmt.Sprintf("rare%d", i))
+		if i%100 == 0 {
+			for j, w := range frequent {
+				if i >= j*2000 {
+					add(w)
+				}
+			}
+		}
+	}
+	if !a.Approximate() || a.Distinct() != -1 {
+		t.Fatalf("Approximate, Distinct = %v, %d; want true, -1", a.Approximate(), a.Distinct())
+	}
+	top := a.Top()
+	var terms []string
+	for _, e := range top {
+		terms = append(terms, e.Term)
+		if e.Count < exact[e.Term] {
+			t.Errorf("%s: estimate %d is below the true count %d", e.Term, e.Count, exact[e.Term])
+		}
+	}
+	if !slices.Equal(terms, frequent) {
+		t.Errorf("Top() = %v; want the terms %v", top, frequent)
+	}
+	n := 0
+	for _, c := range exact {
+		n += c
+	}
+	if a.Tokens() != n {
+		t.Errorf("Tokens() = %d; want %d", a.Tokens(), n)
+	}
+}
+
+func TestCountMinSketch(t *testing.T) {
+	s := textstat.NewCountMinSketch(64, 4)
+	exact := map[string]int{}
+	for i := range 2000 {
+		k := fmt.Sprint(i % 300)
+		n := i%7 + 1
+		exact[k] += n
+		if got := s.Add(k, n); got < exact[k] {
+			t.Fatalf("Add(%q, %d) = %d; want at least %d", k, n, got, exact[k])
+		}
+	}
+	for k, want := range exact {
+		if got := s.Estimate(k); got < want {
+			t.Errorf("Estimate(%q) = %d; want at least %d", k, got, want)
+		}
+	}
+	if got := s.Estimate("never added"); got < 0 {
+		t.Errorf("Estimate of a missing key = %d", got)
+	}
+	if s.Bytes() != 64*4*4 {
+		t.Errorf("Bytes() = %d; want %d", s.Bytes(), 64*4*4)
+	}
+	wide := textstat.NewCountMinSketch(1<<16, textstat.MaxSketchDepth)
+	wide.Add("x", 3)
+	if got := wide.Estimate("x"); got != 3 {
+		t.Errorf("Estimate in a sparse sketch = %d; want 3", got)
+	}
+}
+
+func TestCountMinSketchInvalidSize(t *testing.T) {
+	for _, size := range [][2]int{{0, 4}, {-1, 4}, {64, 0}, {64, textstat.MaxSketchDepth + 1}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("NewCountMinSketch(%d, %d) did not panic", size[0], size[1])
+				}
+			}()
+			textstat.NewCountMinSketch(size[0], size[1])
+		}()
+	}
+}
@@ -0,0 +1,113 @@
+// Package textstat counts words and n-grams in text of any size.
+//
+// Text is streamed through a Unicode-aware tokenizer, optionally case
+// folded and filtered through a stop-word list, and counted. Counting is
+// exact until the counts would outgrow a memory budget; from then on an
+// Analyzer switches to a count-min sketch plus a top-K heap, which keeps
+// memory fixed at the price of slightly overestimated counts.
+package textstat
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// ScanWords is a bufio.SplitFunc that returns words. A word is a run of
+// letters, combining marks, digits and connector punctuation (such as
+// '_'), and may contain an apostrophe or hyphen between two word
+// characters: "don't" and "well-known" are single words. Scripts that are
+// written without spaces (Han, Hiragana, Katakana, Thai, ...) have no
+// word boundaries a rule this simple can find, so each of their
+// characters is returned as a word of its own.
+func ScanWords(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	// Skip to the first word character.
+	start := 0
+	for start < len(data) {
+		if !atEOF && !utf8.FullRune(data[start:]) {
+			return start, nil, nil // a rune split across reads
+		}
+		r, size := utf8.DecodeRune(data[start:])
+		if isWordRune(r) {
+			break
+		}
+		start += size
+	}
+	if start == len(data) {
+		return start, nil, nil
+	}
+
+	if r, size := utf8.DecodeRune(data[start:]); isUnspaced(r) {
+		return start + size, data[start : start+size], nil
+	}
+
+	for i := start; i < len(data); {
+		r, size := utf8.DecodeRune(data[i:])
+		if isWordRune(r) && !isUnspaced(r) {
+			i += size
+			continue
+		}
+		if isJoiner(r) && i+size < len(data) {
+			next, _ := utf8.DecodeRune(data[i+size:])
+			if isWordRune(next) && !isUnspaced(next) {
+				i += size
+				continue
+			}
+		}
+		if isJoiner(r) && i+size == len(data) && !atEOF {
+			break // need more data to decide
+		}
+		if r == utf8.RuneError && size == 1 && !utf8.FullRune(data[i:]) && !atEOF {
+			break // a rune split across reads
+		}
+		return i, data[start:i], nil
+	}
+	if atEOF {
+		return len(data), data[start:], nil
+	}
+	// Request more data, keeping the partial word.
+	return start, nil, nil
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.Mn, r) ||
+		unicode.Is(unicode.Mc, r) || unicode.Is(unicode.Pc, r)
+}
+
+// isJoiner reports whether r may join two word characters into one word.
+func isJoiner(r rune) bool {
+	return r == '\'' || r == '’' || r == '-'
+}
+
+// isUnspaced reports whether r belongs to a script written without
+// spaces between words.
+func isUnspaced(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana,
+		unicode.Thai, unicode.Lao, unicode.Khmer, unicode.Myanmar)
+}
+
+// Fold returns s with simple Unicode case folding applied, so "Go", "GO"
+// and "go" fold to the same string, as do "ΣΊΣΥΦΟΣ" and "σίσυφος" with
+// its final sigma. Unlike full case folding it maps each rune to one
+// rune, so "Straße" and "STRASSE" stay different.
+func Fold(s string) string {
+	for _, r := range s {
+		if fold(r) != r {
+			return mapFold(s)
+		}
+	}
+	return s // nothing to fold; avoid the copy
+}
+
+func mapFold(s string) string {
+	out := make([]rune, 0, len(s))
+	for _, r := range s {
+		out = append(out, fold(r))
+	}
+	return string(out)
+}
+
+// fold maps every rune of a case-folding orbit, like {K, k, K (Kelvin)}
+// or {Σ, σ, ς}, to the same rune.
+func fold(r rune) rune {
+	return unicode.ToLower(unicode.ToUpper(r))
+}